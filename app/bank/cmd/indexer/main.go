@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/indexer"
+	proxy "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	single "github.com/adamwoolhether/smartcontract/app/bank/single/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/config"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+const (
+	defaultStoreFile = "zarf/ethereum/bank_indexer.json"
+)
+
+// contracts are the bank contracts by the name the deploy commands record
+// them under in the registry.
+var contracts = map[string]*bind.MetaData{
+	"bank":        proxy.BankMetaData,
+	"bank_single": single.BankMetaData,
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		return err
+	}
+
+	if cfg.PrintConfig {
+		fmt.Print(cfg)
+		return nil
+	}
+
+	storeFile := os.Getenv("INDEXER_STORE")
+	if storeFile == "" {
+		storeFile = defaultStoreFile
+	}
+
+	var startBlock uint64
+	startBlockSet := false
+	if v := os.Getenv("INDEXER_START_BLOCK"); v != "" {
+		var err error
+		if startBlock, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return fmt.Errorf("parsing start block: %w", err)
+		}
+		startBlockSet = true
+	}
+
+	// =========================================================================
+
+	backend, closeBackend, err := cfg.Backend(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	registry := cfg.Registry()
+
+	// Without a start block, indexing starts at the earliest deployment
+	// since nothing happened to the contracts before it.
+	var contractIDs []common.Address
+	for _, name := range []string{"bank", "bank_single"} {
+		d, err := registry.Lookup(ctx, backend, name, contracts[name])
+		if err != nil {
+			if errors.Is(err, ethereum.ErrNotDeployed) {
+				continue
+			}
+			return err
+		}
+
+		fmt.Println("contractID:", d.Address)
+		contractIDs = append(contractIDs, d.Address)
+
+		if !startBlockSet && (len(contractIDs) == 1 || d.Block < startBlock) {
+			startBlock = d.Block
+		}
+	}
+
+	if len(contractIDs) == 0 {
+		return fmt.Errorf("need to deploy the bank or bank_single contract, none are recorded in %s", registry.Path())
+	}
+
+	store, err := indexer.Open(storeFile)
+	if err != nil {
+		return err
+	}
+
+	idx, err := indexer.New(indexer.Config{
+		Backend:    backend,
+		Store:      store,
+		Contracts:  contractIDs,
+		StartBlock: startBlock,
+		Log: func(format string, args ...any) {
+			fmt.Printf(format+"\n", args...)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\nIndexing")
+	fmt.Println("----------------------------------------------------")
+	fmt.Println("store:", storeFile)
+	fmt.Println("next block:", store.NextBlock())
+
+	return idx.Run(ctx)
+}
@@ -0,0 +1,427 @@
+// Package indexer follows the chain and records the deposits, withdrawals and
+// reconciles of the bank contracts, so account balances can be read without
+// calling AccountBalance one account at a time.
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bankapi/v2"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// Set of defaults used when the Config fields are left at zero.
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultReorgWindow  = 64
+)
+
+// Set of EventLog messages emitted by the bank contracts that change an
+// account's balance. Addresses are logged as lowercase hex without a prefix.
+var (
+	depositLog  = regexp.MustCompile(`^deposit\[([0-9a-f]{40})\] balance\[(\d+)\]$`)
+	withdrawLog = regexp.MustCompile(`^withdraw\[([0-9a-f]{40})\] amount\[(\d+)\]$`)
+	partialLog  = regexp.MustCompile(`^withdraw\[([0-9a-f]{40})\] amount\[(\d+)\] balance\[(\d+)\]$`)
+	winnerLog   = regexp.MustCompile(`^winner\[(\d+)\] owner\[(\d+)\]$`)
+	smallPotLog = regexp.MustCompile(`^pot was less than fee: winner\[0\] owner\[(\d+)\]$`)
+)
+
+// eventLog is the decoded EventLog event shared by the bank contracts.
+type eventLog struct {
+	Value string
+}
+
+// Config represents the settings needed to construct an indexer.
+type Config struct {
+	Backend      ethereum.Backend
+	Store        *Store
+	Contracts    []common.Address // Proxy and single bank contracts to index
+	StartBlock   uint64           // Block to start from when the store is empty
+	PollInterval time.Duration    // How often Run syncs (0 = 5s)
+	ReorgWindow  uint64           // Number of blocks checked for reorgs (0 = 64)
+	ChunkSize    uint64           // Number of blocks requested per FilterLogs call (0 = 1000)
+	Log          func(format string, args ...any)
+}
+
+// Indexer follows the chain and records the balance changes of the bank
+// contracts in the store.
+type Indexer struct {
+	cfg       Config
+	filters   []*ethereum.EventFilter
+	owners    map[common.Address]*bank.Bank
+	reconcile abi.Method
+}
+
+// New constructs an indexer for the bank contracts in the config.
+func New(cfg Config) (*Indexer, error) {
+	if cfg.Backend == nil || cfg.Store == nil {
+		return nil, errors.New("backend and store are required")
+	}
+
+	if len(cfg.Contracts) == 0 {
+		return nil, errors.New("at least one contract is required")
+	}
+
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	if cfg.ReorgWindow == 0 {
+		cfg.ReorgWindow = defaultReorgWindow
+	}
+
+	if cfg.Log == nil {
+		cfg.Log = func(string, ...any) {}
+	}
+
+	// The proxy bank ABI is used for every contract since they all share
+	// the same EventLog event and Owner call.
+	bankABI, err := bank.BankMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("parsing bank abi: %w", err)
+	}
+
+	apiABI, err := bankapi.BankapiMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("parsing bank api abi: %w", err)
+	}
+
+	idx := Indexer{
+		cfg:       cfg,
+		owners:    make(map[common.Address]*bank.Bank),
+		reconcile: apiABI.Methods["Reconcile"],
+	}
+
+	for _, contract := range cfg.Contracts {
+		ef := ethereum.NewEventFilter(cfg.Backend, contract, *bankABI)
+		ef.ChunkSize = cfg.ChunkSize
+		idx.filters = append(idx.filters, ef)
+
+		caller, err := bank.NewBank(contract, cfg.Backend)
+		if err != nil {
+			return nil, fmt.Errorf("binding contract %s: %w", contract, err)
+		}
+		idx.owners[contract] = caller
+	}
+
+	return &idx, nil
+}
+
+// Run syncs every poll interval until the context is done.
+func (idx *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(idx.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := idx.Sync(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync rolls back any reorged blocks and records the balance changes in the
+// blocks since the last sync, returning the new entries.
+func (idx *Indexer) Sync(ctx context.Context) ([]Entry, error) {
+	store := idx.cfg.Store
+
+	fork, reorged, err := ethereum.FindFork(ctx, idx.cfg.Backend, store.Blocks())
+	if err != nil {
+		return nil, err
+	}
+
+	if reorged {
+		idx.cfg.Log("indexer: reorg detected, rolling back to block %d", fork)
+		if err := store.Rollback(fork); err != nil {
+			return nil, fmt.Errorf("rolling back to block %d: %w", fork, err)
+		}
+	}
+
+	header, err := idx.cfg.Backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving latest header: %w", err)
+	}
+	head := header.Number.Uint64()
+
+	from := store.NextBlock()
+	if from < idx.cfg.StartBlock {
+		from = idx.cfg.StartBlock
+	}
+
+	if from > head {
+		return nil, nil
+	}
+
+	var logs []ethereum.Event[eventLog]
+	for _, ef := range idx.filters {
+		events, err := ethereum.QueryEvents[eventLog](&bind.FilterOpts{Start: from, End: &head, Context: ctx}, ef, "EventLog")
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, events...)
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].LogIndex < logs[j].LogIndex
+	})
+
+	entries, err := idx.entries(ctx, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Commit(entries, head, header.Hash(), idx.cfg.ReorgWindow); err != nil {
+		return nil, fmt.Errorf("committing blocks %d-%d: %w", from, head, err)
+	}
+
+	idx.cfg.Log("indexer: processed blocks %d-%d, %d entries", from, head, len(entries))
+
+	return entries, nil
+}
+
+// entries converts the logs that change balances into entries. Balances are
+// tracked as the logs are processed, since a reconcile debits the losers
+// based on their balance.
+func (idx *Indexer) entries(ctx context.Context, logs []ethereum.Event[eventLog]) ([]Entry, error) {
+	balances := make(map[balanceKey]*big.Int)
+
+	balance := func(contract common.Address, account common.Address) *big.Int {
+		if b, exists := balances[balanceKey{contract, account}]; exists {
+			return b
+		}
+		return idx.cfg.Store.Balance(contract, account)
+	}
+
+	var entries []Entry
+
+	add := func(evt ethereum.Event[eventLog], account common.Address, kind Kind, amount *big.Int, newBalance *big.Int) {
+		balances[balanceKey{evt.Address, account}] = newBalance
+
+		entries = append(entries, Entry{
+			Contract:    evt.Address,
+			Account:     account,
+			Kind:        kind,
+			Amount:      amount,
+			Balance:     newBalance,
+			BlockNumber: evt.BlockNumber,
+			BlockHash:   evt.BlockHash,
+			TxHash:      evt.TxHash,
+			LogIndex:    evt.LogIndex,
+		})
+	}
+
+	for _, evt := range logs {
+		msg := evt.Data.Value
+
+		switch {
+		case depositLog.MatchString(msg):
+			account, newBalance, err := parseAccountAmount(depositLog, msg)
+			if err != nil {
+				return nil, fmt.Errorf("parsing deposit log %q: %w", msg, err)
+			}
+
+			// The deposit log only carries the new balance, so the amount
+			// is the difference from the recorded balance.
+			amount := new(big.Int).Sub(newBalance, balance(evt.Address, account))
+			add(evt, account, KindDeposit, amount, newBalance)
+
+		case withdrawLog.MatchString(msg):
+			account, amount, err := parseAccountAmount(withdrawLog, msg)
+			if err != nil {
+				return nil, fmt.Errorf("parsing withdraw log %q: %w", msg, err)
+			}
+
+			add(evt, account, KindWithdraw, new(big.Int).Neg(amount), big.NewInt(0))
+
+		case partialLog.MatchString(msg):
+			account, amount, err := parseAccountAmount(partialLog, msg)
+			if err != nil {
+				return nil, fmt.Errorf("parsing withdraw log %q: %w", msg, err)
+			}
+
+			// A partial withdraw logs the balance left behind.
+			newBalance, ok := new(big.Int).SetString(partialLog.FindStringSubmatch(msg)[3], 10)
+			if !ok {
+				return nil, fmt.Errorf("parsing withdraw log %q: invalid balance", msg)
+			}
+			add(evt, account, KindWithdraw, new(big.Int).Neg(amount), newBalance)
+
+		case winnerLog.MatchString(msg), smallPotLog.MatchString(msg):
+			changes, err := idx.reconcileChanges(ctx, evt, balance)
+			if err != nil {
+				if errors.Is(err, errReconcileNotFound) {
+					idx.cfg.Log("indexer: skipping reconcile in tx %s: %s", evt.TxHash, err)
+					continue
+				}
+				return nil, fmt.Errorf("processing reconcile %s: %w", evt.TxHash, err)
+			}
+
+			for _, change := range changes {
+				newBalance := new(big.Int).Add(balance(evt.Address, change.account), change.amount)
+				add(evt, change.account, KindReconcile, change.amount, newBalance)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// errReconcileNotFound is returned when the Reconcile call behind a
+// reconcile log can't be found in the transaction's input.
+var errReconcileNotFound = errors.New("reconcile call not found in transaction input")
+
+// balanceChange is a change to an account's balance made by a reconcile.
+type balanceChange struct {
+	account common.Address
+	amount  *big.Int
+}
+
+// reconcileChanges works out the balance changes of a reconcile. The final
+// reconcile log only carries the amounts paid out, so the winner and losers
+// are decoded from the transaction's input and the owner is read from the
+// contract at the reconcile's block.
+//
+// The Reconcile call doesn't have to be the transaction's call, a wallet or
+// other contract can make it, so the input is searched for an encoded
+// Reconcile call. The first one that pays out the amounts in the log is used,
+// and errReconcileNotFound is returned if there is none.
+func (idx *Indexer) reconcileChanges(ctx context.Context, evt ethereum.Event[eventLog], balance func(common.Address, common.Address) *big.Int) ([]balanceChange, error) {
+	var winnerAmount, ownerAmount *big.Int
+
+	switch {
+	case winnerLog.MatchString(evt.Data.Value):
+		m := winnerLog.FindStringSubmatch(evt.Data.Value)
+		winnerAmount, _ = new(big.Int).SetString(m[1], 10)
+		ownerAmount, _ = new(big.Int).SetString(m[2], 10)
+
+	default:
+		m := smallPotLog.FindStringSubmatch(evt.Data.Value)
+		winnerAmount = big.NewInt(0)
+		ownerAmount, _ = new(big.Int).SetString(m[1], 10)
+	}
+
+	tx, _, err := idx.cfg.Backend.TransactionByHash(ctx, evt.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving transaction: %w", err)
+	}
+
+	for _, args := range idx.reconcileCalls(tx.Data()) {
+		winner := args[0].(common.Address)
+		losers := args[1].([]common.Address)
+		ante := args[2].(*big.Int)
+		fee := args[3].(*big.Int)
+
+		// A loser without enough balance to cover the ante loses what they
+		// have. Balances are tracked in case a loser is listed twice.
+		current := make(map[common.Address]*big.Int)
+		pot := new(big.Int).Set(ante)
+
+		var changes []balanceChange
+		for _, loser := range losers {
+			if _, exists := current[loser]; !exists {
+				current[loser] = balance(evt.Address, loser)
+			}
+
+			debit := ante
+			if current[loser].Cmp(ante) < 0 {
+				debit = current[loser]
+			}
+			current[loser] = new(big.Int).Sub(current[loser], debit)
+			pot.Add(pot, debit)
+
+			changes = append(changes, balanceChange{account: loser, amount: new(big.Int).Neg(debit)})
+		}
+
+		// The contract pays the whole pot to the owner when it can't cover
+		// the fee.
+		expWinner, expOwner := new(big.Int).Sub(pot, fee), fee
+		if pot.Cmp(fee) < 0 {
+			expWinner, expOwner = big.NewInt(0), pot
+		}
+
+		if expWinner.Cmp(winnerAmount) != 0 || expOwner.Cmp(ownerAmount) != 0 {
+			continue
+		}
+
+		owner, err := idx.owners[evt.Address].Owner(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(evt.BlockNumber)})
+		if err != nil {
+			return nil, fmt.Errorf("retrieving owner: %w", err)
+		}
+
+		changes = append(changes,
+			balanceChange{account: winner, amount: winnerAmount},
+			balanceChange{account: owner, amount: ownerAmount},
+		)
+
+		return changes, nil
+	}
+
+	return nil, errReconcileNotFound
+}
+
+// reconcileCalls returns the arguments of every Reconcile call encoded in the
+// transaction's input, starting with the transaction's own call.
+func (idx *Indexer) reconcileCalls(data []byte) [][]any {
+	var calls [][]any
+
+	for i := 0; i+4 <= len(data); i++ {
+		if !bytes.Equal(data[i:i+4], idx.reconcile.ID) {
+			continue
+		}
+
+		args, err := idx.reconcile.Inputs.Unpack(data[i+4:])
+		if err != nil {
+			continue
+		}
+
+		// Unpacking doesn't check the input is a well formed call, so
+		// only calls that pack back to the same input are kept.
+		packed, err := idx.reconcile.Inputs.Pack(args...)
+		if err != nil || !bytes.HasPrefix(data[i+4:], packed) {
+			continue
+		}
+
+		calls = append(calls, args)
+	}
+
+	return calls
+}
+
+// parseAccountAmount extracts the account and amount from a log message
+// matched by the regular expression.
+func parseAccountAmount(re *regexp.Regexp, msg string) (common.Address, *big.Int, error) {
+	m := re.FindStringSubmatch(msg)
+	if m == nil {
+		return common.Address{}, nil, errors.New("log doesn't match")
+	}
+
+	amount, ok := new(big.Int).SetString(m[2], 10)
+	if !ok {
+		return common.Address{}, nil, fmt.Errorf("invalid amount %q", m[2])
+	}
+
+	return common.HexToAddress(m[1]), amount, nil
+}
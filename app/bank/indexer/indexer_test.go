@@ -0,0 +1,551 @@
+package indexer_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/indexer"
+	proxybank "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bankapi/v2"
+	"github.com/adamwoolhether/smartcontract/app/bank/single/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+const (
+	deployerAcct = iota
+	winnerAcc
+	loser1Acc
+	loser2Acc
+	numAccounts
+)
+
+const gasLimit = 1_700_000
+
+// wallet is the deployed wallet fixture from testdata.
+type wallet struct {
+	address  common.Address
+	contract *bind.BoundContract
+}
+
+// deployWallet deploys the wallet fixture, which owns contracts and makes
+// their calls the way a multisig wallet would.
+func deployWallet(t *testing.T, client *ethereum.Client) wallet {
+	t.Helper()
+
+	ctx := context.Background()
+
+	abiData, err := os.ReadFile("testdata/wallet/Wallet.abi")
+	if err != nil {
+		t.Fatalf("unable to read abi: %s", err)
+	}
+
+	binData, err := os.ReadFile("testdata/wallet/Wallet.bin")
+	if err != nil {
+		t.Fatalf("unable to read bin: %s", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(abiData)))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %s", err)
+	}
+
+	txOpts, err := client.NewTransactOpts(ctx, 3_000_000, big.NewInt(0), big.NewFloat(0))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts for deploy: %s", err)
+	}
+
+	address, tx, contract, err := bind.DeployContract(txOpts, parsed, common.FromHex(strings.TrimSpace(string(binData))), client.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy wallet: %s", err)
+	}
+
+	if _, err := client.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	return wallet{address: address, contract: contract}
+}
+
+func TestIndexer(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(numAccounts, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	clients := make([]*ethereum.Client, numAccounts)
+	for i := range clients {
+		if clients[i], err = ethereum.NewClient(backend, backend.PrivateKeys[i]); err != nil {
+			t.Fatalf("unable to create client %d: %s", i, err)
+		}
+	}
+	deployer := clients[deployerAcct]
+
+	// waitMined fails the test if the transaction wasn't successful.
+	waitMined := func(t *testing.T, client *ethereum.Client, tx *types.Transaction) {
+		t.Helper()
+
+		if _, err := client.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+	}
+
+	txOpts := func(t *testing.T, client *ethereum.Client, valueGWei float64) *bind.TransactOpts {
+		t.Helper()
+
+		opts, err := client.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGWei))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		return opts
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	bankAddr, tx, singleBank, err := bank.DeployBank(txOpts(t, deployer, 0), deployer.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy bank: %s", err)
+	}
+	waitMined(t, deployer, tx)
+
+	// The proxy bank implements Reconcile through the bank API, so it's
+	// indexed to cover reconciles.
+	implAddr, tx, _, err := bankapi.DeployBankapi(txOpts(t, deployer, 0), deployer.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy bank api: %s", err)
+	}
+	waitMined(t, deployer, tx)
+
+	apiAddr, tx, proxy, err := proxybank.DeployBank(txOpts(t, deployer, 0), deployer.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy proxy bank: %s", err)
+	}
+	waitMined(t, deployer, tx)
+
+	tx, err = proxy.SetContract(txOpts(t, deployer, 0), implAddr)
+	if err != nil {
+		t.Fatalf("unable to set bank api: %s", err)
+	}
+	waitMined(t, deployer, tx)
+
+	bankAPI, err := bankapi.NewBankapi(apiAddr, deployer.Backend)
+	if err != nil {
+		t.Fatalf("unable to bind bank api: %s", err)
+	}
+
+	// A second proxy bank is owned by a wallet contract, so its reconciles
+	// aren't the transaction's call.
+	wallet := deployWallet(t, deployer)
+	walletBankAddr := crypto.CreateAddress(wallet.address, 1)
+
+	// walletCall has the wallet call the contract with the abi encoded
+	// method and returns the transaction.
+	walletCall := func(t *testing.T, walletMethod string, contract common.Address, contractABI *abi.ABI, method string, args ...any) *types.Transaction {
+		t.Helper()
+
+		data, err := contractABI.Pack(method, args...)
+		if err != nil {
+			t.Fatalf("unable to pack %s: %s", method, err)
+		}
+
+		tx, err := wallet.contract.Transact(txOpts(t, deployer, 0), walletMethod, contract, data)
+		if err != nil {
+			t.Fatalf("unable to call %s through the wallet: %s", method, err)
+		}
+		waitMined(t, deployer, tx)
+
+		return tx
+	}
+
+	tx, err = wallet.contract.Transact(txOpts(t, deployer, 0), "Create", common.FromHex(proxybank.BankBin))
+	if err != nil {
+		t.Fatalf("unable to create proxy bank: %s", err)
+	}
+	waitMined(t, deployer, tx)
+
+	proxyABI, err := proxybank.BankMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("unable to parse proxy bank abi: %s", err)
+	}
+
+	apiABI, err := bankapi.BankapiMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("unable to parse bank api abi: %s", err)
+	}
+
+	walletCall(t, "Execute", walletBankAddr, proxyABI, "SetContract", implAddr)
+
+	storeFile := filepath.Join(t.TempDir(), "bank_indexer.json")
+
+	// logs holds the messages logged by the indexer.
+	var logs []string
+
+	// newIndexer opens the store file and constructs an indexer over it,
+	// the same as restarting the indexer command.
+	newIndexer := func(t *testing.T) (*indexer.Indexer, *indexer.Store) {
+		t.Helper()
+
+		store, err := indexer.Open(storeFile)
+		if err != nil {
+			t.Fatalf("unable to open store: %s", err)
+		}
+
+		idx, err := indexer.New(indexer.Config{
+			Backend:   backend,
+			Store:     store,
+			Contracts: []common.Address{bankAddr, apiAddr, walletBankAddr},
+			ChunkSize: 2,
+			Log: func(format string, args ...any) {
+				logs = append(logs, fmt.Sprintf(format, args...))
+			},
+		})
+		if err != nil {
+			t.Fatalf("unable to create indexer: %s", err)
+		}
+
+		return idx, store
+	}
+
+	idx, store := newIndexer(t)
+
+	const depositGWei = 1_000_000_000.0
+	depositWei := currency.GWei2Wei(big.NewFloat(depositGWei))
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("deposits", func(t *testing.T) {
+		for _, acc := range []int{winnerAcc, loser1Acc, loser2Acc} {
+			client := clients[acc]
+
+			bank, err := bank.NewBank(bankAddr, client.Backend)
+			if err != nil {
+				t.Fatalf("unable to bind bank: %s", err)
+			}
+
+			tx, err := bank.Deposit(txOpts(t, client, depositGWei))
+			if err != nil {
+				t.Fatalf("unable to deposit: %s", err)
+			}
+			waitMined(t, client, tx)
+
+			api, err := bankapi.NewBankapi(apiAddr, client.Backend)
+			if err != nil {
+				t.Fatalf("unable to bind bank api: %s", err)
+			}
+
+			tx, err = api.Deposit(txOpts(t, client, depositGWei))
+			if err != nil {
+				t.Fatalf("unable to deposit: %s", err)
+			}
+			waitMined(t, client, tx)
+		}
+
+		entries, err := idx.Sync(ctx)
+		if err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		if len(entries) != 6 {
+			t.Fatalf("wrong number of entries, got %d  exp %d", len(entries), 6)
+		}
+
+		for _, entry := range entries {
+			if entry.Kind != indexer.KindDeposit || entry.Amount.Cmp(depositWei) != 0 {
+				t.Fatalf("wrong deposit entry, got %s %v  exp %s %v", entry.Kind, entry.Amount, indexer.KindDeposit, depositWei)
+			}
+		}
+
+		callOpts, err := deployer.NewCallOpts(ctx)
+		if err != nil {
+			t.Fatalf("unable to create call opts: %s", err)
+		}
+
+		for _, acc := range []int{winnerAcc, loser1Acc, loser2Acc} {
+			exp, err := singleBank.AccountBalance(callOpts, clients[acc].Address())
+			if err != nil {
+				t.Fatalf("unable to retrieve balance: %s", err)
+			}
+
+			if got := store.Balance(bankAddr, clients[acc].Address()); got.Cmp(exp) != 0 {
+				t.Fatalf("wrong balance for account %d, got %v  exp %v", acc, got, exp)
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("withdraw", func(t *testing.T) {
+		client := clients[loser2Acc]
+
+		bank, err := bank.NewBank(bankAddr, client.Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank: %s", err)
+		}
+
+		tx, err := bank.Withdraw(txOpts(t, client, 0))
+		if err != nil {
+			t.Fatalf("unable to withdraw: %s", err)
+		}
+		waitMined(t, client, tx)
+
+		entries, err := idx.Sync(ctx)
+		if err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		if len(entries) != 1 || entries[0].Kind != indexer.KindWithdraw {
+			t.Fatalf("should get a single withdraw entry, got %+v", entries)
+		}
+
+		if entries[0].Amount.Cmp(new(big.Int).Neg(depositWei)) != 0 {
+			t.Fatalf("wrong withdraw amount, got %v  exp %v", entries[0].Amount, new(big.Int).Neg(depositWei))
+		}
+
+		if balance := store.Balance(bankAddr, client.Address()); balance.Sign() != 0 {
+			t.Fatalf("balance should be zero after withdraw, got %v", balance)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reconcile", func(t *testing.T) {
+		ante := currency.GWei2Wei(big.NewFloat(100_000_000))
+		fee := currency.GWei2Wei(big.NewFloat(10_000_000))
+
+		losers := []common.Address{clients[loser1Acc].Address(), clients[loser2Acc].Address()}
+		tx, err := bankAPI.Reconcile(txOpts(t, deployer, 0), clients[winnerAcc].Address(), losers, ante, fee)
+		if err != nil {
+			t.Fatalf("unable to reconcile: %s", err)
+		}
+		waitMined(t, deployer, tx)
+
+		if _, err := idx.Sync(ctx); err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		// Both losers still had their deposits in the bank API, since the
+		// withdraw was from the single bank.
+		pot := new(big.Int).Mul(ante, big.NewInt(3))
+		winnings := new(big.Int).Sub(pot, fee)
+
+		expWinner := new(big.Int).Add(depositWei, winnings)
+		if got := store.Balance(apiAddr, clients[winnerAcc].Address()); got.Cmp(expWinner) != 0 {
+			t.Fatalf("wrong winner balance, got %v  exp %v", got, expWinner)
+		}
+
+		expLoser := new(big.Int).Sub(depositWei, ante)
+		for _, loser := range losers {
+			if got := store.Balance(apiAddr, loser); got.Cmp(expLoser) != 0 {
+				t.Fatalf("wrong loser balance, got %v  exp %v", got, expLoser)
+			}
+		}
+
+		entries := store.Entries(clients[winnerAcc].Address())
+		last := entries[len(entries)-1]
+		if last.Kind != indexer.KindReconcile || last.Amount.Cmp(winnings) != 0 || last.TxHash != tx.Hash() {
+			t.Fatalf("wrong winner reconcile entry, got %+v", last)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reconcile through a contract", func(t *testing.T) {
+		for _, acc := range []int{winnerAcc, loser1Acc, loser2Acc} {
+			client := clients[acc]
+
+			api, err := bankapi.NewBankapi(walletBankAddr, client.Backend)
+			if err != nil {
+				t.Fatalf("unable to bind bank api: %s", err)
+			}
+
+			tx, err := api.Deposit(txOpts(t, client, depositGWei))
+			if err != nil {
+				t.Fatalf("unable to deposit: %s", err)
+			}
+			waitMined(t, client, tx)
+		}
+
+		if _, err := idx.Sync(ctx); err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		walletBank, err := bankapi.NewBankapi(walletBankAddr, deployer.Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank api: %s", err)
+		}
+
+		owner, err := walletBank.Owner(nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve owner: %s", err)
+		}
+
+		ante := currency.GWei2Wei(big.NewFloat(100_000_000))
+		fee := currency.GWei2Wei(big.NewFloat(10_000_000))
+		winner := clients[winnerAcc].Address()
+		losers := []common.Address{clients[loser1Acc].Address(), clients[loser2Acc].Address()}
+
+		before := make(map[common.Address]*big.Int)
+		for _, account := range append([]common.Address{winner, owner}, losers...) {
+			before[account] = store.Balance(walletBankAddr, account)
+		}
+
+		tx := walletCall(t, "Execute", walletBankAddr, apiABI, "Reconcile", winner, losers, ante, fee)
+
+		if _, err := idx.Sync(ctx); err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		pot := new(big.Int).Mul(ante, big.NewInt(3))
+		winnings := new(big.Int).Sub(pot, fee)
+
+		expWinner := new(big.Int).Add(before[winner], winnings)
+		if got := store.Balance(walletBankAddr, winner); got.Cmp(expWinner) != 0 {
+			t.Fatalf("wrong winner balance, got %v  exp %v", got, expWinner)
+		}
+
+		for _, loser := range losers {
+			expLoser := new(big.Int).Sub(before[loser], ante)
+			if got := store.Balance(walletBankAddr, loser); got.Cmp(expLoser) != 0 {
+				t.Fatalf("wrong loser balance, got %v  exp %v", got, expLoser)
+			}
+		}
+
+		expOwner := new(big.Int).Add(before[owner], fee)
+		if got := store.Balance(walletBankAddr, owner); got.Cmp(expOwner) != 0 {
+			t.Fatalf("wrong owner balance, got %v  exp %v", got, expOwner)
+		}
+
+		entries := store.Entries(winner)
+		last := entries[len(entries)-1]
+		if last.Kind != indexer.KindReconcile || last.Contract != walletBankAddr || last.TxHash != tx.Hash() {
+			t.Fatalf("wrong winner reconcile entry, got %+v", last)
+		}
+
+		// A reconcile whose call isn't in the transaction's input is
+		// skipped without stopping the sync.
+		walletCall(t, "Propose", walletBankAddr, apiABI, "Reconcile", winner, losers, ante, fee)
+
+		tx, err = wallet.contract.Transact(txOpts(t, deployer, 0), "Run")
+		if err != nil {
+			t.Fatalf("unable to run the proposed call: %s", err)
+		}
+		waitMined(t, deployer, tx)
+
+		logs = nil
+		entries, err = idx.Sync(ctx)
+		if err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		if len(entries) != 0 {
+			t.Fatalf("skipped reconcile should have no entries, got %+v", entries)
+		}
+
+		var skipped bool
+		for _, msg := range logs {
+			skipped = skipped || strings.Contains(msg, "skipping reconcile in tx "+tx.Hash().Hex())
+		}
+
+		if !skipped {
+			t.Fatalf("skipped reconcile should be logged, got %q", logs)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("resume", func(t *testing.T) {
+		next := store.NextBlock()
+		entries := store.Entries(clients[winnerAcc].Address())
+
+		idx, store = newIndexer(t)
+
+		if store.NextBlock() != next {
+			t.Fatalf("should resume from the last processed block, got %d  exp %d", store.NextBlock(), next)
+		}
+
+		if got := store.Entries(clients[winnerAcc].Address()); len(got) != len(entries) {
+			t.Fatalf("entries should be persisted, got %d  exp %d", len(got), len(entries))
+		}
+
+		newEntries, err := idx.Sync(ctx)
+		if err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		if len(newEntries) != 0 {
+			t.Fatalf("processed blocks should not be indexed again, got %d entries", len(newEntries))
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reorg", func(t *testing.T) {
+		client := clients[loser2Acc]
+		before := store.Balance(bankAddr, client.Address())
+
+		bank, err := bank.NewBank(bankAddr, client.Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank: %s", err)
+		}
+
+		tx, err := bank.Deposit(txOpts(t, client, depositGWei))
+		if err != nil {
+			t.Fatalf("unable to deposit: %s", err)
+		}
+		waitMined(t, client, tx)
+
+		if _, err := idx.Sync(ctx); err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		if got := store.Balance(bankAddr, client.Address()); got.Cmp(depositWei) != 0 {
+			t.Fatalf("deposit should be indexed, got %v  exp %v", got, depositWei)
+		}
+
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			t.Fatalf("unable to retrieve receipt: %s", err)
+		}
+
+		header, err := backend.HeaderByHash(ctx, receipt.BlockHash)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+
+		// Replace the block with the deposit with a longer chain without it.
+		if err := backend.Fork(ctx, header.ParentHash); err != nil {
+			t.Fatalf("unable to fork: %s", err)
+		}
+		backend.Commit()
+		backend.Commit()
+
+		if _, err := idx.Sync(ctx); err != nil {
+			t.Fatalf("unable to sync: %s", err)
+		}
+
+		if got := store.Balance(bankAddr, client.Address()); got.Cmp(before) != 0 {
+			t.Fatalf("reorged deposit should be rolled back, got %v  exp %v", got, before)
+		}
+
+		for _, entry := range store.Entries(client.Address()) {
+			if entry.TxHash == tx.Hash() {
+				t.Fatalf("reorged entry should be removed, got %+v", entry)
+			}
+		}
+	})
+}
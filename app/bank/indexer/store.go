@@ -0,0 +1,250 @@
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Kind represents the type of balance change recorded by an entry.
+type Kind string
+
+// Set of balance changes the indexer records.
+const (
+	KindDeposit   Kind = "deposit"
+	KindWithdraw  Kind = "withdraw"
+	KindReconcile Kind = "reconcile"
+)
+
+// Entry represents a change to an account's balance in a bank contract.
+type Entry struct {
+	Contract    common.Address `json:"contract"`
+	Account     common.Address `json:"account"`
+	Kind        Kind           `json:"kind"`
+	Amount      *big.Int       `json:"amount"`  // Wei, negative when the account was debited
+	Balance     *big.Int       `json:"balance"` // Wei, the account balance after the change
+	BlockNumber uint64         `json:"block_number"`
+	BlockHash   common.Hash    `json:"block_hash"`
+	TxHash      common.Hash    `json:"tx_hash"`
+	LogIndex    uint           `json:"log_index"`
+}
+
+// state is the content of the store file.
+type state struct {
+	NextBlock uint64                 `json:"next_block"`
+	Blocks    map[uint64]common.Hash `json:"blocks"`
+	Entries   []Entry                `json:"entries"`
+}
+
+// balanceKey identifies an account's balance in a bank contract.
+type balanceKey struct {
+	contract common.Address
+	account  common.Address
+}
+
+// Store is a file based store of the indexed entries. The whole store is
+// kept in memory and rewritten to the file every time it changes.
+type Store struct {
+	path string
+
+	mu       sync.RWMutex
+	state    state
+	balances map[balanceKey]*big.Int
+}
+
+// Open loads the store from the file at the specified path. If the file
+// doesn't exist, an empty store is returned and the file is created on the
+// first commit.
+func Open(path string) (*Store, error) {
+	s := Store{
+		path: path,
+		state: state{
+			Blocks: make(map[uint64]common.Hash),
+		},
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("reading store: %w", err)
+	default:
+		if err := json.Unmarshal(data, &s.state); err != nil {
+			return nil, fmt.Errorf("decoding store: %w", err)
+		}
+		if s.state.Blocks == nil {
+			s.state.Blocks = make(map[uint64]common.Hash)
+		}
+	}
+
+	s.rebuildBalances()
+
+	return &s, nil
+}
+
+// NextBlock returns the next block to process. Zero means no block has been
+// processed yet.
+func (s *Store) NextBlock() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.state.NextBlock
+}
+
+// Blocks returns the hashes of the recently processed blocks, keyed by block
+// number, so they can be checked for reorgs.
+func (s *Store) Blocks() map[uint64]common.Hash {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blocks := make(map[uint64]common.Hash, len(s.state.Blocks))
+	for number, hash := range s.state.Blocks {
+		blocks[number] = hash
+	}
+
+	return blocks
+}
+
+// Balance returns the balance of the account in the bank contract.
+func (s *Store) Balance(contract common.Address, account common.Address) *big.Int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if balance, exists := s.balances[balanceKey{contract, account}]; exists {
+		return new(big.Int).Set(balance)
+	}
+
+	return big.NewInt(0)
+}
+
+// Entries returns the entries recorded for the account across all bank
+// contracts, oldest first.
+func (s *Store) Entries(account common.Address) []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []Entry
+	for _, entry := range s.state.Entries {
+		if entry.Account == account {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// Commit records the entries found up to and including the specified block
+// and saves the store. Only the hashes of the last window blocks are kept for
+// reorg detection.
+func (s *Store) Commit(entries []Entry, number uint64, hash common.Hash, window uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range entries {
+		s.state.Entries = append(s.state.Entries, entry)
+		s.state.Blocks[entry.BlockNumber] = entry.BlockHash
+		s.balances[balanceKey{entry.Contract, entry.Account}] = entry.Balance
+	}
+
+	s.state.Blocks[number] = hash
+	s.state.NextBlock = number + 1
+
+	if number >= window {
+		for blockNumber := range s.state.Blocks {
+			if blockNumber < number-window {
+				delete(s.state.Blocks, blockNumber)
+			}
+		}
+	}
+
+	return s.save()
+}
+
+// Rollback removes everything recorded for the fork block and the blocks
+// after it, so they are processed again.
+func (s *Store) Rollback(fork uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.state.Entries[:0]
+	for _, entry := range s.state.Entries {
+		if entry.BlockNumber < fork {
+			entries = append(entries, entry)
+		}
+	}
+	s.state.Entries = entries
+
+	for number := range s.state.Blocks {
+		if number >= fork {
+			delete(s.state.Blocks, number)
+		}
+	}
+
+	if fork < s.state.NextBlock {
+		s.state.NextBlock = fork
+	}
+
+	s.rebuildBalances()
+
+	return s.save()
+}
+
+// rebuildBalances recalculates the balances from the entries and expects the
+// lock to be held.
+func (s *Store) rebuildBalances() {
+	sort.SliceStable(s.state.Entries, func(i, j int) bool {
+		a, b := s.state.Entries[i], s.state.Entries[j]
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		return a.LogIndex < b.LogIndex
+	})
+
+	s.balances = make(map[balanceKey]*big.Int)
+	for _, entry := range s.state.Entries {
+		s.balances[balanceKey{entry.Contract, entry.Account}] = entry.Balance
+	}
+}
+
+// save writes the store to a temporary file and renames it over the store
+// file, so a crash never leaves a partially written store behind. It expects
+// the lock to be held.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary store file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing store: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("syncing store: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing store: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replacing store: %w", err)
+	}
+
+	return nil
+}
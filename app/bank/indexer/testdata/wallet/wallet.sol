@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.0;
+
+// Wallet is a test fixture that owns a bank and makes its calls, the way a
+// multisig wallet would.
+contract Wallet {
+
+    // Owner represents the address who deployed the wallet.
+    address public Owner;
+
+    // pending is the call proposed to the wallet, made by Run.
+    address private pendingTarget;
+    bytes private pendingData;
+
+    // constructor is called when the contract is deployed.
+    constructor() {
+        Owner = msg.sender;
+    }
+
+    // onlyOwner can be used to restrict access to a function for the owner only.
+    modifier onlyOwner {
+        if (msg.sender != Owner) revert();
+        _;
+    }
+
+    // Create deploys the contract from its init code, so the wallet is the
+    // contract's owner.
+    function Create(bytes memory code) onlyOwner public returns (address addr) {
+        assembly {
+            addr := create(0, add(code, 32), mload(code))
+        }
+        require(addr != address(0), "create failed");
+    }
+
+    // Execute calls the target with the data.
+    function Execute(address target, bytes memory data) onlyOwner public {
+        call(target, data);
+    }
+
+    // Propose saves the call for Run, so the transaction making the call
+    // doesn't carry its data.
+    function Propose(address target, bytes memory data) onlyOwner public {
+        pendingTarget = target;
+        pendingData = data;
+    }
+
+    // Run makes the proposed call.
+    function Run() onlyOwner public {
+        call(pendingTarget, pendingData);
+        delete pendingTarget;
+        delete pendingData;
+    }
+
+    // call calls the target, reverting with the target's error when it fails.
+    function call(address target, bytes memory data) private {
+        (bool success, bytes memory result) = target.call(data);
+        if (!success) {
+            assembly {
+                revert(add(result, 32), mload(result))
+            }
+        }
+    }
+}
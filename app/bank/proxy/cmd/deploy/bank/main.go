@@ -1,118 +0,0 @@
-package main
-
-import (
-	"context"
-	"fmt"
-	"math/big"
-	"os"
-
-	"github.com/ethereum/go-ethereum/log"
-
-	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
-	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
-	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
-)
-
-const (
-	keyStoreFile = "zarf/ethereum/keystore/UTC--2022-05-12T14-47-50.112225000Z--6327a38415c53ffb36c11db55ea74cc9cb4976fd"
-	passPhrase   = "123"
-)
-
-var coinMarketCapKey = os.Getenv("CMC_API_KEY")
-
-func main() {
-	if err := run(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-}
-
-func run() (err error) {
-	ctx := context.Background()
-
-	backend, err := ethereum.CreateDialedBackend(ctx, ethereum.NetworkHTTPLocalhost)
-	if err != nil {
-		return err
-	}
-	defer backend.Close()
-
-	privateKey, err := ethereum.PrivateKeyByKeyFile(keyStoreFile, passPhrase)
-	if err != nil {
-		return err
-	}
-
-	clt, err := ethereum.NewClient(backend, privateKey)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("\nInput Values")
-	fmt.Println("----------------------------------------------------")
-	fmt.Println("fromAddress:", clt.Address())
-
-	// =========================================================================
-
-	converter, err := currency.NewConverter(bank.BankMetaData.ABI, coinMarketCapKey)
-	if err != nil {
-		converter = currency.NewDefaultConverter(bank.BankMetaData.ABI)
-	}
-	oneETHToUSD, oneUSDToETH := converter.Values()
-
-	fmt.Println("oneETHToUSD:", oneETHToUSD)
-	fmt.Println("oneUSDToETH:", oneUSDToETH)
-
-	// =========================================================================
-
-	startingBalance, err := clt.Balance(ctx)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		endingBalance, dErr := clt.Balance(ctx)
-		if dErr != nil {
-			err = dErr
-			return
-		}
-		fmt.Print(converter.FmtBalanceSheet(startingBalance, endingBalance))
-	}()
-
-	// =========================================================================
-
-	const gasLimit = 1600000
-	const gasPriceGwei = 39.576
-	const valueGwei = 0.0
-	tranOpts, err := clt.NewTransactOpts(ctx, gasLimit, currency.GWei2Wei(big.NewFloat(gasPriceGwei)), big.NewFloat(valueGwei))
-	if err != nil {
-		return err
-	}
-
-	// =========================================================================
-
-	address, tx, _, err := bank.DeployBank(tranOpts, clt.Backend)
-	if err != nil {
-		return err
-	}
-	fmt.Print(converter.FmtTransaction(tx))
-
-	fmt.Println("\nContract Details")
-	fmt.Println("----------------------------------------------------")
-	fmt.Println("contract id     :", address.Hex())
-
-	if err := os.WriteFile("zarf/ethereum/bank.cid", []byte(address.Hex()), 0644); err != nil {
-		return fmt.Errorf("exporting bank.cid file: %w", err)
-	}
-
-	// =========================================================================
-
-	fmt.Println("\nWaiting Logs")
-	fmt.Println("----------------------------------------------------")
-	log.Root().SetHandler(log.StdoutHandler)
-
-	receipt, err := clt.WaitMined(ctx, tx)
-	if err != nil {
-		return err
-	}
-	fmt.Print(converter.FmtTransactionReceipt(receipt, tx.GasPrice()))
-
-	return nil
-}
@@ -1,153 +0,0 @@
-package main
-
-import (
-	"context"
-	"errors"
-	"fmt"
-	"math/big"
-	"os"
-	"strconv"
-
-	"github.com/ethereum/go-ethereum/common"
-
-	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
-	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
-	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
-)
-
-const (
-	ownerStoreFile    = "zarf/ethereum/keystore/UTC--2022-05-12T14-47-50.112225000Z--6327a38415c53ffb36c11db55ea74cc9cb4976fd"
-	account1StoreFile = "zarf/ethereum/keystore/UTC--2022-05-13T16-57-20.203544000Z--8e113078adf6888b7ba84967f299f29aece24c55"
-	account2StoreFile = "zarf/ethereum/keystore/UTC--2022-05-13T16-59-42.277071000Z--0070742ff6003c3e809e78d524f0fe5dcc5ba7f7"
-	account3StoreFile = "zarf/ethereum/keystore/UTC--2022-09-16T16-13-42.375710134Z--7fdfc99999f1760e8dbd75a480b93c7b8386b79a"
-	account4StoreFile = "zarf/ethereum/keystore/UTC--2022-09-16T16-13-55.707637523Z--000cf95cb5eb168f57d0befcdf6a201e3e1acea9"
-
-	passPhrase = "123" // All three accounts use the same passphrase
-)
-
-var coinMarketCapKey = os.Getenv("CMC_API_KEY")
-
-func main() {
-	if err := run(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-}
-
-func run() (err error) {
-	ctx := context.Background()
-
-	depositAmount := os.Getenv("DEPOSIT_AMOUNT")
-	depositTarget := os.Getenv("DEPOSIT_TARGET")
-	var ethAccount string
-
-	// Validate the deposit target is valid.
-	switch depositTarget {
-	case "owner":
-		ethAccount = ownerStoreFile
-	case "account1":
-		ethAccount = account1StoreFile
-	case "account2":
-		ethAccount = account2StoreFile
-	case "account3":
-		ethAccount = account3StoreFile
-	case "account4":
-		ethAccount = account4StoreFile
-	default:
-		ethAccount = account1StoreFile
-	}
-
-	backend, err := ethereum.CreateDialedBackend(ctx, ethereum.NetworkHTTPLocalhost)
-	if err != nil {
-		return err
-	}
-	defer backend.Close()
-
-	privateKey, err := ethereum.PrivateKeyByKeyFile(ethAccount, passPhrase)
-	if err != nil {
-		return err
-	}
-
-	clt, err := ethereum.NewClient(backend, privateKey)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("\nInput Values")
-	fmt.Println("----------------------------------------------------")
-	fmt.Println("fromAddress:", clt.Address())
-
-	// =========================================================================
-
-	converter, err := currency.NewConverter(bank.BankMetaData.ABI, coinMarketCapKey)
-	if err != nil {
-		converter = currency.NewDefaultConverter(bank.BankMetaData.ABI)
-	}
-	oneETHToUSD, oneUSDToETH := converter.Values()
-
-	fmt.Println("oneETHToUSD:", oneETHToUSD)
-	fmt.Println("oneUSDToETH:", oneUSDToETH)
-
-	// =========================================================================
-
-	startingBalance, err := clt.Balance(ctx)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		endingBalance, dErr := clt.Balance(ctx)
-		if dErr != nil {
-			err = dErr
-			return
-		}
-		fmt.Print(converter.FmtBalanceSheet(startingBalance, endingBalance))
-	}()
-
-	// =========================================================================
-
-	valueGwei, err := strconv.ParseFloat(depositAmount, 64)
-	if err != nil {
-		return fmt.Errorf("converting deposit amount to float: %v", err)
-	}
-
-	const gasLimit = 1600000
-	const gasPriceGwei = 39.576
-	tranOpts, err := clt.NewTransactOpts(ctx, gasLimit, currency.GWei2Wei(big.NewFloat(gasPriceGwei)), big.NewFloat(valueGwei))
-	if err != nil {
-		return err
-	}
-
-	// =========================================================================
-
-	contractIDBytes, err := os.ReadFile("zarf/ethereum/bank.cid")
-	if err != nil {
-		return fmt.Errorf("importing bank.cid file: %w", err)
-	}
-
-	contractID := string(contractIDBytes)
-	if contractID == "" {
-		return errors.New("need to export the bank.cid file")
-	}
-	fmt.Println("contractID:", contractID)
-
-	proxyContract, err := bank.NewBank(common.HexToAddress(contractID), clt.Backend)
-	if err != nil {
-		return fmt.Errorf("new proxy connection: %w", err)
-	}
-
-	tx, err := proxyContract.Deposit(tranOpts)
-	if err != nil {
-		return err
-	}
-	fmt.Print(converter.FmtTransaction(tx))
-
-	// =========================================================================
-
-	receipt, err := clt.WaitMined(ctx, tx)
-	if err != nil {
-		return err
-	}
-	fmt.Print(converter.FmtTransactionReceipt(receipt, tx.GasPrice()))
-
-	return nil
-}
@@ -26,12 +26,13 @@ var (
 	_ = common.Big1
 	_ = types.BloomLookup
 	_ = event.NewSubscription
+	_ = abi.ConvertType
 )
 
 // BankMetaData contains all meta data concerning the Bank contract.
 var BankMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"}],\"name\":\"EventLog\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"API\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"AccountBalance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Balance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"contractAddr\",\"type\":\"address\"}],\"name\":\"SetContract\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Version\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Withdraw\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
-	Bin: "0x608060405234801561001057600080fd5b5033600260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550611843806100616000396000f3fe60806040526004361061007b5760003560e01c8063bb62860d1161004e578063bb62860d1461010b578063d2aadb3c14610136578063e63f341f1461015f578063ed21248c1461019c5761007b565b80630ef678871461008057806357ea89b6146100ab5780637d7b0099146100b5578063b4a99a4e146100e0575b600080fd5b34801561008c57600080fd5b506100956101a6565b6040516100a29190610b9a565b60405180910390f35b6100b36101ed565b005b3480156100c157600080fd5b506100ca61035c565b6040516100d79190610bf6565b60405180910390f35b3480156100ec57600080fd5b506100f5610380565b6040516101029190610bf6565b60405180910390f35b34801561011757600080fd5b506101206103a6565b60405161012d9190610ca1565b60405180910390f35b34801561014257600080fd5b5061015d60048036038101906101589190610d03565b610434565b005b34801561016b57600080fd5b5061018660048036038101906101819190610d03565b6106e3565b6040516101939190610b9a565b60405180910390f35b6101a4610786565b005b6000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905090565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166040516024016040516020818303038152906040527f57ea89b6000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff83818316178352505050506040516102b79190610d77565b600060405180830381855af49150503d80600081146102f2576040519150601f19603f3d011682016040523d82523d6000602084013e6102f7565b606091505b505090507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610325826108f5565b6040516020016103359190610e16565b6040516020818303038152906040526040516103519190610ca1565b60405180910390a150565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600180546103b390610e7a565b80601f01602080910402602001604051908101604052809291908181526020018280546103df90610e7a565b801561042c5780601f106104015761010080835404028352916020019161042c565b820191906000526020600020905b81548152906001019060200180831161040f57829003601f168201915b505050505081565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461048e57600080fd5b806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060008060008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166040516024016040516020818303038152906040527fbb62860d000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff83818316178352505050506040516105989190610d77565b6000604051808303816000865af19150503d80600081146105d5576040519150601f19603f3d011682016040523d82523d6000602084013e6105da565b606091505b5091509150811561060d57808060200190518101906105f99190610fd1565b6001908161060791906111c6565b50610653565b6040518060400160405280600781526020017f756e6b6e6f776e000000000000000000000000000000000000000000000000008152506001908161065191906111c6565b505b7fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61069d60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff16610978565b6106a6846108f5565b60016040516020016106ba9392919061138d565b6040516020818303038152906040526040516106d69190610ca1565b60405180910390a1505050565b6000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461073f57600080fd5b600360008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166040516024016040516020818303038152906040527fed21248c000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff83818316178352505050506040516108509190610d77565b600060405180830381855af49150503d806000811461088b576040519150601f19603f3d011682016040523d82523d6000602084013e610890565b606091505b505090507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6108be826108f5565b6040516020016108ce9190610e16565b6040516020818303038152906040526040516108ea9190610ca1565b60405180910390a150565b6060811561093a576040518060400160405280600481526020017f74727565000000000000000000000000000000000000000000000000000000008152509050610973565b6040518060400160405280600581526020017f66616c736500000000000000000000000000000000000000000000000000000081525090505b919050565b60606000602867ffffffffffffffff81111561099757610996610eb5565b5b6040519080825280601f01601f1916602001820160405280156109c95781602001600182028036833780820191505090505b50905060005b6014811015610b315760008160136109e79190611429565b60086109f3919061145d565b60026109ff91906115d2565b8573ffffffffffffffffffffffffffffffffffffffff16610a20919061164c565b60f81b9050600060108260f81c610a37919061168a565b60f81b905060008160f81c6010610a4e91906116bb565b8360f81c610a5c91906116f8565b60f81b9050610a6a82610b3b565b85856002610a78919061145d565b81518110610a8957610a8861172d565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350610ac181610b3b565b856001866002610ad1919061145d565b610adb919061175c565b81518110610aec57610aeb61172d565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053505050508080610b2990611790565b9150506109cf565b5080915050919050565b6000600a8260f81c60ff161015610b665760308260f81c610b5c91906117d8565b60f81b9050610b7c565b60578260f81c610b7691906117d8565b60f81b90505b919050565b6000819050919050565b610b9481610b81565b82525050565b6000602082019050610baf6000830184610b8b565b92915050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000610be082610bb5565b9050919050565b610bf081610bd5565b82525050565b6000602082019050610c0b6000830184610be7565b92915050565b600081519050919050565b600082825260208201905092915050565b60005b83811015610c4b578082015181840152602081019050610c30565b60008484015250505050565b6000601f19601f8301169050919050565b6000610c7382610c11565b610c7d8185610c1c565b9350610c8d818560208601610c2d565b610c9681610c57565b840191505092915050565b60006020820190508181036000830152610cbb8184610c68565b905092915050565b6000604051905090565b600080fd5b600080fd5b610ce081610bd5565b8114610ceb57600080fd5b50565b600081359050610cfd81610cd7565b92915050565b600060208284031215610d1957610d18610ccd565b5b6000610d2784828501610cee565b91505092915050565b600081519050919050565b600081905092915050565b6000610d5182610d30565b610d5b8185610d3b565b9350610d6b818560208601610c2d565b80840191505092915050565b6000610d838284610d46565b915081905092915050565b7f737563636573735b000000000000000000000000000000000000000000000000815250565b600081905092915050565b6000610dca82610c11565b610dd48185610db4565b9350610de4818560208601610c2d565b80840191505092915050565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b6000610e2182610d8e565b600882019150610e318284610dbf565b9150610e3c82610df0565b60018201915081905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680610e9257607f821691505b602082108103610ea557610ea4610e4b565b5b50919050565b600080fd5b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b610eed82610c57565b810181811067ffffffffffffffff82111715610f0c57610f0b610eb5565b5b80604052505050565b6000610f1f610cc3565b9050610f2b8282610ee4565b919050565b600067ffffffffffffffff821115610f4b57610f4a610eb5565b5b610f5482610c57565b9050602081019050919050565b6000610f74610f6f84610f30565b610f15565b905082815260208101848484011115610f9057610f8f610eb0565b5b610f9b848285610c2d565b509392505050565b600082601f830112610fb857610fb7610eab565b5b8151610fc8848260208601610f61565b91505092915050565b600060208284031215610fe757610fe6610ccd565b5b600082015167ffffffffffffffff81111561100557611004610cd2565b5b61101184828501610fa3565b91505092915050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b60006008830261107c7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8261103f565b611086868361103f565b95508019841693508086168417925050509392505050565b6000819050919050565b60006110c36110be6110b984610b81565b61109e565b610b81565b9050919050565b6000819050919050565b6110dd836110a8565b6110f16110e9826110ca565b84845461104c565b825550505050565b600090565b6111066110f9565b6111118184846110d4565b505050565b5b818110156111355761112a6000826110fe565b600181019050611117565b5050565b601f82111561117a5761114b8161101a565b6111548461102f565b81016020851015611163578190505b61117761116f8561102f565b830182611116565b50505b505050565b600082821c905092915050565b600061119d6000198460080261117f565b1980831691505092915050565b60006111b6838361118c565b9150826002028217905092915050565b6111cf82610c11565b67ffffffffffffffff8111156111e8576111e7610eb5565b5b6111f28254610e7a565b6111fd828285611139565b600060209050601f831160018114611230576000841561121e578287015190505b61122885826111aa565b865550611290565b601f19841661123e8661101a565b60005b8281101561126657848901518255600182019150602085019450602081019050611241565b86831015611283578489015161127f601f89168261118c565b8355505b6001600288020188555050505b505050505050565b7f636f6e74726163745b0000000000000000000000000000000000000000000000815250565b7f5d20737563636573735b00000000000000000000000000000000000000000000815250565b7f5d2076657273696f6e5b00000000000000000000000000000000000000000000815250565b6000815461131781610e7a565b6113218186610db4565b9450600182166000811461133c576001811461135157611384565b60ff1983168652811515820286019350611384565b61135a8561101a565b60005b8381101561137c5781548189015260018201915060208101905061135d565b838801955050505b50505092915050565b600061139882611298565b6009820191506113a88286610dbf565b91506113b3826112be565b600a820191506113c38285610dbf565b91506113ce826112e4565b600a820191506113de828461130a565b91506113e982610df0565b600182019150819050949350505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b600061143482610b81565b915061143f83610b81565b9250828203905081811115611457576114566113fa565b5b92915050565b600061146882610b81565b915061147383610b81565b925082820261148181610b81565b91508282048414831517611498576114976113fa565b5b5092915050565b60008160011c9050919050565b6000808291508390505b60018511156114f6578086048111156114d2576114d16113fa565b5b60018516156114e15780820291505b80810290506114ef8561149f565b94506114b6565b94509492505050565b60008261150f57600190506115cb565b8161151d57600090506115cb565b8160018114611533576002811461153d5761156c565b60019150506115cb565b60ff84111561154f5761154e6113fa565b5b8360020a915084821115611566576115656113fa565b5b506115cb565b5060208310610133831016604e8410600b84101617156115a15782820a90508381111561159c5761159b6113fa565b5b6115cb565b6115ae84848460016114ac565b925090508184048111156115c5576115c46113fa565b5b81810290505b9392505050565b60006115dd82610b81565b91506115e883610b81565b92506116157fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff84846114ff565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b600061165782610b81565b915061166283610b81565b9250826116725761167161161d565b5b828204905092915050565b600060ff82169050919050565b60006116958261167d565b91506116a08361167d565b9250826116b0576116af61161d565b5b828204905092915050565b60006116c68261167d565b91506116d18361167d565b92508282026116df8161167d565b91508082146116f1576116f06113fa565b5b5092915050565b60006117038261167d565b915061170e8361167d565b9250828203905060ff811115611727576117266113fa565b5b92915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b600061176782610b81565b915061177283610b81565b925082820190508082111561178a576117896113fa565b5b92915050565b600061179b82610b81565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82036117cd576117cc6113fa565b5b600182019050919050565b60006117e38261167d565b91506117ee8361167d565b9250828201905060ff811115611807576118066113fa565b5b9291505056fea26469706673582212203244f786807d60fea98dcedc868932574356a71b7d24d373ead651e60fac596c64736f6c63430008120033",
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"}],\"name\":\"EventLog\",\"type\":\"event\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"},{\"inputs\":[],\"name\":\"API\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"AccountBalance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Balance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"contractAddr\",\"type\":\"address\"}],\"name\":\"SetContract\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Version\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Withdraw\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b5033600260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555061180d806100616000396000f3fe60806040526004361061007f5760003560e01c8063bb62860d1161004e578063bb62860d14610165578063d2aadb3c14610190578063e63f341f146101b9578063ed21248c146101f657610080565b80630ef67887146100da57806357ea89b6146101055780637d7b00991461010f578063b4a99a4e1461013a57610080565b5b60006100d06000368080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f82011690508083019250505050505050610200565b9050805160208201f35b3480156100e657600080fd5b506100ef610321565b6040516100fc9190610b53565b60405180910390f35b61010d610368565b005b34801561011b57600080fd5b506101246103f6565b6040516101319190610baf565b60405180910390f35b34801561014657600080fd5b5061014f61041a565b60405161015c9190610baf565b60405180910390f35b34801561017157600080fd5b5061017a610440565b6040516101879190610c5a565b60405180910390f35b34801561019c57600080fd5b506101b760048036038101906101b29190610cbc565b6104ce565b005b3480156101c557600080fd5b506101e060048036038101906101db9190610cbc565b61077d565b6040516101ed9190610b53565b60405180910390f35b6101fe610820565b005b606060008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163b0361027d576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161027490610d35565b60405180910390fd5b60008060008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16846040516102c59190610d9c565b600060405180830381855af49150503d8060008114610300576040519150601f19603f3d011682016040523d82523d6000602084013e610305565b606091505b50915091508161031757805160208201fd5b8092505050919050565b6000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905090565b6103f36040516024016040516020818303038152906040527f57ea89b6000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff8381831617835250505050610200565b50565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6001805461044d90610de2565b80601f016020809104026020016040519081016040528092919081815260200182805461047990610de2565b80156104c65780601f1061049b576101008083540402835291602001916104c6565b820191906000526020600020905b8154815290600101906020018083116104a957829003601f168201915b505050505081565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461052857600080fd5b806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060008060008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166040516024016040516020818303038152906040527fbb62860d000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff83818316178352505050506040516106329190610d9c565b6000604051808303816000865af19150503d806000811461066f576040519150601f19603f3d011682016040523d82523d6000602084013e610674565b606091505b509150915081156106a757808060200190518101906106939190610f39565b600190816106a1919061112e565b506106ed565b6040518060400160405280600781526020017f756e6b6e6f776e00000000000000000000000000000000000000000000000000815250600190816106eb919061112e565b505b7fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61073760008054906101000a900473ffffffffffffffffffffffffffffffffffffffff166108ae565b61074084610a71565b600160405160200161075493929190611357565b6040516020818303038152906040526040516107709190610c5a565b60405180910390a1505050565b6000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146107d957600080fd5b600360008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b6108ab6040516024016040516020818303038152906040527fed21248c000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff8381831617835250505050610200565b50565b60606000602867ffffffffffffffff8111156108cd576108cc610e1d565b5b6040519080825280601f01601f1916602001820160405280156108ff5781602001600182028036833780820191505090505b50905060005b6014811015610a6757600081601361091d91906113f3565b60086109299190611427565b6002610935919061159c565b8573ffffffffffffffffffffffffffffffffffffffff166109569190611616565b60f81b9050600060108260f81c61096d9190611654565b60f81b905060008160f81c60106109849190611685565b8360f81c61099291906116c2565b60f81b90506109a082610af4565b858560026109ae9190611427565b815181106109bf576109be6116f7565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053506109f781610af4565b856001866002610a079190611427565b610a119190611726565b81518110610a2257610a216116f7565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053505050508080610a5f9061175a565b915050610905565b5080915050919050565b60608115610ab6576040518060400160405280600481526020017f74727565000000000000000000000000000000000000000000000000000000008152509050610aef565b6040518060400160405280600581526020017f66616c736500000000000000000000000000000000000000000000000000000081525090505b919050565b6000600a8260f81c60ff161015610b1f5760308260f81c610b1591906117a2565b60f81b9050610b35565b60578260f81c610b2f91906117a2565b60f81b90505b919050565b6000819050919050565b610b4d81610b3a565b82525050565b6000602082019050610b686000830184610b44565b92915050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000610b9982610b6e565b9050919050565b610ba981610b8e565b82525050565b6000602082019050610bc46000830184610ba0565b92915050565b600081519050919050565b600082825260208201905092915050565b60005b83811015610c04578082015181840152602081019050610be9565b60008484015250505050565b6000601f19601f8301169050919050565b6000610c2c82610bca565b610c368185610bd5565b9350610c46818560208601610be6565b610c4f81610c10565b840191505092915050565b60006020820190508181036000830152610c748184610c21565b905092915050565b6000604051905090565b600080fd5b600080fd5b610c9981610b8e565b8114610ca457600080fd5b50565b600081359050610cb681610c90565b92915050565b600060208284031215610cd257610cd1610c86565b5b6000610ce084828501610ca7565b91505092915050565b7f6e6f2061706920636f6e74726163742073657400000000000000000000000000600082015250565b6000610d1f601383610bd5565b9150610d2a82610ce9565b602082019050919050565b60006020820190508181036000830152610d4e81610d12565b9050919050565b600081519050919050565b600081905092915050565b6000610d7682610d55565b610d808185610d60565b9350610d90818560208601610be6565b80840191505092915050565b6000610da88284610d6b565b915081905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680610dfa57607f821691505b602082108103610e0d57610e0c610db3565b5b50919050565b600080fd5b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b610e5582610c10565b810181811067ffffffffffffffff82111715610e7457610e73610e1d565b5b80604052505050565b6000610e87610c7c565b9050610e938282610e4c565b919050565b600067ffffffffffffffff821115610eb357610eb2610e1d565b5b610ebc82610c10565b9050602081019050919050565b6000610edc610ed784610e98565b610e7d565b905082815260208101848484011115610ef857610ef7610e18565b5b610f03848285610be6565b509392505050565b600082601f830112610f2057610f1f610e13565b5b8151610f30848260208601610ec9565b91505092915050565b600060208284031215610f4f57610f4e610c86565b5b600082015167ffffffffffffffff811115610f6d57610f6c610c8b565b5b610f7984828501610f0b565b91505092915050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b600060088302610fe47fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82610fa7565b610fee8683610fa7565b95508019841693508086168417925050509392505050565b6000819050919050565b600061102b61102661102184610b3a565b611006565b610b3a565b9050919050565b6000819050919050565b61104583611010565b61105961105182611032565b848454610fb4565b825550505050565b600090565b61106e611061565b61107981848461103c565b505050565b5b8181101561109d57611092600082611066565b60018101905061107f565b5050565b601f8211156110e2576110b381610f82565b6110bc84610f97565b810160208510156110cb578190505b6110df6110d785610f97565b83018261107e565b50505b505050565b600082821c905092915050565b6000611105600019846008026110e7565b1980831691505092915050565b600061111e83836110f4565b9150826002028217905092915050565b61113782610bca565b67ffffffffffffffff8111156111505761114f610e1d565b5b61115a8254610de2565b6111658282856110a1565b600060209050601f8311600181146111985760008415611186578287015190505b6111908582611112565b8655506111f8565b601f1984166111a686610f82565b60005b828110156111ce578489015182556001820191506020850194506020810190506111a9565b868310156111eb57848901516111e7601f8916826110f4565b8355505b6001600288020188555050505b505050505050565b7f636f6e74726163745b0000000000000000000000000000000000000000000000815250565b600081905092915050565b600061123c82610bca565b6112468185611226565b9350611256818560208601610be6565b80840191505092915050565b7f5d20737563636573735b00000000000000000000000000000000000000000000815250565b7f5d2076657273696f6e5b00000000000000000000000000000000000000000000815250565b600081546112bb81610de2565b6112c58186611226565b945060018216600081146112e057600181146112f557611328565b60ff1983168652811515820286019350611328565b6112fe85610f82565b60005b8381101561132057815481890152600182019150602081019050611301565b838801955050505b50505092915050565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b600061136282611200565b6009820191506113728286611231565b915061137d82611262565b600a8201915061138d8285611231565b915061139882611288565b600a820191506113a882846112ae565b91506113b382611331565b600182019150819050949350505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b60006113fe82610b3a565b915061140983610b3a565b9250828203905081811115611421576114206113c4565b5b92915050565b600061143282610b3a565b915061143d83610b3a565b925082820261144b81610b3a565b91508282048414831517611462576114616113c4565b5b5092915050565b60008160011c9050919050565b6000808291508390505b60018511156114c05780860481111561149c5761149b6113c4565b5b60018516156114ab5780820291505b80810290506114b985611469565b9450611480565b94509492505050565b6000826114d95760019050611595565b816114e75760009050611595565b81600181146114fd576002811461150757611536565b6001915050611595565b60ff841115611519576115186113c4565b5b8360020a9150848211156115305761152f6113c4565b5b50611595565b5060208310610133831016604e8410600b841016171561156b5782820a905083811115611566576115656113c4565b5b611595565b6115788484846001611476565b9250905081840481111561158f5761158e6113c4565b5b81810290505b9392505050565b60006115a782610b3a565b91506115b283610b3a565b92506115df7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff84846114c9565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b600061162182610b3a565b915061162c83610b3a565b92508261163c5761163b6115e7565b5b828204905092915050565b600060ff82169050919050565b600061165f82611647565b915061166a83611647565b92508261167a576116796115e7565b5b828204905092915050565b600061169082611647565b915061169b83611647565b92508282026116a981611647565b91508082146116bb576116ba6113c4565b5b5092915050565b60006116cd82611647565b91506116d883611647565b9250828203905060ff8111156116f1576116f06113c4565b5b92915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b600061173182610b3a565b915061173c83610b3a565b9250828201905080821115611754576117536113c4565b5b92915050565b600061176582610b3a565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8203611797576117966113c4565b5b600182019050919050565b60006117ad82611647565b91506117b883611647565b9250828201905060ff8111156117d1576117d06113c4565b5b9291505056fea264697066735822122068efe5736f023b404ae71c7f1d96badfd351b8c56e055c3aa59e4d18568cd8f964736f6c63430008150033",
 }
 
 // BankABI is the input ABI used to generate the binding from.
@@ -156,11 +157,11 @@ func NewBankFilterer(address common.Address, filterer bind.ContractFilterer) (*B
 
 // bindBank binds a generic wrapper to an already deployed contract.
 func bindBank(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
-	parsed, err := abi.JSON(strings.NewReader(BankABI))
+	parsed, err := BankMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
-	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
 }
 
 // Call invokes the (constant) contract method with params as input values and
@@ -419,6 +420,27 @@ func (_Bank *BankTransactorSession) Withdraw() (*types.Transaction, error) {
 	return _Bank.Contract.Withdraw(&_Bank.TransactOpts)
 }
 
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_Bank *BankTransactor) Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error) {
+	return _Bank.contract.RawTransact(opts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_Bank *BankSession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _Bank.Contract.Fallback(&_Bank.TransactOpts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_Bank *BankTransactorSession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _Bank.Contract.Fallback(&_Bank.TransactOpts, calldata)
+}
+
 // BankEventLogIterator is returned from FilterEventLog and is used to iterate over the raw logs and unpacked data for EventLog events raised by the Bank contract.
 type BankEventLogIterator struct {
 	Event *BankEventLog // Event containing the contract specifics and raw log
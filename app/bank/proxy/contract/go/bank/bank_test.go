@@ -0,0 +1,309 @@
+package bank_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	bankapiv1 "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bankapi/v1"
+	bankapiv2 "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bankapi/v2"
+	bankapiv3 "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bankapi/v3"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+const (
+	deployerAcct = iota
+	winnerAcc
+	loser1Acc
+	loser2Acc
+	numAccounts
+)
+
+// TestBankProxyUpgrade walks the proxy through every BankAPI version, the
+// balances held in the proxy's storage must survive each upgrade.
+func TestBankProxyUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(numAccounts, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	clients := make([]*ethereum.Client, numAccounts)
+	for i := range clients {
+		if clients[i], err = ethereum.NewClient(backend, backend.PrivateKeys[i]); err != nil {
+			t.Fatalf("unable to create client %d: %s", i, err)
+		}
+	}
+	deployer := clients[deployerAcct]
+
+	callOpts, err := deployer.NewCallOpts(ctx)
+	if err != nil {
+		t.Fatalf("unable to create call opts: %s", err)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	const gasLimit = 2_500_000
+
+	txOpts := func(t *testing.T, client *ethereum.Client, valueGWei float64) *bind.TransactOpts {
+		t.Helper()
+
+		opts, err := client.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGWei))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		return opts
+	}
+
+	// waitMined fails the test if the transaction wasn't successful.
+	waitMined := func(t *testing.T, client *ethereum.Client, tx *types.Transaction, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		if _, err := client.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+	}
+
+	// reverted fails the test unless the transaction reverted with the reason.
+	reverted := func(t *testing.T, client *ethereum.Client, tx *types.Transaction, err error, reason string) {
+		t.Helper()
+
+		if err == nil {
+			_, err = client.WaitMined(ctx, tx)
+		}
+
+		if err == nil || !strings.Contains(err.Error(), reason) {
+			t.Fatalf("should revert with %q, got %v", reason, err)
+		}
+	}
+
+	proxyAddr, tx, proxy, err := bank.DeployBank(txOpts(t, deployer, 0), deployer.Backend)
+	waitMined(t, deployer, tx, err)
+
+	// proxyFor binds the proxy for the account.
+	proxyFor := func(t *testing.T, acc int) *bank.Bank {
+		t.Helper()
+
+		b, err := bank.NewBank(proxyAddr, clients[acc].Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank: %s", err)
+		}
+
+		return b
+	}
+
+	// v2For binds the proxy with the version 2 ABI for the account, the
+	// proxy forwards the calls it doesn't implement to the API.
+	v2For := func(t *testing.T, acc int) *bankapiv2.Bankapi {
+		t.Helper()
+
+		b, err := bankapiv2.NewBankapi(proxyAddr, clients[acc].Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank api v2: %s", err)
+		}
+
+		return b
+	}
+
+	// v3For binds the proxy with the version 3 ABI for the account.
+	v3For := func(t *testing.T, acc int) *bankapiv3.Bankapi {
+		t.Helper()
+
+		b, err := bankapiv3.NewBankapi(proxyAddr, clients[acc].Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank api v3: %s", err)
+		}
+
+		return b
+	}
+
+	// upgrade points the proxy at the deployed API and checks the version the
+	// proxy read back from it.
+	upgrade := func(t *testing.T, apiAddr common.Address, tx *types.Transaction, err error, version string) {
+		t.Helper()
+
+		waitMined(t, deployer, tx, err)
+
+		tx, err = proxy.SetContract(txOpts(t, deployer, 0), apiAddr)
+		waitMined(t, deployer, tx, err)
+
+		api, err := proxy.API(callOpts)
+		if err != nil {
+			t.Fatalf("unable to get api: %s", err)
+		}
+
+		if api != apiAddr {
+			t.Fatalf("wrong api, got %s  exp %s", api, apiAddr)
+		}
+
+		got, err := proxy.Version(callOpts)
+		if err != nil {
+			t.Fatalf("unable to get version: %s", err)
+		}
+
+		if got != version {
+			t.Fatalf("wrong version, got %s  exp %s", got, version)
+		}
+	}
+
+	// The expected balance of every account, kept as the test moves money.
+	balances := make([]*big.Int, numAccounts)
+	for i := range balances {
+		balances[i] = big.NewInt(0)
+	}
+
+	// checkBalances compares the proxy's balances to the expected ones.
+	checkBalances := func(t *testing.T) {
+		t.Helper()
+
+		for acc, exp := range balances {
+			got, err := proxy.AccountBalance(callOpts, clients[acc].Address())
+			if err != nil {
+				t.Fatalf("unable to get balance of account %d: %s", acc, err)
+			}
+
+			if got.Cmp(exp) != 0 {
+				t.Fatalf("wrong balance for account %d, got %v  exp %v", acc, got, exp)
+			}
+		}
+	}
+
+	const depositGWei = 1_000_000
+	depositWei := big.NewInt(depositGWei * 1e9)
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("v1 deposits", func(t *testing.T) {
+		tx, err := proxyFor(t, winnerAcc).Deposit(txOpts(t, clients[winnerAcc], depositGWei))
+		reverted(t, clients[winnerAcc], tx, err, "no api contract set")
+
+		apiAddr, tx, _, err := bankapiv1.DeployBankapi(txOpts(t, deployer, 0), deployer.Backend)
+		upgrade(t, apiAddr, tx, err, "0.1.0")
+
+		for _, acc := range []int{winnerAcc, loser1Acc, loser2Acc} {
+			tx, err := proxyFor(t, acc).Deposit(txOpts(t, clients[acc], depositGWei))
+			waitMined(t, clients[acc], tx, err)
+
+			balances[acc].Add(balances[acc], depositWei)
+		}
+		checkBalances(t)
+
+		// Version 1 has no reconcile, so the call the proxy forwards fails.
+		tx, err = v2For(t, deployerAcct).Reconcile(txOpts(t, deployer, 0), clients[winnerAcc].Address(), nil, depositWei, big.NewInt(0))
+		reverted(t, deployer, tx, err, "execution reverted")
+		checkBalances(t)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("v2 reconcile", func(t *testing.T) {
+		apiAddr, tx, _, err := bankapiv2.DeployBankapi(txOpts(t, deployer, 0), deployer.Backend)
+		upgrade(t, apiAddr, tx, err, "0.2.0")
+		checkBalances(t)
+
+		anteWei := big.NewInt(400_000 * 1e9)
+		feeWei := big.NewInt(10_000 * 1e9)
+		losers := []common.Address{clients[loser1Acc].Address(), clients[loser2Acc].Address()}
+
+		// Only the bank's owner can reconcile through the proxy.
+		tx, err = v2For(t, winnerAcc).Reconcile(txOpts(t, clients[winnerAcc], 0), clients[winnerAcc].Address(), losers, depositWei, big.NewInt(0))
+		reverted(t, clients[winnerAcc], tx, err, "execution reverted")
+		checkBalances(t)
+
+		tx, err = v2For(t, deployerAcct).Reconcile(txOpts(t, deployer, 0), clients[winnerAcc].Address(), losers, anteWei, feeWei)
+		waitMined(t, deployer, tx, err)
+
+		// The pot starts with the winner's ante, which the contract doesn't
+		// take from the winner's balance, plus the antes of the losers.
+		potWei := new(big.Int).Mul(anteWei, big.NewInt(3))
+		balances[loser1Acc].Sub(balances[loser1Acc], anteWei)
+		balances[loser2Acc].Sub(balances[loser2Acc], anteWei)
+		balances[winnerAcc].Add(balances[winnerAcc], potWei.Sub(potWei, feeWei))
+		balances[deployerAcct].Add(balances[deployerAcct], feeWei)
+		checkBalances(t)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("v3 withdraws and caps", func(t *testing.T) {
+		apiAddr, tx, _, err := bankapiv3.DeployBankapi(txOpts(t, deployer, 0), deployer.Backend)
+		upgrade(t, apiAddr, tx, err, "0.3.0")
+		checkBalances(t)
+
+		// Version 3 restricts reconciles to the bank's owner.
+		losers := []common.Address{clients[loser1Acc].Address()}
+		tx, err = v3For(t, winnerAcc).Reconcile(txOpts(t, clients[winnerAcc], 0), clients[winnerAcc].Address(), losers, depositWei, big.NewInt(0))
+		reverted(t, clients[winnerAcc], tx, err, "execution reverted")
+		checkBalances(t)
+
+		// A partial withdraw leaves the rest of the balance.
+		withdrawWei := big.NewInt(250_000 * 1e9)
+		tx, err = v3For(t, loser1Acc).WithdrawAmount(txOpts(t, clients[loser1Acc], 0), withdrawWei)
+		waitMined(t, clients[loser1Acc], tx, err)
+
+		balances[loser1Acc].Sub(balances[loser1Acc], withdrawWei)
+		checkBalances(t)
+
+		tx, err = v3For(t, loser1Acc).WithdrawAmount(txOpts(t, clients[loser1Acc], 0), depositWei)
+		reverted(t, clients[loser1Acc], tx, err, "not enough balance")
+
+		// The cap limits deposits and the proxy keeps none of a refused one.
+		capWei := new(big.Int).Add(balances[loser2Acc], depositWei)
+		tx, err = v3For(t, deployerAcct).SetDepositCap(txOpts(t, deployer, 0), clients[loser2Acc].Address(), capWei)
+		waitMined(t, deployer, tx, err)
+
+		got, err := v3For(t, deployerAcct).DepositCap(callOpts, clients[loser2Acc].Address())
+		if err != nil {
+			t.Fatalf("unable to get deposit cap: %s", err)
+		}
+
+		if got.Cmp(capWei) != 0 {
+			t.Fatalf("wrong deposit cap, got %v  exp %v", got, capWei)
+		}
+
+		tx, err = v3For(t, loser2Acc).SetDepositCap(txOpts(t, clients[loser2Acc], 0), clients[loser2Acc].Address(), big.NewInt(0))
+		reverted(t, clients[loser2Acc], tx, err, "execution reverted")
+
+		tx, err = proxyFor(t, loser2Acc).Deposit(txOpts(t, clients[loser2Acc], depositGWei))
+		waitMined(t, clients[loser2Acc], tx, err)
+		balances[loser2Acc].Add(balances[loser2Acc], depositWei)
+
+		before, err := backend.BalanceAt(ctx, proxyAddr, nil)
+		if err != nil {
+			t.Fatalf("unable to get proxy balance: %s", err)
+		}
+
+		tx, err = proxyFor(t, loser2Acc).Deposit(txOpts(t, clients[loser2Acc], 1))
+		reverted(t, clients[loser2Acc], tx, err, "deposit exceeds the cap")
+
+		after, err := backend.BalanceAt(ctx, proxyAddr, nil)
+		if err != nil {
+			t.Fatalf("unable to get proxy balance: %s", err)
+		}
+
+		if after.Cmp(before) != 0 {
+			t.Fatalf("proxy kept a refused deposit, got %v  exp %v", after, before)
+		}
+		checkBalances(t)
+
+		// The full withdraw of earlier versions still works.
+		tx, err = proxyFor(t, winnerAcc).Withdraw(txOpts(t, clients[winnerAcc], 0))
+		waitMined(t, clients[winnerAcc], tx, err)
+
+		balances[winnerAcc].SetInt64(0)
+		checkBalances(t)
+	})
+}
@@ -0,0 +1,472 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bankapi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BankapiMetaData contains all meta data concerning the Bankapi contract.
+var BankapiMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"}],\"name\":\"EventLog\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"API\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Version\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Withdraw\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+	Bin: "0x60806040523480156200001157600080fd5b506040518060400160405280600581526020017f302e312e3000000000000000000000000000000000000000000000000000000081525060019081620000589190620002d9565b50620003c0565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680620000e157607f821691505b602082108103620000f757620000f662000099565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b600060088302620001617fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8262000122565b6200016d868362000122565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b6000620001ba620001b4620001ae8462000185565b6200018f565b62000185565b9050919050565b6000819050919050565b620001d68362000199565b620001ee620001e582620001c1565b8484546200012f565b825550505050565b600090565b62000205620001f6565b62000212818484620001cb565b505050565b5b818110156200023a576200022e600082620001fb565b60018101905062000218565b5050565b601f82111562000289576200025381620000fd565b6200025e8462000112565b810160208510156200026e578190505b620002866200027d8562000112565b83018262000217565b50505b505050565b600082821c905092915050565b6000620002ae600019846008026200028e565b1980831691505092915050565b6000620002c983836200029b565b9150826002028217905092915050565b620002e4826200005f565b67ffffffffffffffff8111156200030057620002ff6200006a565b5b6200030c8254620000c8565b620003198282856200023e565b600060209050601f8311600181146200035157600084156200033c578287015190505b620003488582620002bb565b865550620003b8565b601f1984166200036186620000fd565b60005b828110156200038b5784890151825560018201915060208501945060208101905062000364565b86831015620003ab5784890151620003a7601f8916826200029b565b8355505b6001600288020188555050505b505050505050565b61100780620003d06000396000f3fe60806040526004361061004a5760003560e01c806357ea89b61461004f5780637d7b009914610059578063b4a99a4e14610084578063bb62860d146100af578063ed21248c146100da575b600080fd5b6100576100e4565b005b34801561006557600080fd5b5061006e6102a7565b60405161007b9190610850565b60405180910390f35b34801561009057600080fd5b506100996102cb565b6040516100a69190610850565b60405180910390f35b3480156100bb57600080fd5b506100c46102f1565b6040516100d191906108fb565b60405180910390f35b6100e261037f565b005b60003390506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020540361016b576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161016290610969565b60405180910390fd5b6000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205490508173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f193505050501580156101f5573d6000803e3d6000fd5b506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6102653361047e565b61026e83610641565b60405160200161027f929190610a37565b60405160208183030381529060405260405161029b91906108fb565b60405180910390a15050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600180546102fe90610ab7565b80601f016020809104026020016040519081016040528092919081815260200182805461032a90610ab7565b80156103775780601f1061034c57610100808354040283529160200191610377565b820191906000526020600020905b81548152906001019060200180831161035a57829003601f168201915b505050505081565b34600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546103ce9190610b21565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6103ff3361047e565b610447600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054610641565b604051602001610458929190610ba1565b60405160208183030381529060405260405161047491906108fb565b60405180910390a1565b60606000602867ffffffffffffffff81111561049d5761049c610bf2565b5b6040519080825280601f01601f1916602001820160405280156104cf5781602001600182028036833780820191505090505b50905060005b60148110156106375760008160136104ed9190610c21565b60086104f99190610c55565b60026105059190610dca565b8573ffffffffffffffffffffffffffffffffffffffff166105269190610e44565b60f81b9050600060108260f81c61053d9190610e82565b60f81b905060008160f81c60106105549190610eb3565b8360f81c6105629190610ef0565b60f81b9050610570826107c9565b8585600261057e9190610c55565b8151811061058f5761058e610f25565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053506105c7816107c9565b8560018660026105d79190610c55565b6105e19190610b21565b815181106105f2576105f1610f25565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350505050808061062f90610f54565b9150506104d5565b5080915050919050565b606060008203610688576040518060400160405280600181526020017f300000000000000000000000000000000000000000000000000000000000000081525090506107c4565b600082905060005b600082146106ba5780806106a390610f54565b915050600a826106b39190610e44565b9150610690565b60008167ffffffffffffffff8111156106d6576106d5610bf2565b5b6040519080825280601f01601f1916602001820160405280156107085781602001600182028036833780820191505090505b50905060008290505b600086146107bc576001816107269190610c21565b90506000600a80886107389190610e44565b6107429190610c55565b8761074d9190610c21565b60306107599190610f9c565b905060008160f81b90508084848151811061077757610776610f25565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350600a886107b39190610e44565b97505050610711565b819450505050505b919050565b6000600a8260f81c60ff1610156107f45760308260f81c6107ea9190610f9c565b60f81b905061080a565b60578260f81c6108049190610f9c565b60f81b90505b919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061083a8261080f565b9050919050565b61084a8161082f565b82525050565b60006020820190506108656000830184610841565b92915050565b600081519050919050565b600082825260208201905092915050565b60005b838110156108a557808201518184015260208101905061088a565b60008484015250505050565b6000601f19601f8301169050919050565b60006108cd8261086b565b6108d78185610876565b93506108e7818560208601610887565b6108f0816108b1565b840191505092915050565b6000602082019050818103600083015261091581846108c2565b905092915050565b7f6e6f7420656e6f7567682062616c616e63650000000000000000000000000000600082015250565b6000610953601283610876565b915061095e8261091d565b602082019050919050565b6000602082019050818103600083015261098281610946565b9050919050565b7f77697468647261775b0000000000000000000000000000000000000000000000815250565b600081905092915050565b60006109c58261086b565b6109cf81856109af565b93506109df818560208601610887565b80840191505092915050565b7f5d20616d6f756e745b0000000000000000000000000000000000000000000000815250565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b6000610a4282610989565b600982019150610a5282856109ba565b9150610a5d826109eb565b600982019150610a6d82846109ba565b9150610a7882610a11565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680610acf57607f821691505b602082108103610ae257610ae1610a88565b5b50919050565b6000819050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000610b2c82610ae8565b9150610b3783610ae8565b9250828201905080821115610b4f57610b4e610af2565b5b92915050565b7f6465706f7369745b000000000000000000000000000000000000000000000000815250565b7f5d2062616c616e63655b00000000000000000000000000000000000000000000815250565b6000610bac82610b55565b600882019150610bbc82856109ba565b9150610bc782610b7b565b600a82019150610bd782846109ba565b9150610be282610a11565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b6000610c2c82610ae8565b9150610c3783610ae8565b9250828203905081811115610c4f57610c4e610af2565b5b92915050565b6000610c6082610ae8565b9150610c6b83610ae8565b9250828202610c7981610ae8565b91508282048414831517610c9057610c8f610af2565b5b5092915050565b60008160011c9050919050565b6000808291508390505b6001851115610cee57808604811115610cca57610cc9610af2565b5b6001851615610cd95780820291505b8081029050610ce785610c97565b9450610cae565b94509492505050565b600082610d075760019050610dc3565b81610d155760009050610dc3565b8160018114610d2b5760028114610d3557610d64565b6001915050610dc3565b60ff841115610d4757610d46610af2565b5b8360020a915084821115610d5e57610d5d610af2565b5b50610dc3565b5060208310610133831016604e8410600b8410161715610d995782820a905083811115610d9457610d93610af2565b5b610dc3565b610da68484846001610ca4565b92509050818404811115610dbd57610dbc610af2565b5b81810290505b9392505050565b6000610dd582610ae8565b9150610de083610ae8565b9250610e0d7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8484610cf7565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b6000610e4f82610ae8565b9150610e5a83610ae8565b925082610e6a57610e69610e15565b5b828204905092915050565b600060ff82169050919050565b6000610e8d82610e75565b9150610e9883610e75565b925082610ea857610ea7610e15565b5b828204905092915050565b6000610ebe82610e75565b9150610ec983610e75565b9250828202610ed781610e75565b9150808214610ee957610ee8610af2565b5b5092915050565b6000610efb82610e75565b9150610f0683610e75565b9250828203905060ff811115610f1f57610f1e610af2565b5b92915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b6000610f5f82610ae8565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8203610f9157610f90610af2565b5b600182019050919050565b6000610fa782610e75565b9150610fb283610e75565b9250828201905060ff811115610fcb57610fca610af2565b5b9291505056fea2646970667358221220f76ddf9f0edcb2b19f7b626225062550a78b643887a159a73f94ed95c5617f7f64736f6c63430008150033",
+}
+
+// BankapiABI is the input ABI used to generate the binding from.
+// Deprecated: Use BankapiMetaData.ABI instead.
+var BankapiABI = BankapiMetaData.ABI
+
+// BankapiBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BankapiMetaData.Bin instead.
+var BankapiBin = BankapiMetaData.Bin
+
+// DeployBankapi deploys a new Ethereum contract, binding an instance of Bankapi to it.
+func DeployBankapi(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Bankapi, error) {
+	parsed, err := BankapiMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BankapiBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Bankapi{BankapiCaller: BankapiCaller{contract: contract}, BankapiTransactor: BankapiTransactor{contract: contract}, BankapiFilterer: BankapiFilterer{contract: contract}}, nil
+}
+
+// Bankapi is an auto generated Go binding around an Ethereum contract.
+type Bankapi struct {
+	BankapiCaller     // Read-only binding to the contract
+	BankapiTransactor // Write-only binding to the contract
+	BankapiFilterer   // Log filterer for contract events
+}
+
+// BankapiCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BankapiCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BankapiTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BankapiTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BankapiFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BankapiFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BankapiSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BankapiSession struct {
+	Contract     *Bankapi          // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BankapiCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BankapiCallerSession struct {
+	Contract *BankapiCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts  // Call options to use throughout this session
+}
+
+// BankapiTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BankapiTransactorSession struct {
+	Contract     *BankapiTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// BankapiRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BankapiRaw struct {
+	Contract *Bankapi // Generic contract binding to access the raw methods on
+}
+
+// BankapiCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BankapiCallerRaw struct {
+	Contract *BankapiCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BankapiTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BankapiTransactorRaw struct {
+	Contract *BankapiTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBankapi creates a new instance of Bankapi, bound to a specific deployed contract.
+func NewBankapi(address common.Address, backend bind.ContractBackend) (*Bankapi, error) {
+	contract, err := bindBankapi(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bankapi{BankapiCaller: BankapiCaller{contract: contract}, BankapiTransactor: BankapiTransactor{contract: contract}, BankapiFilterer: BankapiFilterer{contract: contract}}, nil
+}
+
+// NewBankapiCaller creates a new read-only instance of Bankapi, bound to a specific deployed contract.
+func NewBankapiCaller(address common.Address, caller bind.ContractCaller) (*BankapiCaller, error) {
+	contract, err := bindBankapi(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiCaller{contract: contract}, nil
+}
+
+// NewBankapiTransactor creates a new write-only instance of Bankapi, bound to a specific deployed contract.
+func NewBankapiTransactor(address common.Address, transactor bind.ContractTransactor) (*BankapiTransactor, error) {
+	contract, err := bindBankapi(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiTransactor{contract: contract}, nil
+}
+
+// NewBankapiFilterer creates a new log filterer instance of Bankapi, bound to a specific deployed contract.
+func NewBankapiFilterer(address common.Address, filterer bind.ContractFilterer) (*BankapiFilterer, error) {
+	contract, err := bindBankapi(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiFilterer{contract: contract}, nil
+}
+
+// bindBankapi binds a generic wrapper to an already deployed contract.
+func bindBankapi(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BankapiMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bankapi *BankapiRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bankapi.Contract.BankapiCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bankapi *BankapiRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.Contract.BankapiTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bankapi *BankapiRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bankapi.Contract.BankapiTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bankapi *BankapiCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bankapi.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bankapi *BankapiTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bankapi *BankapiTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bankapi.Contract.contract.Transact(opts, method, params...)
+}
+
+// API is a free data retrieval call binding the contract method 0x7d7b0099.
+//
+// Solidity: function API() view returns(address)
+func (_Bankapi *BankapiCaller) API(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "API")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// API is a free data retrieval call binding the contract method 0x7d7b0099.
+//
+// Solidity: function API() view returns(address)
+func (_Bankapi *BankapiSession) API() (common.Address, error) {
+	return _Bankapi.Contract.API(&_Bankapi.CallOpts)
+}
+
+// API is a free data retrieval call binding the contract method 0x7d7b0099.
+//
+// Solidity: function API() view returns(address)
+func (_Bankapi *BankapiCallerSession) API() (common.Address, error) {
+	return _Bankapi.Contract.API(&_Bankapi.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Bankapi *BankapiCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "Owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Bankapi *BankapiSession) Owner() (common.Address, error) {
+	return _Bankapi.Contract.Owner(&_Bankapi.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Bankapi *BankapiCallerSession) Owner() (common.Address, error) {
+	return _Bankapi.Contract.Owner(&_Bankapi.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0xbb62860d.
+//
+// Solidity: function Version() view returns(string)
+func (_Bankapi *BankapiCaller) Version(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "Version")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0xbb62860d.
+//
+// Solidity: function Version() view returns(string)
+func (_Bankapi *BankapiSession) Version() (string, error) {
+	return _Bankapi.Contract.Version(&_Bankapi.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0xbb62860d.
+//
+// Solidity: function Version() view returns(string)
+func (_Bankapi *BankapiCallerSession) Version() (string, error) {
+	return _Bankapi.Contract.Version(&_Bankapi.CallOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Bankapi *BankapiTransactor) Deposit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "Deposit")
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Bankapi *BankapiSession) Deposit() (*types.Transaction, error) {
+	return _Bankapi.Contract.Deposit(&_Bankapi.TransactOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Bankapi *BankapiTransactorSession) Deposit() (*types.Transaction, error) {
+	return _Bankapi.Contract.Deposit(&_Bankapi.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() payable returns()
+func (_Bankapi *BankapiTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "Withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() payable returns()
+func (_Bankapi *BankapiSession) Withdraw() (*types.Transaction, error) {
+	return _Bankapi.Contract.Withdraw(&_Bankapi.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() payable returns()
+func (_Bankapi *BankapiTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _Bankapi.Contract.Withdraw(&_Bankapi.TransactOpts)
+}
+
+// BankapiEventLogIterator is returned from FilterEventLog and is used to iterate over the raw logs and unpacked data for EventLog events raised by the Bankapi contract.
+type BankapiEventLogIterator struct {
+	Event *BankapiEventLog // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BankapiEventLogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BankapiEventLog)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BankapiEventLog)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BankapiEventLogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BankapiEventLogIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BankapiEventLog represents a EventLog event raised by the Bankapi contract.
+type BankapiEventLog struct {
+	Value string
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterEventLog is a free log retrieval operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Bankapi *BankapiFilterer) FilterEventLog(opts *bind.FilterOpts) (*BankapiEventLogIterator, error) {
+
+	logs, sub, err := _Bankapi.contract.FilterLogs(opts, "EventLog")
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiEventLogIterator{contract: _Bankapi.contract, event: "EventLog", logs: logs, sub: sub}, nil
+}
+
+// WatchEventLog is a free log subscription operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Bankapi *BankapiFilterer) WatchEventLog(opts *bind.WatchOpts, sink chan<- *BankapiEventLog) (event.Subscription, error) {
+
+	logs, sub, err := _Bankapi.contract.WatchLogs(opts, "EventLog")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BankapiEventLog)
+				if err := _Bankapi.contract.UnpackLog(event, "EventLog", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEventLog is a log parse operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Bankapi *BankapiFilterer) ParseEventLog(log types.Log) (*BankapiEventLog, error) {
+	event := new(BankapiEventLog)
+	if err := _Bankapi.contract.UnpackLog(event, "EventLog", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
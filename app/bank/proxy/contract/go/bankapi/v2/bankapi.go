@@ -26,12 +26,13 @@ var (
 	_ = common.Big1
 	_ = types.BloomLookup
 	_ = event.NewSubscription
+	_ = abi.ConvertType
 )
 
 // BankapiMetaData contains all meta data concerning the Bankapi contract.
 var BankapiMetaData = &bind.MetaData{
 	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"}],\"name\":\"EventLog\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"API\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"winner\",\"type\":\"address\"},{\"internalType\":\"address[]\",\"name\":\"losers\",\"type\":\"address[]\"},{\"internalType\":\"uint256\",\"name\":\"anteWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"gameFeeWei\",\"type\":\"uint256\"}],\"name\":\"Reconcile\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Version\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Withdraw\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
-	Bin: "0x60806040523480156200001157600080fd5b506040518060400160405280600581526020017f302e312e3000000000000000000000000000000000000000000000000000000081525060019081620000589190620002d9565b50620003c0565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680620000e157607f821691505b602082108103620000f757620000f662000099565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b600060088302620001617fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8262000122565b6200016d868362000122565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b6000620001ba620001b4620001ae8462000185565b6200018f565b62000185565b9050919050565b6000819050919050565b620001d68362000199565b620001ee620001e582620001c1565b8484546200012f565b825550505050565b600090565b62000205620001f6565b62000212818484620001cb565b505050565b5b818110156200023a576200022e600082620001fb565b60018101905062000218565b5050565b601f82111562000289576200025381620000fd565b6200025e8462000112565b810160208510156200026e578190505b620002866200027d8562000112565b83018262000217565b50505b505050565b600082821c905092915050565b6000620002ae600019846008026200028e565b1980831691505092915050565b6000620002c983836200029b565b9150826002028217905092915050565b620002e4826200005f565b67ffffffffffffffff8111156200030057620002ff6200006a565b5b6200030c8254620000c8565b620003198282856200023e565b600060209050601f8311600181146200035157600084156200033c578287015190505b620003488582620002bb565b865550620003b8565b601f1984166200036186620000fd565b60005b828110156200038b5784890151825560018201915060208501945060208101905062000364565b86831015620003ab5784890151620003a7601f8916826200029b565b8355505b6001600288020188555050505b505050505050565b611af880620003d06000396000f3fe6080604052600436106100555760003560e01c806357ea89b61461005a5780637d7b009914610064578063b4a99a4e1461008f578063bb62860d146100ba578063ed21248c146100e5578063fa84fd8e146100ef575b600080fd5b610062610118565b005b34801561007057600080fd5b506100796102db565b6040516100869190610dfa565b60405180910390f35b34801561009b57600080fd5b506100a46102ff565b6040516100b19190610dfa565b60405180910390f35b3480156100c657600080fd5b506100cf610325565b6040516100dc9190610ea5565b60405180910390f35b6100ed6103b3565b005b3480156100fb57600080fd5b5061011660048036038101906101119190611085565b6104b2565b005b60003390506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020540361019f576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161019690611154565b60405180910390fd5b6000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205490508173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f19350505050158015610229573d6000803e3d6000fd5b506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61029933610a28565b6102a283610beb565b6040516020016102b3929190611222565b6040516020818303038152906040526040516102cf9190610ea5565b60405180910390a15050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60018054610332906112a2565b80601f016020809104026020016040519081016040528092919081815260200182805461035e906112a2565b80156103ab5780601f10610380576101008083540402835291602001916103ab565b820191906000526020600020905b81548152906001019060200180831161038e57829003601f168201915b505050505081565b34600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546104029190611302565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61043333610a28565b61047b600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054610beb565b60405160200161048c929190611382565b6040516020818303038152906040526040516104a89190610ea5565b60405180910390a1565b600082905060005b84518110156107425783600360008784815181106104db576104da6113d3565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205410156106b0577fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6105a76003600088858151811061055f5761055e6113d3565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054610beb565b6105b086610beb565b6040516020016105c192919061144e565b6040516020818303038152906040526040516105dd9190610ea5565b60405180910390a1600360008683815181106105fc576105fb6113d3565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020548261064a9190611302565b9150600060036000878481518110610665576106646113d3565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000208190555061072f565b83826106bc9190611302565b915083600360008784815181106106d6576106d56113d3565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546107279190611490565b925050819055505b808061073a906114c4565b9150506104ba565b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61076d84610beb565b61077684610beb565b61077f84610beb565b6040516020016107919392919061157e565b6040516020818303038152906040526040516107ad9190610ea5565b60405180910390a1600081036107f8576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016107ef9061165d565b60405180910390fd5b818110156108dc578060036000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546108719190611302565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6108a282610beb565b6040516020016108b291906116ef565b6040516020818303038152906040526040516108ce9190610ea5565b60405180910390a150610a22565b81816108e89190611490565b905080600360008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546109399190611302565b925050819055508160036000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546109b19190611302565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6109e282610beb565b6109eb84610beb565b6040516020016109fc92919061176c565b604051602081830303815290604052604051610a189190610ea5565b60405180910390a1505b50505050565b60606000602867ffffffffffffffff811115610a4757610a46610f0c565b5b6040519080825280601f01601f191660200182016040528015610a795781602001600182028036833780820191505090505b50905060005b6014811015610be1576000816013610a979190611490565b6008610aa391906117bd565b6002610aaf9190611932565b8573ffffffffffffffffffffffffffffffffffffffff16610ad091906119ac565b60f81b9050600060108260f81c610ae791906119ea565b60f81b905060008160f81c6010610afe9190611a1b565b8360f81c610b0c9190611a58565b60f81b9050610b1a82610d73565b85856002610b2891906117bd565b81518110610b3957610b386113d3565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350610b7181610d73565b856001866002610b8191906117bd565b610b8b9190611302565b81518110610b9c57610b9b6113d3565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053505050508080610bd9906114c4565b915050610a7f565b5080915050919050565b606060008203610c32576040518060400160405280600181526020017f30000000000000000000000000000000000000000000000000000000000000008152509050610d6e565b600082905060005b60008214610c64578080610c4d906114c4565b915050600a82610c5d91906119ac565b9150610c3a565b60008167ffffffffffffffff811115610c8057610c7f610f0c565b5b6040519080825280601f01601f191660200182016040528015610cb25781602001600182028036833780820191505090505b50905060008290505b60008614610d6657600181610cd09190611490565b90506000600a8088610ce291906119ac565b610cec91906117bd565b87610cf79190611490565b6030610d039190611a8d565b905060008160f81b905080848481518110610d2157610d206113d3565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350600a88610d5d91906119ac565b97505050610cbb565b819450505050505b919050565b6000600a8260f81c60ff161015610d9e5760308260f81c610d949190611a8d565b60f81b9050610db4565b60578260f81c610dae9190611a8d565b60f81b90505b919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000610de482610db9565b9050919050565b610df481610dd9565b82525050565b6000602082019050610e0f6000830184610deb565b92915050565b600081519050919050565b600082825260208201905092915050565b60005b83811015610e4f578082015181840152602081019050610e34565b60008484015250505050565b6000601f19601f8301169050919050565b6000610e7782610e15565b610e818185610e20565b9350610e91818560208601610e31565b610e9a81610e5b565b840191505092915050565b60006020820190508181036000830152610ebf8184610e6c565b905092915050565b6000604051905090565b600080fd5b600080fd5b610ee481610dd9565b8114610eef57600080fd5b50565b600081359050610f0181610edb565b92915050565b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b610f4482610e5b565b810181811067ffffffffffffffff82111715610f6357610f62610f0c565b5b80604052505050565b6000610f76610ec7565b9050610f828282610f3b565b919050565b600067ffffffffffffffff821115610fa257610fa1610f0c565b5b602082029050602081019050919050565b600080fd5b6000610fcb610fc684610f87565b610f6c565b90508083825260208201905060208402830185811115610fee57610fed610fb3565b5b835b8181101561101757806110038882610ef2565b845260208401935050602081019050610ff0565b5050509392505050565b600082601f83011261103657611035610f07565b5b8135611046848260208601610fb8565b91505092915050565b6000819050919050565b6110628161104f565b811461106d57600080fd5b50565b60008135905061107f81611059565b92915050565b6000806000806080858703121561109f5761109e610ed1565b5b60006110ad87828801610ef2565b945050602085013567ffffffffffffffff8111156110ce576110cd610ed6565b5b6110da87828801611021565b93505060406110eb87828801611070565b92505060606110fc87828801611070565b91505092959194509250565b7f6e6f7420656e6f7567682062616c616e63650000000000000000000000000000600082015250565b600061113e601283610e20565b915061114982611108565b602082019050919050565b6000602082019050818103600083015261116d81611131565b9050919050565b7f77697468647261775b0000000000000000000000000000000000000000000000815250565b600081905092915050565b60006111b082610e15565b6111ba818561119a565b93506111ca818560208601610e31565b80840191505092915050565b7f5d20616d6f756e745b0000000000000000000000000000000000000000000000815250565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b600061122d82611174565b60098201915061123d82856111a5565b9150611248826111d6565b60098201915061125882846111a5565b9150611263826111fc565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b600060028204905060018216806112ba57607f821691505b6020821081036112cd576112cc611273565b5b50919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b600061130d8261104f565b91506113188361104f565b92508282019050808211156113305761132f6112d3565b5b92915050565b7f6465706f7369745b000000000000000000000000000000000000000000000000815250565b7f5d2062616c616e63655b00000000000000000000000000000000000000000000815250565b600061138d82611336565b60088201915061139d82856111a5565b91506113a88261135c565b600a820191506113b882846111a5565b91506113c3826111fc565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f6163636f756e742062616c616e63652000000000000000000000000000000000815250565b7f206973206c657373207468616e2074686520616e746520000000000000000000815250565b600061145982611402565b60108201915061146982856111a5565b915061147482611428565b60178201915061148482846111a5565b91508190509392505050565b600061149b8261104f565b91506114a68361104f565b92508282039050818111156114be576114bd6112d3565b5b92915050565b60006114cf8261104f565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8203611501576115006112d3565b5b600182019050919050565b7f616e74655b000000000000000000000000000000000000000000000000000000815250565b7f5d2067616d654665655b00000000000000000000000000000000000000000000815250565b7f5d20706f745b0000000000000000000000000000000000000000000000000000815250565b60006115898261150c565b60058201915061159982866111a5565b91506115a482611532565b600a820191506115b482856111a5565b91506115bf82611558565b6006820191506115cf82846111a5565b91506115da826111fc565b600182019150819050949350505050565b7f6e6f20706f74207761732063726561746564206261736564206f6e207465682060008201527f6163636f756e742062616c616e63657300000000000000000000000000000000602082015250565b6000611647603083610e20565b9150611652826115eb565b604082019050919050565b600060208201905081810360008301526116768161163a565b9050919050565b7f706f7420776173206c657373207468616e206665653a2077696e6e65725b305d60008201527f206f776e65725b00000000000000000000000000000000000000000000000000602082015250565b60006116d960278361119a565b91506116e48261167d565b602782019050919050565b60006116fa826116cc565b915061170682846111a5565b9150611711826111fc565b60018201915081905092915050565b7f77696e6e65725b00000000000000000000000000000000000000000000000000815250565b7f5d206f776e65725b000000000000000000000000000000000000000000000000815250565b600061177782611720565b60078201915061178782856111a5565b915061179282611746565b6008820191506117a282846111a5565b91506117ad826111fc565b6001820191508190509392505050565b60006117c88261104f565b91506117d38361104f565b92508282026117e18161104f565b915082820484148315176117f8576117f76112d3565b5b5092915050565b60008160011c9050919050565b6000808291508390505b600185111561185657808604811115611832576118316112d3565b5b60018516156118415780820291505b808102905061184f856117ff565b9450611816565b94509492505050565b60008261186f576001905061192b565b8161187d576000905061192b565b8160018114611893576002811461189d576118cc565b600191505061192b565b60ff8411156118af576118ae6112d3565b5b8360020a9150848211156118c6576118c56112d3565b5b5061192b565b5060208310610133831016604e8410600b84101617156119015782820a9050838111156118fc576118fb6112d3565b5b61192b565b61190e848484600161180c565b92509050818404811115611925576119246112d3565b5b81810290505b9392505050565b600061193d8261104f565b91506119488361104f565b92506119757fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff848461185f565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b60006119b78261104f565b91506119c28361104f565b9250826119d2576119d161197d565b5b828204905092915050565b600060ff82169050919050565b60006119f5826119dd565b9150611a00836119dd565b925082611a1057611a0f61197d565b5b828204905092915050565b6000611a26826119dd565b9150611a31836119dd565b9250828202611a3f816119dd565b9150808214611a5157611a506112d3565b5b5092915050565b6000611a63826119dd565b9150611a6e836119dd565b9250828203905060ff811115611a8757611a866112d3565b5b92915050565b6000611a98826119dd565b9150611aa3836119dd565b9250828201905060ff811115611abc57611abb6112d3565b5b9291505056fea2646970667358221220cb71f460675e12e907a6df15ce47ed8bfecdf8e5551907e348ffadef982c289c64736f6c63430008120033",
+	Bin: "0x60806040523480156200001157600080fd5b506040518060400160405280600581526020017f302e322e3000000000000000000000000000000000000000000000000000000081525060019081620000589190620002d9565b50620003c0565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680620000e157607f821691505b602082108103620000f757620000f662000099565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b600060088302620001617fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8262000122565b6200016d868362000122565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b6000620001ba620001b4620001ae8462000185565b6200018f565b62000185565b9050919050565b6000819050919050565b620001d68362000199565b620001ee620001e582620001c1565b8484546200012f565b825550505050565b600090565b62000205620001f6565b62000212818484620001cb565b505050565b5b818110156200023a576200022e600082620001fb565b60018101905062000218565b5050565b601f82111562000289576200025381620000fd565b6200025e8462000112565b810160208510156200026e578190505b620002866200027d8562000112565b83018262000217565b50505b505050565b600082821c905092915050565b6000620002ae600019846008026200028e565b1980831691505092915050565b6000620002c983836200029b565b9150826002028217905092915050565b620002e4826200005f565b67ffffffffffffffff8111156200030057620002ff6200006a565b5b6200030c8254620000c8565b620003198282856200023e565b600060209050601f8311600181146200035157600084156200033c578287015190505b620003488582620002bb565b865550620003b8565b601f1984166200036186620000fd565b60005b828110156200038b5784890151825560018201915060208501945060208101905062000364565b86831015620003ab5784890151620003a7601f8916826200029b565b8355505b6001600288020188555050505b505050505050565b611b5280620003d06000396000f3fe6080604052600436106100555760003560e01c806357ea89b61461005a5780637d7b009914610064578063b4a99a4e1461008f578063bb62860d146100ba578063ed21248c146100e5578063fa84fd8e146100ef575b600080fd5b610062610118565b005b34801561007057600080fd5b506100796102db565b6040516100869190610e54565b60405180910390f35b34801561009b57600080fd5b506100a46102ff565b6040516100b19190610e54565b60405180910390f35b3480156100c657600080fd5b506100cf610325565b6040516100dc9190610eff565b60405180910390f35b6100ed6103b3565b005b3480156100fb57600080fd5b50610116600480360381019061011191906110df565b6104b2565b005b60003390506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020540361019f576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610196906111ae565b60405180910390fd5b6000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205490508173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f19350505050158015610229573d6000803e3d6000fd5b506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61029933610a82565b6102a283610c45565b6040516020016102b392919061127c565b6040516020818303038152906040526040516102cf9190610eff565b60405180910390a15050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60018054610332906112fc565b80601f016020809104026020016040519081016040528092919081815260200182805461035e906112fc565b80156103ab5780601f10610380576101008083540402835291602001916103ab565b820191906000526020600020905b81548152906001019060200180831161038e57829003601f168201915b505050505081565b34600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610402919061135c565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61043333610a82565b61047b600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054610c45565b60405160200161048c9291906113dc565b6040516020818303038152906040526040516104a89190610eff565b60405180910390a1565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461050c57600080fd5b600082905060005b845181101561079c5783600360008784815181106105355761053461142d565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054101561070a577fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610601600360008885815181106105b9576105b861142d565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054610c45565b61060a86610c45565b60405160200161061b9291906114a8565b6040516020818303038152906040526040516106379190610eff565b60405180910390a1600360008683815181106106565761065561142d565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054826106a4919061135c565b91506000600360008784815181106106bf576106be61142d565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550610789565b8382610716919061135c565b915083600360008784815181106107305761072f61142d565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825461078191906114ea565b925050819055505b80806107949061151e565b915050610514565b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6107c784610c45565b6107d084610c45565b6107d984610c45565b6040516020016107eb939291906115d8565b6040516020818303038152906040526040516108079190610eff565b60405180910390a160008103610852576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610849906116b7565b60405180910390fd5b81811015610936578060036000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546108cb919061135c565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6108fc82610c45565b60405160200161090c9190611749565b6040516020818303038152906040526040516109289190610eff565b60405180910390a150610a7c565b818161094291906114ea565b905080600360008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610993919061135c565b925050819055508160036000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610a0b919061135c565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610a3c82610c45565b610a4584610c45565b604051602001610a569291906117c6565b604051602081830303815290604052604051610a729190610eff565b60405180910390a1505b50505050565b60606000602867ffffffffffffffff811115610aa157610aa0610f66565b5b6040519080825280601f01601f191660200182016040528015610ad35781602001600182028036833780820191505090505b50905060005b6014811015610c3b576000816013610af191906114ea565b6008610afd9190611817565b6002610b09919061198c565b8573ffffffffffffffffffffffffffffffffffffffff16610b2a9190611a06565b60f81b9050600060108260f81c610b419190611a44565b60f81b905060008160f81c6010610b589190611a75565b8360f81c610b669190611ab2565b60f81b9050610b7482610dcd565b85856002610b829190611817565b81518110610b9357610b9261142d565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350610bcb81610dcd565b856001866002610bdb9190611817565b610be5919061135c565b81518110610bf657610bf561142d565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053505050508080610c339061151e565b915050610ad9565b5080915050919050565b606060008203610c8c576040518060400160405280600181526020017f30000000000000000000000000000000000000000000000000000000000000008152509050610dc8565b600082905060005b60008214610cbe578080610ca79061151e565b915050600a82610cb79190611a06565b9150610c94565b60008167ffffffffffffffff811115610cda57610cd9610f66565b5b6040519080825280601f01601f191660200182016040528015610d0c5781602001600182028036833780820191505090505b50905060008290505b60008614610dc057600181610d2a91906114ea565b90506000600a8088610d3c9190611a06565b610d469190611817565b87610d5191906114ea565b6030610d5d9190611ae7565b905060008160f81b905080848481518110610d7b57610d7a61142d565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350600a88610db79190611a06565b97505050610d15565b819450505050505b919050565b6000600a8260f81c60ff161015610df85760308260f81c610dee9190611ae7565b60f81b9050610e0e565b60578260f81c610e089190611ae7565b60f81b90505b919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000610e3e82610e13565b9050919050565b610e4e81610e33565b82525050565b6000602082019050610e696000830184610e45565b92915050565b600081519050919050565b600082825260208201905092915050565b60005b83811015610ea9578082015181840152602081019050610e8e565b60008484015250505050565b6000601f19601f8301169050919050565b6000610ed182610e6f565b610edb8185610e7a565b9350610eeb818560208601610e8b565b610ef481610eb5565b840191505092915050565b60006020820190508181036000830152610f198184610ec6565b905092915050565b6000604051905090565b600080fd5b600080fd5b610f3e81610e33565b8114610f4957600080fd5b50565b600081359050610f5b81610f35565b92915050565b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b610f9e82610eb5565b810181811067ffffffffffffffff82111715610fbd57610fbc610f66565b5b80604052505050565b6000610fd0610f21565b9050610fdc8282610f95565b919050565b600067ffffffffffffffff821115610ffc57610ffb610f66565b5b602082029050602081019050919050565b600080fd5b600061102561102084610fe1565b610fc6565b905080838252602082019050602084028301858111156110485761104761100d565b5b835b81811015611071578061105d8882610f4c565b84526020840193505060208101905061104a565b5050509392505050565b600082601f8301126110905761108f610f61565b5b81356110a0848260208601611012565b91505092915050565b6000819050919050565b6110bc816110a9565b81146110c757600080fd5b50565b6000813590506110d9816110b3565b92915050565b600080600080608085870312156110f9576110f8610f2b565b5b600061110787828801610f4c565b945050602085013567ffffffffffffffff81111561112857611127610f30565b5b6111348782880161107b565b9350506040611145878288016110ca565b9250506060611156878288016110ca565b91505092959194509250565b7f6e6f7420656e6f7567682062616c616e63650000000000000000000000000000600082015250565b6000611198601283610e7a565b91506111a382611162565b602082019050919050565b600060208201905081810360008301526111c78161118b565b9050919050565b7f77697468647261775b0000000000000000000000000000000000000000000000815250565b600081905092915050565b600061120a82610e6f565b61121481856111f4565b9350611224818560208601610e8b565b80840191505092915050565b7f5d20616d6f756e745b0000000000000000000000000000000000000000000000815250565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b6000611287826111ce565b60098201915061129782856111ff565b91506112a282611230565b6009820191506112b282846111ff565b91506112bd82611256565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061131457607f821691505b602082108103611327576113266112cd565b5b50919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000611367826110a9565b9150611372836110a9565b925082820190508082111561138a5761138961132d565b5b92915050565b7f6465706f7369745b000000000000000000000000000000000000000000000000815250565b7f5d2062616c616e63655b00000000000000000000000000000000000000000000815250565b60006113e782611390565b6008820191506113f782856111ff565b9150611402826113b6565b600a8201915061141282846111ff565b915061141d82611256565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f6163636f756e742062616c616e63652000000000000000000000000000000000815250565b7f206973206c657373207468616e2074686520616e746520000000000000000000815250565b60006114b38261145c565b6010820191506114c382856111ff565b91506114ce82611482565b6017820191506114de82846111ff565b91508190509392505050565b60006114f5826110a9565b9150611500836110a9565b92508282039050818111156115185761151761132d565b5b92915050565b6000611529826110a9565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff820361155b5761155a61132d565b5b600182019050919050565b7f616e74655b000000000000000000000000000000000000000000000000000000815250565b7f5d2067616d654665655b00000000000000000000000000000000000000000000815250565b7f5d20706f745b0000000000000000000000000000000000000000000000000000815250565b60006115e382611566565b6005820191506115f382866111ff565b91506115fe8261158c565b600a8201915061160e82856111ff565b9150611619826115b2565b60068201915061162982846111ff565b915061163482611256565b600182019150819050949350505050565b7f6e6f20706f74207761732063726561746564206261736564206f6e207465682060008201527f6163636f756e742062616c616e63657300000000000000000000000000000000602082015250565b60006116a1603083610e7a565b91506116ac82611645565b604082019050919050565b600060208201905081810360008301526116d081611694565b9050919050565b7f706f7420776173206c657373207468616e206665653a2077696e6e65725b305d60008201527f206f776e65725b00000000000000000000000000000000000000000000000000602082015250565b60006117336027836111f4565b915061173e826116d7565b602782019050919050565b600061175482611726565b915061176082846111ff565b915061176b82611256565b60018201915081905092915050565b7f77696e6e65725b00000000000000000000000000000000000000000000000000815250565b7f5d206f776e65725b000000000000000000000000000000000000000000000000815250565b60006117d18261177a565b6007820191506117e182856111ff565b91506117ec826117a0565b6008820191506117fc82846111ff565b915061180782611256565b6001820191508190509392505050565b6000611822826110a9565b915061182d836110a9565b925082820261183b816110a9565b915082820484148315176118525761185161132d565b5b5092915050565b60008160011c9050919050565b6000808291508390505b60018511156118b05780860481111561188c5761188b61132d565b5b600185161561189b5780820291505b80810290506118a985611859565b9450611870565b94509492505050565b6000826118c95760019050611985565b816118d75760009050611985565b81600181146118ed57600281146118f757611926565b6001915050611985565b60ff8411156119095761190861132d565b5b8360020a9150848211156119205761191f61132d565b5b50611985565b5060208310610133831016604e8410600b841016171561195b5782820a9050838111156119565761195561132d565b5b611985565b6119688484846001611866565b9250905081840481111561197f5761197e61132d565b5b81810290505b9392505050565b6000611997826110a9565b91506119a2836110a9565b92506119cf7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff84846118b9565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b6000611a11826110a9565b9150611a1c836110a9565b925082611a2c57611a2b6119d7565b5b828204905092915050565b600060ff82169050919050565b6000611a4f82611a37565b9150611a5a83611a37565b925082611a6a57611a696119d7565b5b828204905092915050565b6000611a8082611a37565b9150611a8b83611a37565b9250828202611a9981611a37565b9150808214611aab57611aaa61132d565b5b5092915050565b6000611abd82611a37565b9150611ac883611a37565b9250828203905060ff811115611ae157611ae061132d565b5b92915050565b6000611af282611a37565b9150611afd83611a37565b9250828201905060ff811115611b1657611b1561132d565b5b9291505056fea2646970667358221220365cf3f840422d19145c8d887c3965e069699aac4dd923f20b41ce47154e715c64736f6c63430008150033",
 }
 
 // BankapiABI is the input ABI used to generate the binding from.
@@ -156,11 +157,11 @@ func NewBankapiFilterer(address common.Address, filterer bind.ContractFilterer)
 
 // bindBankapi binds a generic wrapper to an already deployed contract.
 func bindBankapi(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
-	parsed, err := abi.JSON(strings.NewReader(BankapiABI))
+	parsed, err := BankapiMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
-	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
 }
 
 // Call invokes the (constant) contract method with params as input values and
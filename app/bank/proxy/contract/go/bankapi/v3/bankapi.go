@@ -0,0 +1,566 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bankapi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BankapiMetaData contains all meta data concerning the Bankapi contract.
+var BankapiMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"}],\"name\":\"EventLog\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"API\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"DepositCap\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"winner\",\"type\":\"address\"},{\"internalType\":\"address[]\",\"name\":\"losers\",\"type\":\"address[]\"},{\"internalType\":\"uint256\",\"name\":\"anteWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"gameFeeWei\",\"type\":\"uint256\"}],\"name\":\"Reconcile\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"capWei\",\"type\":\"uint256\"}],\"name\":\"SetDepositCap\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Version\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Withdraw\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amountWei\",\"type\":\"uint256\"}],\"name\":\"WithdrawAmount\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x60806040523480156200001157600080fd5b506040518060400160405280600581526020017f302e332e3000000000000000000000000000000000000000000000000000000081525060019081620000589190620002d9565b50620003c0565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680620000e157607f821691505b602082108103620000f757620000f662000099565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b600060088302620001617fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8262000122565b6200016d868362000122565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b6000620001ba620001b4620001ae8462000185565b6200018f565b62000185565b9050919050565b6000819050919050565b620001d68362000199565b620001ee620001e582620001c1565b8484546200012f565b825550505050565b600090565b62000205620001f6565b62000212818484620001cb565b505050565b5b818110156200023a576200022e600082620001fb565b60018101905062000218565b5050565b601f82111562000289576200025381620000fd565b6200025e8462000112565b810160208510156200026e578190505b620002866200027d8562000112565b83018262000217565b50505b505050565b600082821c905092915050565b6000620002ae600019846008026200028e565b1980831691505092915050565b6000620002c983836200029b565b9150826002028217905092915050565b620002e4826200005f565b67ffffffffffffffff8111156200030057620002ff6200006a565b5b6200030c8254620000c8565b620003198282856200023e565b600060209050601f8311600181146200035157600084156200033c578287015190505b620003488582620002bb565b865550620003b8565b601f1984166200036186620000fd565b60005b828110156200038b5784890151825560018201915060208501945060208101905062000364565b86831015620003ab5784890151620003a7601f8916826200029b565b8355505b6001600288020188555050505b505050505050565b61224580620003d06000396000f3fe6080604052600436106100865760003560e01c8063b4a99a4e11610059578063b4a99a4e14610112578063bb62860d1461013d578063c2c2673c14610168578063ed21248c146101a5578063fa84fd8e146101af57610086565b80632a82c7b71461008b57806357ea89b6146100b45780637d7b0099146100be578063a4627c8f146100e9575b600080fd5b34801561009757600080fd5b506100b260048036038101906100ad91906113ba565b6101d8565b005b6100bc6102e2565b005b3480156100ca57600080fd5b506100d36104a5565b6040516100e09190611409565b60405180910390f35b3480156100f557600080fd5b50610110600480360381019061010b9190611424565b6104c9565b005b34801561011e57600080fd5b506101276106ad565b6040516101349190611409565b60405180910390f35b34801561014957600080fd5b506101526106d3565b60405161015f91906114e1565b60405180910390f35b34801561017457600080fd5b5061018f600480360381019061018a9190611503565b610761565b60405161019c919061153f565b60405180910390f35b6101ad6107aa565b005b3480156101bb57600080fd5b506101d660048036038101906101d191906116a2565b6109b1565b005b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461023257600080fd5b80600460008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a6102a083610f81565b6102a983611144565b6040516020016102ba9291906117d3565b6040516020818303038152906040526040516102d691906114e1565b60405180910390a15050565b60003390506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205403610369576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161036090611870565b60405180910390fd5b6000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205490506000600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f19350505050158015610438573d6000803e3d6000fd5b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61046333610f81565b61046c83611144565b60405160200161047d9291906118b6565b60405160208183030381529060405260405161049991906114e1565b60405180910390a15050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6000339050600082148061051b575081600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054105b1561055b576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161055290611870565b60405180910390fd5b81600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546105aa9190611936565b925050819055508073ffffffffffffffffffffffffffffffffffffffff166108fc839081150290604051600060405180830381858888f193505050501580156105f7573d6000803e3d6000fd5b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61062233610f81565b61062b84611144565b610673600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611144565b60405160200161068593929190611990565b6040516020818303038152906040526040516106a191906114e1565b60405180910390a15050565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600180546106e090611a2c565b80601f016020809104026020016040519081016040528092919081815260200182805461070c90611a2c565b80156107595780601f1061072e57610100808354040283529160200191610759565b820191906000526020600020905b81548152906001019060200180831161073c57829003601f168201915b505050505081565b6000600460008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b6000600460003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205490506000811415801561084857508034600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020546108469190611a5d565b115b156108b15761085681611144565b6040516020016108669190611ab7565b6040516020818303038152906040526040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016108a891906114e1565b60405180910390fd5b34600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546109009190611a5d565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61093133610f81565b610979600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611144565b60405160200161098a929190611b03565b6040516020818303038152906040526040516109a691906114e1565b60405180910390a150565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610a0b57600080fd5b600082905060005b8451811015610c9b578360036000878481518110610a3457610a33611b54565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020541015610c09577fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610b0060036000888581518110610ab857610ab7611b54565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611144565b610b0986611144565b604051602001610b1a929190611bcf565b604051602081830303815290604052604051610b3691906114e1565b60405180910390a160036000868381518110610b5557610b54611b54565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205482610ba39190611a5d565b9150600060036000878481518110610bbe57610bbd611b54565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550610c88565b8382610c159190611a5d565b91508360036000878481518110610c2f57610c2e611b54565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610c809190611936565b925050819055505b8080610c9390611c11565b915050610a13565b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610cc684611144565b610ccf84611144565b610cd884611144565b604051602001610cea93929190611ccb565b604051602081830303815290604052604051610d0691906114e1565b60405180910390a160008103610d51576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610d4890611daa565b60405180910390fd5b81811015610e35578060036000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610dca9190611a5d565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610dfb82611144565b604051602001610e0b9190611e3c565b604051602081830303815290604052604051610e2791906114e1565b60405180910390a150610f7b565b8181610e419190611936565b905080600360008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610e929190611a5d565b925050819055508160036000600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254610f0a9190611a5d565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a610f3b82611144565b610f4484611144565b604051602001610f55929190611eb9565b604051602081830303815290604052604051610f7191906114e1565b60405180910390a1505b50505050565b60606000602867ffffffffffffffff811115610fa057610f9f61155f565b5b6040519080825280601f01601f191660200182016040528015610fd25781602001600182028036833780820191505090505b50905060005b601481101561113a576000816013610ff09190611936565b6008610ffc9190611f0a565b6002611008919061207f565b8573ffffffffffffffffffffffffffffffffffffffff1661102991906120f9565b60f81b9050600060108260f81c6110409190612137565b60f81b905060008160f81c60106110579190612168565b8360f81c61106591906121a5565b60f81b9050611073826112cc565b858560026110819190611f0a565b8151811061109257611091611b54565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053506110ca816112cc565b8560018660026110da9190611f0a565b6110e49190611a5d565b815181106110f5576110f4611b54565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350505050808061113290611c11565b915050610fd8565b5080915050919050565b60606000820361118b576040518060400160405280600181526020017f300000000000000000000000000000000000000000000000000000000000000081525090506112c7565b600082905060005b600082146111bd5780806111a690611c11565b915050600a826111b691906120f9565b9150611193565b60008167ffffffffffffffff8111156111d9576111d861155f565b5b6040519080825280601f01601f19166020018201604052801561120b5781602001600182028036833780820191505090505b50905060008290505b600086146112bf576001816112299190611936565b90506000600a808861123b91906120f9565b6112459190611f0a565b876112509190611936565b603061125c91906121da565b905060008160f81b90508084848151811061127a57611279611b54565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350600a886112b691906120f9565b97505050611214565b819450505050505b919050565b6000600a8260f81c60ff1610156112f75760308260f81c6112ed91906121da565b60f81b905061130d565b60578260f81c61130791906121da565b60f81b90505b919050565b6000604051905090565b600080fd5b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061135182611326565b9050919050565b61136181611346565b811461136c57600080fd5b50565b60008135905061137e81611358565b92915050565b6000819050919050565b61139781611384565b81146113a257600080fd5b50565b6000813590506113b48161138e565b92915050565b600080604083850312156113d1576113d061131c565b5b60006113df8582860161136f565b92505060206113f0858286016113a5565b9150509250929050565b61140381611346565b82525050565b600060208201905061141e60008301846113fa565b92915050565b60006020828403121561143a5761143961131c565b5b6000611448848285016113a5565b91505092915050565b600081519050919050565b600082825260208201905092915050565b60005b8381101561148b578082015181840152602081019050611470565b60008484015250505050565b6000601f19601f8301169050919050565b60006114b382611451565b6114bd818561145c565b93506114cd81856020860161146d565b6114d681611497565b840191505092915050565b600060208201905081810360008301526114fb81846114a8565b905092915050565b6000602082840312156115195761151861131c565b5b60006115278482850161136f565b91505092915050565b61153981611384565b82525050565b60006020820190506115546000830184611530565b92915050565b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b61159782611497565b810181811067ffffffffffffffff821117156115b6576115b561155f565b5b80604052505050565b60006115c9611312565b90506115d5828261158e565b919050565b600067ffffffffffffffff8211156115f5576115f461155f565b5b602082029050602081019050919050565b600080fd5b600061161e611619846115da565b6115bf565b9050808382526020820190506020840283018581111561164157611640611606565b5b835b8181101561166a5780611656888261136f565b845260208401935050602081019050611643565b5050509392505050565b600082601f8301126116895761168861155a565b5b813561169984826020860161160b565b91505092915050565b600080600080608085870312156116bc576116bb61131c565b5b60006116ca8782880161136f565b945050602085013567ffffffffffffffff8111156116eb576116ea611321565b5b6116f787828801611674565b9350506040611708878288016113a5565b9250506060611719878288016113a5565b91505092959194509250565b7f6361705b00000000000000000000000000000000000000000000000000000000815250565b600081905092915050565b600061176182611451565b61176b818561174b565b935061177b81856020860161146d565b80840191505092915050565b7f5d20616d6f756e745b0000000000000000000000000000000000000000000000815250565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b60006117de82611725565b6004820191506117ee8285611756565b91506117f982611787565b6009820191506118098284611756565b9150611814826117ad565b6001820191508190509392505050565b7f6e6f7420656e6f7567682062616c616e63650000000000000000000000000000600082015250565b600061185a60128361145c565b915061186582611824565b602082019050919050565b600060208201905081810360008301526118898161184d565b9050919050565b7f77697468647261775b0000000000000000000000000000000000000000000000815250565b60006118c182611890565b6009820191506118d18285611756565b91506118dc82611787565b6009820191506118ec8284611756565b91506118f7826117ad565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b600061194182611384565b915061194c83611384565b925082820390508181111561196457611963611907565b5b92915050565b7f5d2062616c616e63655b00000000000000000000000000000000000000000000815250565b600061199b82611890565b6009820191506119ab8286611756565b91506119b682611787565b6009820191506119c68285611756565b91506119d18261196a565b600a820191506119e18284611756565b91506119ec826117ad565b600182019150819050949350505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b60006002820490506001821680611a4457607f821691505b602082108103611a5757611a566119fd565b5b50919050565b6000611a6882611384565b9150611a7383611384565b9250828201905080821115611a8b57611a8a611907565b5b92915050565b7f6465706f73697420657863656564732074686520636170206f66200000000000815250565b6000611ac282611a91565b601b82019150611ad28284611756565b915081905092915050565b7f6465706f7369745b000000000000000000000000000000000000000000000000815250565b6000611b0e82611add565b600882019150611b1e8285611756565b9150611b298261196a565b600a82019150611b398284611756565b9150611b44826117ad565b6001820191508190509392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f6163636f756e742062616c616e63652000000000000000000000000000000000815250565b7f206973206c657373207468616e2074686520616e746520000000000000000000815250565b6000611bda82611b83565b601082019150611bea8285611756565b9150611bf582611ba9565b601782019150611c058284611756565b91508190509392505050565b6000611c1c82611384565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8203611c4e57611c4d611907565b5b600182019050919050565b7f616e74655b000000000000000000000000000000000000000000000000000000815250565b7f5d2067616d654665655b00000000000000000000000000000000000000000000815250565b7f5d20706f745b0000000000000000000000000000000000000000000000000000815250565b6000611cd682611c59565b600582019150611ce68286611756565b9150611cf182611c7f565b600a82019150611d018285611756565b9150611d0c82611ca5565b600682019150611d1c8284611756565b9150611d27826117ad565b600182019150819050949350505050565b7f6e6f20706f74207761732063726561746564206261736564206f6e207465682060008201527f6163636f756e742062616c616e63657300000000000000000000000000000000602082015250565b6000611d9460308361145c565b9150611d9f82611d38565b604082019050919050565b60006020820190508181036000830152611dc381611d87565b9050919050565b7f706f7420776173206c657373207468616e206665653a2077696e6e65725b305d60008201527f206f776e65725b00000000000000000000000000000000000000000000000000602082015250565b6000611e2660278361174b565b9150611e3182611dca565b602782019050919050565b6000611e4782611e19565b9150611e538284611756565b9150611e5e826117ad565b60018201915081905092915050565b7f77696e6e65725b00000000000000000000000000000000000000000000000000815250565b7f5d206f776e65725b000000000000000000000000000000000000000000000000815250565b6000611ec482611e6d565b600782019150611ed48285611756565b9150611edf82611e93565b600882019150611eef8284611756565b9150611efa826117ad565b6001820191508190509392505050565b6000611f1582611384565b9150611f2083611384565b9250828202611f2e81611384565b91508282048414831517611f4557611f44611907565b5b5092915050565b60008160011c9050919050565b6000808291508390505b6001851115611fa357808604811115611f7f57611f7e611907565b5b6001851615611f8e5780820291505b8081029050611f9c85611f4c565b9450611f63565b94509492505050565b600082611fbc5760019050612078565b81611fca5760009050612078565b8160018114611fe05760028114611fea57612019565b6001915050612078565b60ff841115611ffc57611ffb611907565b5b8360020a91508482111561201357612012611907565b5b50612078565b5060208310610133831016604e8410600b841016171561204e5782820a90508381111561204957612048611907565b5b612078565b61205b8484846001611f59565b9250905081840481111561207257612071611907565b5b81810290505b9392505050565b600061208a82611384565b915061209583611384565b92506120c27fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8484611fac565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b600061210482611384565b915061210f83611384565b92508261211f5761211e6120ca565b5b828204905092915050565b600060ff82169050919050565b60006121428261212a565b915061214d8361212a565b92508261215d5761215c6120ca565b5b828204905092915050565b60006121738261212a565b915061217e8361212a565b925082820261218c8161212a565b915080821461219e5761219d611907565b5b5092915050565b60006121b08261212a565b91506121bb8361212a565b9250828203905060ff8111156121d4576121d3611907565b5b92915050565b60006121e58261212a565b91506121f08361212a565b9250828201905060ff81111561220957612208611907565b5b9291505056fea26469706673582212208e076c0d7820b20a82f7da6522c9867905fcf4ca275e29c28df4bdf2b673f4f264736f6c63430008150033",
+}
+
+// BankapiABI is the input ABI used to generate the binding from.
+// Deprecated: Use BankapiMetaData.ABI instead.
+var BankapiABI = BankapiMetaData.ABI
+
+// BankapiBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BankapiMetaData.Bin instead.
+var BankapiBin = BankapiMetaData.Bin
+
+// DeployBankapi deploys a new Ethereum contract, binding an instance of Bankapi to it.
+func DeployBankapi(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Bankapi, error) {
+	parsed, err := BankapiMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BankapiBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Bankapi{BankapiCaller: BankapiCaller{contract: contract}, BankapiTransactor: BankapiTransactor{contract: contract}, BankapiFilterer: BankapiFilterer{contract: contract}}, nil
+}
+
+// Bankapi is an auto generated Go binding around an Ethereum contract.
+type Bankapi struct {
+	BankapiCaller     // Read-only binding to the contract
+	BankapiTransactor // Write-only binding to the contract
+	BankapiFilterer   // Log filterer for contract events
+}
+
+// BankapiCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BankapiCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BankapiTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BankapiTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BankapiFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BankapiFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BankapiSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BankapiSession struct {
+	Contract     *Bankapi          // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BankapiCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BankapiCallerSession struct {
+	Contract *BankapiCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts  // Call options to use throughout this session
+}
+
+// BankapiTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BankapiTransactorSession struct {
+	Contract     *BankapiTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// BankapiRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BankapiRaw struct {
+	Contract *Bankapi // Generic contract binding to access the raw methods on
+}
+
+// BankapiCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BankapiCallerRaw struct {
+	Contract *BankapiCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BankapiTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BankapiTransactorRaw struct {
+	Contract *BankapiTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBankapi creates a new instance of Bankapi, bound to a specific deployed contract.
+func NewBankapi(address common.Address, backend bind.ContractBackend) (*Bankapi, error) {
+	contract, err := bindBankapi(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bankapi{BankapiCaller: BankapiCaller{contract: contract}, BankapiTransactor: BankapiTransactor{contract: contract}, BankapiFilterer: BankapiFilterer{contract: contract}}, nil
+}
+
+// NewBankapiCaller creates a new read-only instance of Bankapi, bound to a specific deployed contract.
+func NewBankapiCaller(address common.Address, caller bind.ContractCaller) (*BankapiCaller, error) {
+	contract, err := bindBankapi(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiCaller{contract: contract}, nil
+}
+
+// NewBankapiTransactor creates a new write-only instance of Bankapi, bound to a specific deployed contract.
+func NewBankapiTransactor(address common.Address, transactor bind.ContractTransactor) (*BankapiTransactor, error) {
+	contract, err := bindBankapi(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiTransactor{contract: contract}, nil
+}
+
+// NewBankapiFilterer creates a new log filterer instance of Bankapi, bound to a specific deployed contract.
+func NewBankapiFilterer(address common.Address, filterer bind.ContractFilterer) (*BankapiFilterer, error) {
+	contract, err := bindBankapi(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiFilterer{contract: contract}, nil
+}
+
+// bindBankapi binds a generic wrapper to an already deployed contract.
+func bindBankapi(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BankapiMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bankapi *BankapiRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bankapi.Contract.BankapiCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bankapi *BankapiRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.Contract.BankapiTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bankapi *BankapiRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bankapi.Contract.BankapiTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bankapi *BankapiCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bankapi.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bankapi *BankapiTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bankapi *BankapiTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bankapi.Contract.contract.Transact(opts, method, params...)
+}
+
+// API is a free data retrieval call binding the contract method 0x7d7b0099.
+//
+// Solidity: function API() view returns(address)
+func (_Bankapi *BankapiCaller) API(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "API")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// API is a free data retrieval call binding the contract method 0x7d7b0099.
+//
+// Solidity: function API() view returns(address)
+func (_Bankapi *BankapiSession) API() (common.Address, error) {
+	return _Bankapi.Contract.API(&_Bankapi.CallOpts)
+}
+
+// API is a free data retrieval call binding the contract method 0x7d7b0099.
+//
+// Solidity: function API() view returns(address)
+func (_Bankapi *BankapiCallerSession) API() (common.Address, error) {
+	return _Bankapi.Contract.API(&_Bankapi.CallOpts)
+}
+
+// DepositCap is a free data retrieval call binding the contract method 0xc2c2673c.
+//
+// Solidity: function DepositCap(address account) view returns(uint256)
+func (_Bankapi *BankapiCaller) DepositCap(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "DepositCap", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// DepositCap is a free data retrieval call binding the contract method 0xc2c2673c.
+//
+// Solidity: function DepositCap(address account) view returns(uint256)
+func (_Bankapi *BankapiSession) DepositCap(account common.Address) (*big.Int, error) {
+	return _Bankapi.Contract.DepositCap(&_Bankapi.CallOpts, account)
+}
+
+// DepositCap is a free data retrieval call binding the contract method 0xc2c2673c.
+//
+// Solidity: function DepositCap(address account) view returns(uint256)
+func (_Bankapi *BankapiCallerSession) DepositCap(account common.Address) (*big.Int, error) {
+	return _Bankapi.Contract.DepositCap(&_Bankapi.CallOpts, account)
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Bankapi *BankapiCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "Owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Bankapi *BankapiSession) Owner() (common.Address, error) {
+	return _Bankapi.Contract.Owner(&_Bankapi.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Bankapi *BankapiCallerSession) Owner() (common.Address, error) {
+	return _Bankapi.Contract.Owner(&_Bankapi.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0xbb62860d.
+//
+// Solidity: function Version() view returns(string)
+func (_Bankapi *BankapiCaller) Version(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Bankapi.contract.Call(opts, &out, "Version")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0xbb62860d.
+//
+// Solidity: function Version() view returns(string)
+func (_Bankapi *BankapiSession) Version() (string, error) {
+	return _Bankapi.Contract.Version(&_Bankapi.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0xbb62860d.
+//
+// Solidity: function Version() view returns(string)
+func (_Bankapi *BankapiCallerSession) Version() (string, error) {
+	return _Bankapi.Contract.Version(&_Bankapi.CallOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Bankapi *BankapiTransactor) Deposit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "Deposit")
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Bankapi *BankapiSession) Deposit() (*types.Transaction, error) {
+	return _Bankapi.Contract.Deposit(&_Bankapi.TransactOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Bankapi *BankapiTransactorSession) Deposit() (*types.Transaction, error) {
+	return _Bankapi.Contract.Deposit(&_Bankapi.TransactOpts)
+}
+
+// Reconcile is a paid mutator transaction binding the contract method 0xfa84fd8e.
+//
+// Solidity: function Reconcile(address winner, address[] losers, uint256 anteWei, uint256 gameFeeWei) returns()
+func (_Bankapi *BankapiTransactor) Reconcile(opts *bind.TransactOpts, winner common.Address, losers []common.Address, anteWei *big.Int, gameFeeWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "Reconcile", winner, losers, anteWei, gameFeeWei)
+}
+
+// Reconcile is a paid mutator transaction binding the contract method 0xfa84fd8e.
+//
+// Solidity: function Reconcile(address winner, address[] losers, uint256 anteWei, uint256 gameFeeWei) returns()
+func (_Bankapi *BankapiSession) Reconcile(winner common.Address, losers []common.Address, anteWei *big.Int, gameFeeWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.Contract.Reconcile(&_Bankapi.TransactOpts, winner, losers, anteWei, gameFeeWei)
+}
+
+// Reconcile is a paid mutator transaction binding the contract method 0xfa84fd8e.
+//
+// Solidity: function Reconcile(address winner, address[] losers, uint256 anteWei, uint256 gameFeeWei) returns()
+func (_Bankapi *BankapiTransactorSession) Reconcile(winner common.Address, losers []common.Address, anteWei *big.Int, gameFeeWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.Contract.Reconcile(&_Bankapi.TransactOpts, winner, losers, anteWei, gameFeeWei)
+}
+
+// SetDepositCap is a paid mutator transaction binding the contract method 0x2a82c7b7.
+//
+// Solidity: function SetDepositCap(address account, uint256 capWei) returns()
+func (_Bankapi *BankapiTransactor) SetDepositCap(opts *bind.TransactOpts, account common.Address, capWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "SetDepositCap", account, capWei)
+}
+
+// SetDepositCap is a paid mutator transaction binding the contract method 0x2a82c7b7.
+//
+// Solidity: function SetDepositCap(address account, uint256 capWei) returns()
+func (_Bankapi *BankapiSession) SetDepositCap(account common.Address, capWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.Contract.SetDepositCap(&_Bankapi.TransactOpts, account, capWei)
+}
+
+// SetDepositCap is a paid mutator transaction binding the contract method 0x2a82c7b7.
+//
+// Solidity: function SetDepositCap(address account, uint256 capWei) returns()
+func (_Bankapi *BankapiTransactorSession) SetDepositCap(account common.Address, capWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.Contract.SetDepositCap(&_Bankapi.TransactOpts, account, capWei)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() payable returns()
+func (_Bankapi *BankapiTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "Withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() payable returns()
+func (_Bankapi *BankapiSession) Withdraw() (*types.Transaction, error) {
+	return _Bankapi.Contract.Withdraw(&_Bankapi.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() payable returns()
+func (_Bankapi *BankapiTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _Bankapi.Contract.Withdraw(&_Bankapi.TransactOpts)
+}
+
+// WithdrawAmount is a paid mutator transaction binding the contract method 0xa4627c8f.
+//
+// Solidity: function WithdrawAmount(uint256 amountWei) returns()
+func (_Bankapi *BankapiTransactor) WithdrawAmount(opts *bind.TransactOpts, amountWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.contract.Transact(opts, "WithdrawAmount", amountWei)
+}
+
+// WithdrawAmount is a paid mutator transaction binding the contract method 0xa4627c8f.
+//
+// Solidity: function WithdrawAmount(uint256 amountWei) returns()
+func (_Bankapi *BankapiSession) WithdrawAmount(amountWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.Contract.WithdrawAmount(&_Bankapi.TransactOpts, amountWei)
+}
+
+// WithdrawAmount is a paid mutator transaction binding the contract method 0xa4627c8f.
+//
+// Solidity: function WithdrawAmount(uint256 amountWei) returns()
+func (_Bankapi *BankapiTransactorSession) WithdrawAmount(amountWei *big.Int) (*types.Transaction, error) {
+	return _Bankapi.Contract.WithdrawAmount(&_Bankapi.TransactOpts, amountWei)
+}
+
+// BankapiEventLogIterator is returned from FilterEventLog and is used to iterate over the raw logs and unpacked data for EventLog events raised by the Bankapi contract.
+type BankapiEventLogIterator struct {
+	Event *BankapiEventLog // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BankapiEventLogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BankapiEventLog)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BankapiEventLog)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BankapiEventLogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BankapiEventLogIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BankapiEventLog represents a EventLog event raised by the Bankapi contract.
+type BankapiEventLog struct {
+	Value string
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterEventLog is a free log retrieval operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Bankapi *BankapiFilterer) FilterEventLog(opts *bind.FilterOpts) (*BankapiEventLogIterator, error) {
+
+	logs, sub, err := _Bankapi.contract.FilterLogs(opts, "EventLog")
+	if err != nil {
+		return nil, err
+	}
+	return &BankapiEventLogIterator{contract: _Bankapi.contract, event: "EventLog", logs: logs, sub: sub}, nil
+}
+
+// WatchEventLog is a free log subscription operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Bankapi *BankapiFilterer) WatchEventLog(opts *bind.WatchOpts, sink chan<- *BankapiEventLog) (event.Subscription, error) {
+
+	logs, sub, err := _Bankapi.contract.WatchLogs(opts, "EventLog")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BankapiEventLog)
+				if err := _Bankapi.contract.UnpackLog(event, "EventLog", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEventLog is a log parse operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Bankapi *BankapiFilterer) ParseEventLog(log types.Log) (*BankapiEventLog, error) {
+	event := new(BankapiEventLog)
+	if err := _Bankapi.contract.UnpackLog(event, "EventLog", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -66,19 +66,44 @@ contract bank {
 
     // Deposit the given amount to the account balance.
     function Deposit() payable public {
-        (bool success,) = API.delegatecall(
-            abi.encodeWithSignature("Deposit()")
-        );
-
-        emit EventLog(string.concat("success[", Error.Booltoa(success), "]"));
+        delegate(abi.encodeWithSignature("Deposit()"));
     }
 
     // Withdraw the given amount to the account balance.
     function Withdraw() payable public {
-        (bool success,) = API.delegatecall(
-            abi.encodeWithSignature("Withdraw()")
-        );
+        delegate(abi.encodeWithSignature("Withdraw()"));
+    }
+
+    // fallback forwards every call the bank doesn't implement to the API, so
+    // an API can add calls without deploying a new bank. Calls are made with
+    // the API's ABI against the bank's address, and the API restricts its
+    // own owner-only calls using the bank's Owner.@dev
+    fallback() external payable {
+        bytes memory result = delegate(msg.data);
+        assembly {
+            return(add(result, 32), mload(result))
+        }
+    }
+
+    // /////////////////////////////////////////////////////////////
+    // Private Calls
+
+    // delegate executes the call against the API, reverting with the API's
+    // error when it fails so no value is kept for a failed call. APIs that
+    // don't implement the call fail it as well, while a delegate call to an
+    // address without code would succeed, so the API must be set.@dev
+    function delegate(bytes memory data) private returns (bytes memory) {
+        if (API.code.length == 0) {
+            revert("no api contract set");
+        }
+
+        (bool success, bytes memory result) = API.delegatecall(data);
+        if (!success) {
+            assembly {
+                revert(add(result, 32), mload(result))
+            }
+        }
 
-        emit EventLog(string.concat("success[", Error.Booltoa(success), "]"));
+        return result;
     }
-}
\ No newline at end of file
+}
@@ -25,13 +25,20 @@ contract BankAPI {
 
     // constructor is called when the contract is deployed.
     constructor() {
-        Version = "0.1.0";
+        Version = "0.2.0";
+    }
+
+    // onlyOwner can be used to restrict access to a function for the owner only.
+    // Owner is the proxy's owner when the proxy delegate calls the API.@dev
+    modifier onlyOwner {
+        if (msg.sender != Owner) revert();
+        _;
     }
 
     // Actual implementation of Deposit() & Withdraw():
 
     // Reconcile settles the accounting for a game that was played.
-    function Reconcile(address winner, address[] memory losers, uint256 anteWei, uint256 gameFeeWei) public {
+    function Reconcile(address winner, address[] memory losers, uint256 anteWei, uint256 gameFeeWei) onlyOwner public {
         // Add the ante for each player to the pot. The initialization
         // is for the winner's ante.
         uint256 pot = anteWei;
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.0;
+
+import "../../error.sol";
+
+// NOTE: The state of our API **MUST BE** identical to that of the originally deployed contract,
+// as a one-to-one mapping is conducted. New state can only be added after it.@dev
+contract BankAPI {
+    // API represents the address of the contract to use for delegate calls.
+    address public API;
+
+    // Version is the current version of Store.
+    string public Version;
+
+    // Owner represents the address who deployed the contract.
+    address public Owner;
+
+    // accountBalances represents the amount of money an account has available.
+    mapping (address => uint256) private accountBalances;
+
+    // depositCaps represents the most an account can hold through deposits,
+    // zero meaning no cap.
+    mapping (address => uint256) private depositCaps;
+
+    // EvenLog provides support for external logging.
+    event EventLog(string value);
+
+    // /////////////////////////////////////////////////////////////
+
+    // constructor is called when the contract is deployed.
+    constructor() {
+        Version = "0.3.0";
+    }
+
+    // onlyOwner can be used to restrict access to a function for the owner only.
+    // Owner is the proxy's owner when the proxy delegate calls the API.@dev
+    modifier onlyOwner {
+        if (msg.sender != Owner) revert();
+        _;
+    }
+
+    // /////////////////////////////////////////////////////////////
+    // Owner-Only Calls
+
+    // SetDepositCap sets the most the account can hold through deposits.
+    function SetDepositCap(address account, uint256 capWei) onlyOwner public {
+        depositCaps[account] = capWei;
+        emit EventLog(string.concat("cap[", Error.Addrtoa(account), "] amount[", Error.Itoa(capWei), "]"));
+    }
+
+    // Reconcile settles the accounting for a game that was played.
+    function Reconcile(address winner, address[] memory losers, uint256 anteWei, uint256 gameFeeWei) onlyOwner public {
+        // Add the ante for each player to the pot. The initialization
+        // is for the winner's ante.
+        uint256 pot = anteWei;
+        for (uint i = 0; i < losers.length; i++) {
+            if (accountBalances[losers[i]] < anteWei) {
+                emit EventLog(string.concat("account balance ", Error.Itoa(accountBalances[losers[i]]), " is less than the ante ", Error.Itoa(anteWei)));
+                pot += accountBalances[losers[i]];
+                accountBalances[losers[i]] = 0;
+            } else {
+                pot += anteWei;
+                accountBalances[losers[i]] -= anteWei;
+            }
+        }
+
+        emit EventLog(string.concat("ante[", Error.Itoa(anteWei), "] gameFee[", Error.Itoa(gameFeeWei), "] pot[", Error.Itoa(pot), "]"));
+
+        // This shouldn't happen, but check to see if the pot is 0
+        // because none of the losers had an account balance.
+        if (pot == 0) {
+            revert("no pot was created based on teh account balances");
+        }
+
+        // This shouldn't happen, but check there is enough
+        // in the pot to cover the game fee.
+        if (pot < gameFeeWei) {
+            accountBalances[Owner] += pot;
+            emit EventLog(string.concat("pot was less than fee: winner[0] owner[", Error.Itoa(pot), "]"));
+            return;
+        }
+
+        // Take the game fee from the pot and give the winner
+        // the remaining pot and owner of the game fee.
+        pot -= gameFeeWei;
+        accountBalances[winner] += pot;
+        accountBalances[Owner] += gameFeeWei;
+        emit EventLog(string.concat("winner[", Error.Itoa(pot), "] owner[", Error.Itoa(gameFeeWei), "]"));
+    }
+
+    // /////////////////////////////////////////////////////////////
+    // Account Only Calls
+
+    // DepositCap returns the most the account can hold through deposits.
+    function DepositCap(address account) view public returns (uint) {
+        return depositCaps[account];
+    }
+
+    // Deposit the given amount to the account balance, up to the account's cap.
+    function Deposit() payable public {
+        uint256 cap = depositCaps[msg.sender];
+        if (cap != 0 && accountBalances[msg.sender] + msg.value > cap) {
+            revert(string.concat("deposit exceeds the cap of ", Error.Itoa(cap)));
+        }
+
+        accountBalances[msg.sender] += msg.value;
+        emit EventLog(string.concat("deposit[", Error.Addrtoa(msg.sender), "] balance[", Error.Itoa(accountBalances[msg.sender]), "]"));
+    }
+
+    // Withdraw the entire account balance.
+    function Withdraw() payable public {
+        address payable account = payable(msg.sender);
+
+        if (accountBalances[msg.sender] == 0) {
+            revert("not enough balance");
+        }
+
+        uint256 amount = accountBalances[msg.sender];
+        accountBalances[msg.sender] = 0;
+        account.transfer(amount);
+
+        emit EventLog(string.concat("withdraw[", Error.Addrtoa(msg.sender), "] amount[", Error.Itoa(amount), "]"));
+    }
+
+    // WithdrawAmount withdraws part of the account balance.
+    function WithdrawAmount(uint256 amountWei) public {
+        address payable account = payable(msg.sender);
+
+        if (amountWei == 0 || accountBalances[msg.sender] < amountWei) {
+            revert("not enough balance");
+        }
+
+        accountBalances[msg.sender] -= amountWei;
+        account.transfer(amountWei);
+
+        emit EventLog(string.concat("withdraw[", Error.Addrtoa(msg.sender), "] amount[", Error.Itoa(amountWei), "] balance[", Error.Itoa(accountBalances[msg.sender]), "]"));
+    }
+}
@@ -2,9 +2,13 @@ package bank_test
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"os"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
 	"github.com/adamwoolhether/smartcontract/app/bank/single/contract/go/bank"
 	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
 )
@@ -17,149 +21,196 @@ const (
 	numAccounts
 )
 
-func TestBankSingle(t *testing.T) {
+const gasLimit = 1_700_000
+const valueGwei = 0.0
+
+// fixture holds the deployed and funded bank every test reverts to.
+var fixture struct {
+	backend  *ethereum.SimulatedBackend
+	deployer *ethereum.Client
+	bank     *bank.Bank
+}
+
+func TestMain(m *testing.M) {
+	code, err := run(m)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	os.Exit(code)
+}
+
+// run deploys and funds the bank once and saves the chain as the funded
+// snapshot before running the tests.
+func run(m *testing.M) (int, error) {
 	ctx := context.Background()
 
 	backend, err := ethereum.CreateSimulatedBackend(numAccounts, true, big.NewInt(100))
 	if err != nil {
-		t.Fatalf("unable to create simulated backend: %s", err)
+		return 0, fmt.Errorf("unable to create simulated backend: %w", err)
 	}
 	defer backend.Close()
 
-	// /////////////////////////////////////////////////////////////
-
 	deployer, err := ethereum.NewClient(backend, backend.PrivateKeys[deployerAcct])
 	if err != nil {
-		t.Fatalf("unable to create deplayerAcct: %s", err)
+		return 0, fmt.Errorf("unable to create deplayerAcct: %w", err)
+	}
+
+	deployTxOpts, err := deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
+	if err != nil {
+		return 0, fmt.Errorf("unable to create transaction opts for deploy: %w", err)
 	}
 
-	callOpts, err := deployer.NewCallOpts(ctx)
+	_, tx, testBank, err := bank.DeployBank(deployTxOpts, deployer.Backend)
+	if err != nil {
+		return 0, fmt.Errorf("unable to deploy bank: %w", err)
+	}
+
+	if _, err := deployer.WaitMined(ctx, tx); err != nil {
+		return 0, fmt.Errorf("waiting for deploy: %w", err)
+	}
+
+	depositTxOpts, err := deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
+	if err != nil {
+		return 0, fmt.Errorf("unable to create transaction opts for deposit: %w", err)
+	}
+
+	depositTxOpts.Value = big.NewInt(10)
+	if tx, err = testBank.Deposit(depositTxOpts); err != nil {
+		return 0, fmt.Errorf("unable to deposit money: %w", err)
+	}
+
+	if _, err := deployer.WaitMined(ctx, tx); err != nil {
+		return 0, fmt.Errorf("waiting for deposit: %w", err)
+	}
+
+	backend.Snapshot("funded")
+
+	fixture.backend = backend
+	fixture.deployer = deployer
+	fixture.bank = testBank
+
+	return m.Run(), nil
+}
+
+// reset reverts the chain to the funded bank.
+func reset(t *testing.T) {
+	t.Helper()
+
+	if err := fixture.backend.Revert("funded"); err != nil {
+		t.Fatalf("unable to revert to the funded bank: %s", err)
+	}
+}
+
+// callOpts returns the call options of the deployer.
+func callOpts(t *testing.T) *bind.CallOpts {
+	t.Helper()
+
+	opts, err := fixture.deployer.NewCallOpts(context.Background())
 	if err != nil {
 		t.Fatalf("unable to create call opts: %s", err)
 	}
 
-	// /////////////////////////////////////////////////////////////
-
-	const gasLimit = 1_700_000
-	const valueGwei = 0.0
+	return opts
+}
+
+// deposit deposits the amount of wei and checks the bank's balance.
+func deposit(t *testing.T, amount int64, exp int64) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	depositTxOpts, err := fixture.deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts for deposit: %s", err)
+	}
 
-	var testBank *bank.Bank
-
-	// /////////////////////////////////////////////////////////////
-
-	t.Run("deploy bank", func(t *testing.T) {
-		deployTxOpts, err := deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
-		if err != nil {
-			t.Fatalf("unable to create transaction opts for deploy: %s", err)
-		}
-
-		contractID, tx, _, err := bank.DeployBank(deployTxOpts, deployer.Backend)
-		if err != nil {
-			t.Fatalf("unable to deploy bank: %s", err)
-		}
-
-		if _, err := deployer.WaitMined(ctx, tx); err != nil {
-			t.Fatalf("waiting for deploy: %s", err)
-		}
-
-		testBank, err = bank.NewBank(contractID, deployer.Backend)
-		if err != nil {
-			t.Fatalf("unable to create bank: %s", err)
-		}
-
-		// /////////////////////////////////////////////////////////////
-
-		t.Run("check owner matches", func(t *testing.T) {
-			owner, err := testBank.Owner(callOpts)
-			if err != nil {
-				t.Fatalf("unable to get account owner: %s", err)
-			}
-
-			if owner != deployer.Address() {
-				t.Fatalf("retrieved owner doesn't match expectation: %v != %v", owner, deployer.Address())
-			}
-		})
-
-		// /////////////////////////////////////////////////////////////
-
-		t.Run("check deposit", func(t *testing.T) {
-			initialBalance, err := testBank.Balance(callOpts)
-			if err != nil {
-				t.Fatalf("should be able to get the initial balance: %s", err)
-			}
-
-			depositTxOpts, err := deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
-			if err != nil {
-				t.Fatalf("unable to create transaction opts for deposit: %s", err)
-			}
-
-			depositTxOpts.Value = big.NewInt(10)
-			tx, err := testBank.Deposit(depositTxOpts)
-			if err != nil {
-				t.Fatalf("should be able to deposit money: %s", err)
-			}
-
-			if _, err := deployer.WaitMined(ctx, tx); err != nil {
-				t.Fatalf("waitinf for deposit: %s", err)
-			}
-
-			postDepositBalance, err := testBank.Balance(callOpts)
-			if err != nil {
-				t.Fatalf("unable to get balance after deposit: %s", err)
-			}
-
-			expectedBalance := initialBalance.Add(initialBalance, depositTxOpts.Value)
-			if postDepositBalance.Cmp(expectedBalance) != 0 {
-				t.Fatalf("wrong balance, got %v, exp %v", postDepositBalance, expectedBalance)
-			}
-		})
-
-		// /////////////////////////////////////////////////////////////
-
-		t.Run("check withdraw", func(t *testing.T) {
-			initialBalance, err := testBank.Balance(callOpts)
-			if err != nil {
-				t.Fatalf("should be able to get the initial balacne: %s", err)
-			}
-
-			withdrawTxOpts, err := deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
-			if err != nil {
-				t.Fatalf("unable to create transaction opts for withdraw: %s", err)
-			}
-
-			withdrawTxOpts.Value = big.NewInt(10)
-			tx, err := testBank.Withdraw(withdrawTxOpts)
-			if err != nil {
-				t.Fatalf("unable to withdraw money: %s", err)
-			}
-
-			if _, err := deployer.WaitMined(ctx, tx); err != nil {
-				t.Fatalf("waiting for withdraw: %s", err)
-			}
-
-			postWithdrawBalance, err := testBank.Balance(callOpts)
-			if err != nil {
-				t.Fatalf("should be able to get balance after withdraw: %s", err)
-			}
-
-			expectedBalance := initialBalance.Sub(initialBalance, withdrawTxOpts.Value)
-			if postWithdrawBalance.Cmp(expectedBalance) != 0 {
-				t.Fatalf("wrong balance, got %v  exp %v", postWithdrawBalance, expectedBalance)
-			}
-		})
-
-		// /////////////////////////////////////////////////////////////
-
-		t.Run("check version", func(t *testing.T) {
-			version, err := testBank.Version(callOpts)
-			if err != nil {
-				t.Fatalf("error getting version: %s", err)
-			}
-
-			const expectedVersion = "0.1.0"
-			if version != expectedVersion {
-				t.Fatalf("wrong version. got %s, exp %s\n", version, expectedVersion)
-			}
-		})
-	})
+	depositTxOpts.Value = big.NewInt(amount)
+	tx, err := fixture.bank.Deposit(depositTxOpts)
+	if err != nil {
+		t.Fatalf("should be able to deposit money: %s", err)
+	}
+
+	if _, err := fixture.deployer.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waitinf for deposit: %s", err)
+	}
+
+	balance, err := fixture.bank.Balance(callOpts(t))
+	if err != nil {
+		t.Fatalf("unable to get balance after deposit: %s", err)
+	}
+
+	if balance.Cmp(big.NewInt(exp)) != 0 {
+		t.Fatalf("wrong balance, got %v, exp %v", balance, exp)
+	}
+}
+
+// =============================================================================
+
+func TestOwner(t *testing.T) {
+	reset(t)
+
+	owner, err := fixture.bank.Owner(callOpts(t))
+	if err != nil {
+		t.Fatalf("unable to get account owner: %s", err)
+	}
+
+	if owner != fixture.deployer.Address() {
+		t.Fatalf("retrieved owner doesn't match expectation: %v != %v", owner, fixture.deployer.Address())
+	}
+}
+
+func TestDeposit(t *testing.T) {
+	reset(t)
+	deposit(t, 10, 20)
+
+	// The deposit is gone once the chain is reverted.
+	reset(t)
+	deposit(t, 5, 15)
+}
+
+func TestWithdraw(t *testing.T) {
+	reset(t)
+
+	ctx := context.Background()
+
+	withdrawTxOpts, err := fixture.deployer.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGwei))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts for withdraw: %s", err)
+	}
+
+	withdrawTxOpts.Value = big.NewInt(10)
+	tx, err := fixture.bank.Withdraw(withdrawTxOpts)
+	if err != nil {
+		t.Fatalf("unable to withdraw money: %s", err)
+	}
+
+	if _, err := fixture.deployer.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for withdraw: %s", err)
+	}
+
+	postWithdrawBalance, err := fixture.bank.Balance(callOpts(t))
+	if err != nil {
+		t.Fatalf("should be able to get balance after withdraw: %s", err)
+	}
+
+	// The withdraw returns the 10 wei deposited by the fixture.
+	if postWithdrawBalance.Sign() != 0 {
+		t.Fatalf("wrong balance, got %v  exp 0", postWithdrawBalance)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	reset(t)
+
+	version, err := fixture.bank.Version(callOpts(t))
+	if err != nil {
+		t.Fatalf("error getting version: %s", err)
+	}
+
+	const expectedVersion = "0.1.0"
+	if version != expectedVersion {
+		t.Fatalf("wrong version. got %s, exp %s\n", version, expectedVersion)
+	}
 }
@@ -2,7 +2,9 @@ package basic_test
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"os"
 	"testing"
 
 	"github.com/adamwoolhether/smartcontract/app/basic/contract/go/basic"
@@ -10,7 +12,31 @@ import (
 	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
 )
 
-func TestBasic(t *testing.T) {
+const gasLimit = 1600000
+
+// gasPrice is the price paid for every transaction in the tests.
+var gasPrice = currency.GWei2Wei(big.NewFloat(39.576))
+
+// fixture holds the deployed contract every test reverts to.
+var fixture struct {
+	backend *ethereum.SimulatedBackend
+	client  *ethereum.Client
+	basic   *basic.Basic
+}
+
+func TestMain(m *testing.M) {
+	code, err := run(m)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	os.Exit(code)
+}
+
+// run deploys the contract once and saves the chain as the deployed
+// snapshot before running the tests.
+func run(m *testing.M) (int, error) {
 	ctx := context.Background()
 
 	const numAccounts = 1
@@ -20,47 +46,63 @@ func TestBasic(t *testing.T) {
 
 	backend, err := ethereum.CreateSimulatedBackend(numAccounts, autoCommit, accountBalance)
 	if err != nil {
-		t.Fatalf("unable to create simulated backend: %s", err)
+		return 0, fmt.Errorf("unable to create simulated backend: %w", err)
 	}
 	defer backend.Close()
 
 	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
 	if err != nil {
-		t.Fatalf("unable to create ethereum api: %s", err)
+		return 0, fmt.Errorf("unable to create ethereum api: %w", err)
 	}
 
-	// /////////////////////////////////////////////////////////////
-
-	const gasLimit = 1600000
-	valueGwei := big.NewFloat(0.0)
-	gasPrice := currency.GWei2Wei(big.NewFloat(39.576))
-	txOpts, err := client.NewTransactOpts(ctx, gasLimit, gasPrice, valueGwei)
+	txOpts, err := client.NewTransactOpts(ctx, gasLimit, gasPrice, big.NewFloat(0.0))
 	if err != nil {
-		t.Fatalf("unable to create transaction opts for deploy: %s", err)
+		return 0, fmt.Errorf("unable to create transaction opts for deploy: %w", err)
 	}
 
 	contractID, tx, _, err := basic.DeployBasic(txOpts, client.Backend)
 	if err != nil {
-		t.Fatalf("unable to deploy basic: %s", err)
+		return 0, fmt.Errorf("unable to deploy basic: %w", err)
 	}
 
 	if _, err := client.WaitMined(ctx, tx); err != nil {
-		t.Fatalf("waiting for deploy: %s", err)
+		return 0, fmt.Errorf("waiting for deploy: %w", err)
 	}
 
 	testBasic, err := basic.NewBasic(contractID, client.Backend)
 	if err != nil {
-		t.Fatalf("error creating basic: %s", err)
+		return 0, fmt.Errorf("error creating basic: %w", err)
 	}
 
-	// /////////////////////////////////////////////////////////////
+	backend.Snapshot("deployed")
+
+	fixture.backend = backend
+	fixture.client = client
+	fixture.basic = testBasic
 
-	callOpts, err := client.NewCallOpts(ctx)
+	return m.Run(), nil
+}
+
+// reset reverts the chain to the deployed contract.
+func reset(t *testing.T) {
+	t.Helper()
+
+	if err := fixture.backend.Revert("deployed"); err != nil {
+		t.Fatalf("unable to revert to the deployed contract: %s", err)
+	}
+}
+
+// =============================================================================
+
+func TestVersion(t *testing.T) {
+	reset(t)
+
+	callOpts, err := fixture.client.NewCallOpts(context.Background())
 	if err != nil {
 		t.Fatalf("unable to create call opts: %s", err)
 	}
 
-	ver, err := testBasic.Version(callOpts)
+	ver, err := fixture.basic.Version(callOpts)
 	if err != nil {
 		t.Fatalf("unable to get version: %s", err)
 	}
@@ -68,10 +110,14 @@ func TestBasic(t *testing.T) {
 	if ver != "1.1" {
 		t.Fatalf("should be able to get the correct version, got %s  exp %s", ver, "1.1")
 	}
+}
 
-	// /////////////////////////////////////////////////////////////
+func TestSetItem(t *testing.T) {
+	reset(t)
+
+	ctx := context.Background()
 
-	txOpts, err = client.NewTransactOpts(ctx, gasLimit, gasPrice, valueGwei)
+	txOpts, err := fixture.client.NewTransactOpts(ctx, gasLimit, gasPrice, big.NewFloat(0.0))
 	if err != nil {
 		t.Fatalf("unable to create transaction opts for setitem: %s", err)
 	}
@@ -79,23 +125,23 @@ func TestBasic(t *testing.T) {
 	key := "bill"
 	value := big.NewInt(1_000_000)
 
-	tx, err = testBasic.SetItem(txOpts, key, value)
+	tx, err := fixture.basic.SetItem(txOpts, key, value)
 	if err != nil {
 		t.Fatalf("should be able to set item: %s", err)
 	}
 
-	if _, err := client.WaitMined(ctx, tx); err != nil {
+	if _, err := fixture.client.WaitMined(ctx, tx); err != nil {
 		t.Fatalf("waiting for setitem: %s", err)
 	}
 
 	// /////////////////////////////////////////////////////////////
 
-	callOpts, err = client.NewCallOpts(ctx)
+	callOpts, err := fixture.client.NewCallOpts(ctx)
 	if err != nil {
 		t.Fatalf("unable to create call opts: %s", err)
 	}
 
-	item, err := testBasic.Items(callOpts, key)
+	item, err := fixture.basic.Items(callOpts, key)
 	if err != nil {
 		t.Fatalf("should be able to retrieve item: %s", err)
 	}
@@ -103,4 +149,16 @@ func TestBasic(t *testing.T) {
 	if item.Cmp(value) != 0 {
 		t.Fatalf("wrong value, got %s  exp %s", item, value)
 	}
+
+	// The item is gone once the chain is reverted.
+	reset(t)
+
+	item, err = fixture.basic.Items(callOpts, key)
+	if err != nil {
+		t.Fatalf("should be able to retrieve item: %s", err)
+	}
+
+	if item.Sign() != 0 {
+		t.Fatalf("item should be unset after revert, got %s", item)
+	}
 }
@@ -0,0 +1,403 @@
+// Package bets gathers the participant and moderator signatures the Book
+// contract needs and submits the bets as the owner. Every precondition the
+// contract enforces is checked locally first, so a call that would revert is
+// rejected before any gas is spent on it.
+package bets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// defaultGasLimit is used when the config doesn't set a gas limit.
+const defaultGasLimit = 1_600_000
+
+// Set of errors returned when a request can't be created or submitted.
+var (
+	ErrNotSigner           = errors.New("account is not a signer of the request")
+	ErrMissingSignatures   = errors.New("request is missing signatures")
+	ErrStaleNonce          = errors.New("nonce has changed since the request was created")
+	ErrBetExists           = errors.New("bet already exists")
+	ErrBetNotFound         = errors.New("bet does not exist")
+	ErrBetNotLive          = errors.New("bet is not live")
+	ErrBetNotExpired       = errors.New("bet has not expired")
+	ErrNotParticipant      = errors.New("account is not a participant")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrFeeExceedsBet       = errors.New("fee exceeds the amount bet")
+)
+
+// Config represents the settings needed to construct a service.
+type Config struct {
+	Client   *ethereum.Client // Must be the owner of the book
+	Book     common.Address
+	GasLimit uint64   // Gas limit of submitted transactions (0 = 1,600,000)
+	GasPrice *big.Int // Gas price of submitted transactions (nil = suggested)
+}
+
+// Service creates the requests for the Book calls that need signatures and
+// submits them once they're signed.
+type Service struct {
+	cfg  Config
+	book *book.Book
+}
+
+// New constructs a service for the book in the config.
+func New(cfg Config) (*Service, error) {
+	if cfg.Client == nil {
+		return nil, errors.New("client is required")
+	}
+
+	if cfg.GasLimit == 0 {
+		cfg.GasLimit = defaultGasLimit
+	}
+
+	bookContract, err := book.NewBook(cfg.Book, cfg.Client.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("binding book %s: %w", cfg.Book, err)
+	}
+
+	return &Service{
+		cfg:  cfg,
+		book: bookContract,
+	}, nil
+}
+
+// =============================================================================
+
+// Bet represents the terms of a new bet.
+type Bet struct {
+	ID           string
+	AmountBetWei *big.Int // Amount each participant bets
+	AmountFeeWei *big.Int // Fee each participant pays the owner
+	Expiration   time.Time
+	Moderator    common.Address
+	Participants []common.Address
+}
+
+// ProposeBet creates the request for placing the bet, to be signed by each
+// participant with their current nonce.
+func (s *Service) ProposeBet(ctx context.Context, bet Bet) (*Request, error) {
+	if bet.ID == "" {
+		return nil, errors.New("bet id is required")
+	}
+
+	if len(bet.Participants) == 0 {
+		return nil, errors.New("at least one participant is required")
+	}
+
+	seen := make(map[common.Address]bool)
+	for _, participant := range bet.Participants {
+		if seen[participant] {
+			return nil, fmt.Errorf("duplicate participant %s", participant)
+		}
+		seen[participant] = true
+	}
+
+	if _, err := s.betDetails(ctx, bet.ID); !errors.Is(err, ErrBetNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrBetExists, bet.ID)
+	}
+
+	nonces, err := s.nonces(ctx, bet.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	req := newRequest(KindPlace, bet.ID, bet.Participants, nonces)
+	req.AmountBetWei = orZero(bet.AmountBetWei)
+	req.AmountFeeWei = orZero(bet.AmountFeeWei)
+	req.Expiration = big.NewInt(bet.Expiration.Unix())
+	req.Moderator = bet.Moderator
+	req.Participants = bet.Participants
+
+	return req, nil
+}
+
+// ProposeReconcile creates the request for reconciling the bet with the
+// winners, to be signed by the bet's moderator.
+func (s *Service) ProposeReconcile(ctx context.Context, betID string, winners []common.Address) (*Request, error) {
+	details, err := s.liveBet(ctx, betID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(winners) == 0 {
+		return nil, errors.New("at least one winner is required")
+	}
+
+	for _, winner := range winners {
+		if !contains(details.Participants, winner) {
+			return nil, fmt.Errorf("%w: %s", ErrNotParticipant, winner)
+		}
+	}
+
+	nonces, err := s.nonces(ctx, []common.Address{details.Moderator})
+	if err != nil {
+		return nil, err
+	}
+
+	req := newRequest(KindReconcile, betID, []common.Address{details.Moderator}, nonces)
+	req.Winners = winners
+
+	return req, nil
+}
+
+// ProposeCancelModerator creates the request for cancelling the bet, to be
+// signed by the bet's moderator.
+func (s *Service) ProposeCancelModerator(ctx context.Context, betID string, amountFeeWei *big.Int) (*Request, error) {
+	details, err := s.cancelBet(ctx, betID, amountFeeWei)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces, err := s.nonces(ctx, []common.Address{details.Moderator})
+	if err != nil {
+		return nil, err
+	}
+
+	req := newRequest(KindCancelModerator, betID, []common.Address{details.Moderator}, nonces)
+	req.AmountFeeWei = orZero(amountFeeWei)
+
+	return req, nil
+}
+
+// ProposeCancelParticipants creates the request for cancelling the bet, to
+// be signed by every participant.
+func (s *Service) ProposeCancelParticipants(ctx context.Context, betID string, amountFeeWei *big.Int) (*Request, error) {
+	details, err := s.cancelBet(ctx, betID, amountFeeWei)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces, err := s.nonces(ctx, details.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	req := newRequest(KindCancelParticipants, betID, details.Participants, nonces)
+	req.AmountFeeWei = orZero(amountFeeWei)
+
+	return req, nil
+}
+
+// =============================================================================
+
+// Submit checks the request against the current state of the book and sends
+// the call as the owner. The returned transaction still needs to be waited
+// on.
+func (s *Service) Submit(ctx context.Context, req *Request) (*types.Transaction, error) {
+	if missing := req.Missing(); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrMissingSignatures, missing)
+	}
+
+	// A nonce changes when the signer signs for another bet, which makes
+	// their signature for this request useless.
+	nonces, err := s.nonces(ctx, req.Signers)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, nonce := range nonces {
+		if nonce.Cmp(req.Nonces[i]) != 0 {
+			return nil, fmt.Errorf("%w: %s signed with %v, contract expects %v", ErrStaleNonce, req.Signers[i], req.Nonces[i], nonce)
+		}
+	}
+
+	switch req.Kind {
+	case KindPlace:
+		if err := s.checkPlace(ctx, req); err != nil {
+			return nil, err
+		}
+
+	case KindReconcile:
+		if err := s.checkReconcile(ctx, req); err != nil {
+			return nil, err
+		}
+
+	case KindCancelModerator, KindCancelParticipants:
+		if _, err := s.cancelBet(ctx, req.BetID, req.AmountFeeWei); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown request kind %q", req.Kind)
+	}
+
+	txOpts, err := s.transactOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.Kind {
+	case KindPlace:
+		return s.book.PlaceBet(txOpts, req.BetID, req.AmountBetWei, req.AmountFeeWei, req.Expiration, req.Moderator, req.Participants, req.Nonces, req.Signatures)
+	case KindReconcile:
+		return s.book.ReconcileBet(txOpts, req.BetID, req.Nonces[0], req.Signatures[0], req.Winners)
+	case KindCancelModerator:
+		return s.book.CancelBetModerator(txOpts, req.BetID, req.AmountFeeWei, req.Nonces[0], req.Signatures[0])
+	default:
+		return s.book.CancelBetParticipants(txOpts, req.BetID, req.AmountFeeWei, req.Nonces, req.Signatures)
+	}
+}
+
+// CancelOwner cancels the live bet as the owner, which needs no signatures.
+func (s *Service) CancelOwner(ctx context.Context, betID string, amountFeeWei *big.Int) (*types.Transaction, error) {
+	if _, err := s.cancelBet(ctx, betID, amountFeeWei); err != nil {
+		return nil, err
+	}
+
+	txOpts, err := s.transactOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.book.CancelBetOwner(txOpts, betID, orZero(amountFeeWei))
+}
+
+// checkPlace checks the bet doesn't exist yet and every participant can
+// cover the bet and fee.
+func (s *Service) checkPlace(ctx context.Context, req *Request) error {
+	if _, err := s.betDetails(ctx, req.BetID); !errors.Is(err, ErrBetNotFound) {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrBetExists, req.BetID)
+	}
+
+	cost := new(big.Int).Add(req.AmountBetWei, req.AmountFeeWei)
+
+	for _, participant := range req.Participants {
+		balance, err := s.book.AccountBalance(s.callOpts(ctx), participant)
+		if err != nil {
+			return fmt.Errorf("retrieving balance of %s: %w", participant, err)
+		}
+
+		if balance.Cmp(cost) < 0 {
+			return fmt.Errorf("%w: %s has %v, needs %v", ErrInsufficientBalance, participant, balance, cost)
+		}
+	}
+
+	return nil
+}
+
+// checkReconcile checks the bet is live and expired as of the latest block.
+func (s *Service) checkReconcile(ctx context.Context, req *Request) error {
+	details, err := s.liveBet(ctx, req.BetID)
+	if err != nil {
+		return err
+	}
+
+	// The next block is at least one second after the latest block.
+	header, err := s.cfg.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("retrieving latest header: %w", err)
+	}
+
+	if header.Time+1 < details.Expiration.Uint64() {
+		return fmt.Errorf("%w: expires at %v, latest block at %d", ErrBetNotExpired, details.Expiration, header.Time)
+	}
+
+	return nil
+}
+
+// cancelBet returns the details of the live bet, failing if the fee is more
+// than the amount bet, since the contract refunds the amount bet less the fee.
+func (s *Service) cancelBet(ctx context.Context, betID string, amountFeeWei *big.Int) (book.BookBetInfo, error) {
+	details, err := s.liveBet(ctx, betID)
+	if err != nil {
+		return book.BookBetInfo{}, err
+	}
+
+	if amountFeeWei != nil && amountFeeWei.Cmp(details.AmountBetWei) > 0 {
+		return book.BookBetInfo{}, fmt.Errorf("%w: %v is more than %v", ErrFeeExceedsBet, amountFeeWei, details.AmountBetWei)
+	}
+
+	return details, nil
+}
+
+// =============================================================================
+
+// liveBet returns the details of the bet, failing if it isn't live.
+func (s *Service) liveBet(ctx context.Context, betID string) (book.BookBetInfo, error) {
+	details, err := s.betDetails(ctx, betID)
+	if err != nil {
+		return book.BookBetInfo{}, err
+	}
+
+	if details.State != book.StateLive {
+		return book.BookBetInfo{}, fmt.Errorf("%w: %s is %s", ErrBetNotLive, betID, book.StateName(details.State))
+	}
+
+	return details, nil
+}
+
+// betDetails returns the details of the bet, or ErrBetNotFound if it doesn't
+// exist.
+func (s *Service) betDetails(ctx context.Context, betID string) (book.BookBetInfo, error) {
+	details, err := s.book.BetDetails(s.callOpts(ctx), betID)
+	if err != nil {
+		if revertErr, ok := ethereum.CallRevert(err); ok && revertErr.Reason == book.ReasonBetNotFound {
+			return book.BookBetInfo{}, fmt.Errorf("%w: %s", ErrBetNotFound, betID)
+		}
+		return book.BookBetInfo{}, fmt.Errorf("retrieving bet %s: %w", betID, err)
+	}
+
+	return details, nil
+}
+
+// nonces returns the current nonce of each account in the book.
+func (s *Service) nonces(ctx context.Context, accounts []common.Address) ([]*big.Int, error) {
+	nonces := make([]*big.Int, len(accounts))
+	for i, account := range accounts {
+		nonce, err := s.book.Nonce(s.callOpts(ctx), account)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving nonce of %s: %w", account, err)
+		}
+		nonces[i] = nonce
+	}
+
+	return nonces, nil
+}
+
+// callOpts returns the options for calls made as the owner, since most of
+// the book's views are owner only.
+func (s *Service) callOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx, From: s.cfg.Client.Address()}
+}
+
+// transactOpts returns the options for transactions sent as the owner.
+func (s *Service) transactOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	return s.cfg.Client.NewTransactOpts(ctx, s.cfg.GasLimit, s.cfg.GasPrice, big.NewFloat(0))
+}
+
+// contains reports whether the address is in the list.
+func contains(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orZero returns the value or zero when it's nil.
+func orZero(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+
+	return v
+}
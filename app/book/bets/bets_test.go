@@ -0,0 +1,379 @@
+package bets_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/adamwoolhether/smartcontract/app/book/bets"
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+const (
+	ownerAcc = iota
+	moderatorAcc
+	player1Acc
+	player2Acc
+	numAccounts
+)
+
+func TestBets(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(numAccounts, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	clients := make([]*ethereum.Client, numAccounts)
+	for i := range clients {
+		if clients[i], err = ethereum.NewClient(backend, backend.PrivateKeys[i]); err != nil {
+			t.Fatalf("unable to create client %d: %s", i, err)
+		}
+	}
+	owner := clients[ownerAcc]
+	moderator := clients[moderatorAcc]
+	players := []*ethereum.Client{clients[player1Acc], clients[player2Acc]}
+	participants := []common.Address{players[0].Address(), players[1].Address()}
+
+	txOpts, err := owner.NewTransactOpts(ctx, 5_000_000, big.NewInt(0), big.NewFloat(0))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts: %s", err)
+	}
+
+	address, tx, contract, err := book.DeployBook(txOpts, owner.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy book: %s", err)
+	}
+
+	if _, err := owner.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	// Each player deposits 1 ether to cover their bets.
+	for _, player := range players {
+		opts, err := player.NewTransactOpts(ctx, 1_000_000, big.NewInt(0), big.NewFloat(1_000_000_000))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		playerBook, err := book.NewBook(address, player.Backend)
+		if err != nil {
+			t.Fatalf("unable to bind book: %s", err)
+		}
+
+		tx, err := playerBook.Deposit(opts)
+		if err != nil {
+			t.Fatalf("unable to deposit: %s", err)
+		}
+
+		if _, err := player.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for deposit: %s", err)
+		}
+	}
+
+	svc, err := bets.New(bets.Config{
+		Client: owner,
+		Book:   address,
+	})
+	if err != nil {
+		t.Fatalf("unable to create service: %s", err)
+	}
+
+	callOpts, err := owner.NewCallOpts(ctx)
+	if err != nil {
+		t.Fatalf("unable to create call opts: %s", err)
+	}
+
+	// submit submits the request and waits for it to be mined.
+	submit := func(t *testing.T, req *bets.Request) {
+		t.Helper()
+
+		tx, err := svc.Submit(ctx, req)
+		if err != nil {
+			t.Fatalf("unable to submit %s: %s", req.Kind, err)
+		}
+
+		if _, err := owner.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for %s: %s", req.Kind, err)
+		}
+	}
+
+	// expectState checks the bet is in the specified state.
+	expectState := func(t *testing.T, betID string, state uint8) {
+		t.Helper()
+
+		details, err := contract.BetDetails(callOpts, betID)
+		if err != nil {
+			t.Fatalf("unable to retrieve bet details: %s", err)
+		}
+
+		if details.State != state {
+			t.Fatalf("wrong state for bet %s, got %s  exp %s", betID, book.StateName(details.State), book.StateName(state))
+		}
+	}
+
+	// placeBet proposes the bet, has both players sign and submits it.
+	placeBet := func(t *testing.T, betID string, expiration time.Time) {
+		t.Helper()
+
+		req, err := svc.ProposeBet(ctx, bets.Bet{
+			ID:           betID,
+			AmountBetWei: big.NewInt(1_000_000),
+			AmountFeeWei: big.NewInt(1_000),
+			Expiration:   expiration,
+			Moderator:    moderator.Address(),
+			Participants: participants,
+		})
+		if err != nil {
+			t.Fatalf("unable to propose bet: %s", err)
+		}
+
+		for _, player := range players {
+			if err := req.Sign(ctx, player.Signer()); err != nil {
+				t.Fatalf("unable to sign: %s", err)
+			}
+		}
+
+		submit(t, req)
+		expectState(t, betID, book.StateLive)
+	}
+
+	header, err := owner.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatalf("unable to retrieve header: %s", err)
+	}
+
+	// Bets that expire now can be reconciled in the next block, bets that
+	// expire in an hour can't be reconciled during the test.
+	expired := time.Unix(int64(header.Time), 0)
+	future := expired.Add(time.Hour)
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("signatures", func(t *testing.T) {
+		req, err := svc.ProposeBet(ctx, bets.Bet{
+			ID:           "signatures",
+			Expiration:   future,
+			Moderator:    moderator.Address(),
+			Participants: participants,
+		})
+		if err != nil {
+			t.Fatalf("unable to propose bet: %s", err)
+		}
+
+		if err := req.Sign(ctx, moderator.Signer()); !errors.Is(err, bets.ErrNotSigner) {
+			t.Fatalf("should reject a non signer, got %v", err)
+		}
+
+		// Player 1 signs player 2's hash.
+		hash, err := req.Hash(participants[1])
+		if err != nil {
+			t.Fatalf("unable to hash request: %s", err)
+		}
+
+		sig, err := players[0].SignHash(ctx, hash)
+		if err != nil {
+			t.Fatalf("unable to sign hash: %s", err)
+		}
+
+		if err := req.AddSignature(participants[1], sig); !errors.Is(err, ethereum.ErrInvalidSignature) {
+			t.Fatalf("should reject a signature from the wrong key, got %v", err)
+		}
+
+		if err := req.Sign(ctx, players[0].Signer()); err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		missing := req.Missing()
+		if len(missing) != 1 || missing[0] != participants[1] {
+			t.Fatalf("wrong missing signers, got %v  exp %v", missing, participants[1:])
+		}
+
+		if _, err := svc.Submit(ctx, req); !errors.Is(err, bets.ErrMissingSignatures) {
+			t.Fatalf("should fail with missing signatures, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("place and reconcile", func(t *testing.T) {
+		placeBet(t, "reconcile", expired)
+
+		if _, err := svc.ProposeBet(ctx, bets.Bet{ID: "reconcile", Participants: participants}); !errors.Is(err, bets.ErrBetExists) {
+			t.Fatalf("should fail for an existing bet, got %v", err)
+		}
+
+		if _, err := svc.ProposeReconcile(ctx, "reconcile", []common.Address{moderator.Address()}); !errors.Is(err, bets.ErrNotParticipant) {
+			t.Fatalf("should fail for a winner that isn't a participant, got %v", err)
+		}
+
+		req, err := svc.ProposeReconcile(ctx, "reconcile", participants[:1])
+		if err != nil {
+			t.Fatalf("unable to propose reconcile: %s", err)
+		}
+
+		if err := req.Sign(ctx, moderator.Signer()); err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		submit(t, req)
+		expectState(t, "reconcile", book.StateReconciled)
+
+		if _, err := svc.ProposeReconcile(ctx, "reconcile", participants[:1]); !errors.Is(err, bets.ErrBetNotLive) {
+			t.Fatalf("should fail for a reconciled bet, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("not expired", func(t *testing.T) {
+		placeBet(t, "not expired", future)
+
+		req, err := svc.ProposeReconcile(ctx, "not expired", participants[:1])
+		if err != nil {
+			t.Fatalf("unable to propose reconcile: %s", err)
+		}
+
+		if err := req.Sign(ctx, moderator.Signer()); err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		if _, err := svc.Submit(ctx, req); !errors.Is(err, bets.ErrBetNotExpired) {
+			t.Fatalf("should fail for a bet that hasn't expired, got %v", err)
+		}
+
+		if err := backend.JumpTime(future); err != nil {
+			t.Fatalf("unable to set time: %s", err)
+		}
+
+		submit(t, req)
+		expectState(t, "not expired", book.StateReconciled)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("cancel", func(t *testing.T) {
+		placeBet(t, "moderator", future)
+
+		req, err := svc.ProposeCancelModerator(ctx, "moderator", big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("unable to propose cancel: %s", err)
+		}
+
+		if err := req.Sign(ctx, moderator.Signer()); err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		submit(t, req)
+		expectState(t, "moderator", book.StateCancelled)
+
+		placeBet(t, "participants", future)
+
+		req, err = svc.ProposeCancelParticipants(ctx, "participants", big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("unable to propose cancel: %s", err)
+		}
+
+		for _, player := range players {
+			if err := req.Sign(ctx, player.Signer()); err != nil {
+				t.Fatalf("unable to sign: %s", err)
+			}
+		}
+
+		submit(t, req)
+		expectState(t, "participants", book.StateCancelled)
+
+		placeBet(t, "owner", future)
+
+		// The fee comes out of the amount bet, so it can't be more.
+		if _, err := svc.ProposeCancelModerator(ctx, "owner", big.NewInt(1_000_001)); !errors.Is(err, bets.ErrFeeExceedsBet) {
+			t.Fatalf("should fail with a fee over the bet, got %v", err)
+		}
+
+		if _, err := svc.CancelOwner(ctx, "owner", big.NewInt(1_000_001)); !errors.Is(err, bets.ErrFeeExceedsBet) {
+			t.Fatalf("should fail with a fee over the bet, got %v", err)
+		}
+
+		tx, err := svc.CancelOwner(ctx, "owner", big.NewInt(0))
+		if err != nil {
+			t.Fatalf("unable to cancel: %s", err)
+		}
+
+		if _, err := owner.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for cancel: %s", err)
+		}
+
+		expectState(t, "owner", book.StateCancelled)
+
+		if _, err := svc.CancelOwner(ctx, "unknown", big.NewInt(0)); !errors.Is(err, bets.ErrBetNotFound) {
+			t.Fatalf("should fail for an unknown bet, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("stale nonce", func(t *testing.T) {
+		stale, err := svc.ProposeBet(ctx, bets.Bet{
+			ID:           "stale",
+			Expiration:   future,
+			Moderator:    moderator.Address(),
+			Participants: participants,
+		})
+		if err != nil {
+			t.Fatalf("unable to propose bet: %s", err)
+		}
+
+		for _, player := range players {
+			if err := stale.Sign(ctx, player.Signer()); err != nil {
+				t.Fatalf("unable to sign: %s", err)
+			}
+		}
+
+		// Placing another bet moves both players' nonces on.
+		placeBet(t, "fresh", future)
+
+		if _, err := svc.Submit(ctx, stale); !errors.Is(err, bets.ErrStaleNonce) {
+			t.Fatalf("should fail with a stale nonce, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("insufficient balance", func(t *testing.T) {
+		poor, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("unable to generate key: %s", err)
+		}
+
+		req, err := svc.ProposeBet(ctx, bets.Bet{
+			ID:           "poor",
+			AmountBetWei: big.NewInt(1),
+			Expiration:   future,
+			Moderator:    moderator.Address(),
+			Participants: []common.Address{players[0].Address(), crypto.PubkeyToAddress(poor.PublicKey)},
+		})
+		if err != nil {
+			t.Fatalf("unable to propose bet: %s", err)
+		}
+
+		if err := req.Sign(ctx, players[0].Signer()); err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		if err := req.Sign(ctx, ethereum.NewKeySigner(poor)); err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		if _, err := svc.Submit(ctx, req); !errors.Is(err, bets.ErrInsufficientBalance) {
+			t.Fatalf("should fail with an insufficient balance, got %v", err)
+		}
+	})
+}
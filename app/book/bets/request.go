@@ -0,0 +1,120 @@
+package bets
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// Kind represents the Book call a request is gathering signatures for.
+type Kind string
+
+// Set of calls that need signatures before the owner can submit them.
+const (
+	KindPlace              Kind = "place"
+	KindReconcile          Kind = "reconcile"
+	KindCancelModerator    Kind = "cancel_moderator"
+	KindCancelParticipants Kind = "cancel_participants"
+)
+
+// Request represents a Book call waiting on the signatures of its signers.
+// Each signer signs keccak256(abi.encode(betID, signer, nonce)) salted with
+// the Ethereum prefix, using the nonce the contract had for them when the
+// request was created.
+type Request struct {
+	Kind  Kind
+	BetID string
+
+	AmountBetWei *big.Int         // Place only
+	AmountFeeWei *big.Int         // Place and cancels
+	Expiration   *big.Int         // Place only, unix time
+	Moderator    common.Address   // Place only
+	Participants []common.Address // Place only
+	Winners      []common.Address // Reconcile only
+
+	Signers    []common.Address // Accounts that must sign, in the order the contract expects
+	Nonces     []*big.Int       // Nonce each signer signs with
+	Signatures [][]byte         // Signature of each signer, nil until signed
+}
+
+// newRequest constructs a request for the signers with their nonces.
+func newRequest(kind Kind, betID string, signers []common.Address, nonces []*big.Int) *Request {
+	return &Request{
+		Kind:       kind,
+		BetID:      betID,
+		Signers:    signers,
+		Nonces:     nonces,
+		Signatures: make([][]byte, len(signers)),
+	}
+}
+
+// Hash returns the hash the signer must sign, before the Ethereum salt is
+// applied.
+func (r *Request) Hash(signer common.Address) (common.Hash, error) {
+	i, err := r.signerIndex(signer)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return ethereum.Hash(r.BetID, signer, r.Nonces[i])
+}
+
+// Sign signs the request with the signer and adds the signature.
+func (r *Request) Sign(ctx context.Context, signer ethereum.Signer) error {
+	hash, err := r.Hash(signer.Address())
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.SignHash(ctx, hash)
+	if err != nil {
+		return err
+	}
+
+	return r.AddSignature(signer.Address(), sig)
+}
+
+// AddSignature verifies the signature was made by the signer over the
+// request's hash and adds it. The signature is checked locally so a bad
+// signature is rejected before any gas is spent on it.
+func (r *Request) AddSignature(signer common.Address, sig []byte) error {
+	i, err := r.signerIndex(signer)
+	if err != nil {
+		return err
+	}
+
+	if err := ethereum.VerifySignature(signer, sig, r.BetID, signer, r.Nonces[i]); err != nil {
+		return err
+	}
+
+	r.Signatures[i] = sig
+
+	return nil
+}
+
+// Missing returns the signers that haven't signed yet.
+func (r *Request) Missing() []common.Address {
+	var missing []common.Address
+	for i, sig := range r.Signatures {
+		if sig == nil {
+			missing = append(missing, r.Signers[i])
+		}
+	}
+
+	return missing
+}
+
+// signerIndex returns the position of the signer in the request.
+func (r *Request) signerIndex(signer common.Address) (int, error) {
+	for i, s := range r.Signers {
+		if s == signer {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrNotSigner, signer)
+}
@@ -0,0 +1,669 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package book
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BookBetInfo is an auto generated low-level Go binding around an user-defined struct.
+type BookBetInfo struct {
+	State        uint8
+	Participants []common.Address
+	Moderator    common.Address
+	AmountBetWei *big.Int
+	Expiration   *big.Int
+}
+
+// BookMetaData contains all meta data concerning the Book contract.
+var BookMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"}],\"name\":\"EventLog\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"AccountBalance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Balance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"betID\",\"type\":\"string\"}],\"name\":\"BetDetails\",\"outputs\":[{\"components\":[{\"internalType\":\"uint8\",\"name\":\"State\",\"type\":\"uint8\"},{\"internalType\":\"address[]\",\"name\":\"Participants\",\"type\":\"address[]\"},{\"internalType\":\"address\",\"name\":\"Moderator\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"AmountBetWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"Expiration\",\"type\":\"uint256\"}],\"internalType\":\"structBook.BetInfo\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"betID\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amountFeeWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"signatures\",\"type\":\"bytes\"}],\"name\":\"CancelBetModerator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"betID\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amountFeeWei\",\"type\":\"uint256\"}],\"name\":\"CancelBetOwner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"betID\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amountFeeWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"nonces\",\"type\":\"uint256[]\"},{\"internalType\":\"bytes[]\",\"name\":\"signatures\",\"type\":\"bytes[]\"}],\"name\":\"CancelBetParticipants\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Drain\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Nonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"betID\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amountBetWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amountFeeWei\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"expiration\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"moderator\",\"type\":\"address\"},{\"internalType\":\"address[]\",\"name\":\"participants\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"nonces\",\"type\":\"uint256[]\"},{\"internalType\":\"bytes[]\",\"name\":\"signatures\",\"type\":\"bytes[]\"}],\"name\":\"PlaceBet\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"betID\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"},{\"internalType\":\"address[]\",\"name\":\"winners\",\"type\":\"address[]\"}],\"name\":\"ReconcileBet\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"Withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b50336000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550614912806100606000396000f3fe6080604052600436106100c25760003560e01c806357ea89b61161007f578063d67a073f11610059578063d67a073f14610252578063e2a06aca14610269578063e63f341f14610292578063ed21248c146102cf576100c2565b806357ea89b6146101e75780637c64ce36146101fe578063b4a99a4e14610227576100c2565b80630e302132146100c75780630ee216b7146100f05780630ef6788714610119578063221da6a51461014457806330d0cee91461016d578063364529e5146101aa575b600080fd5b3480156100d357600080fd5b506100ee60048036038101906100e9919061315b565b6102d9565b005b3480156100fc57600080fd5b506101176004803603810190610112919061321b565b6108c4565b005b34801561012557600080fd5b5061012e610b7b565b60405161013b9190613286565b60405180910390f35b34801561015057600080fd5b5061016b600480360381019061016691906133ba565b610bc5565b005b34801561017957600080fd5b50610194600480360381019061018f919061347a565b6111d1565b6040516101a19190613286565b60405180910390f35b3480156101b657600080fd5b506101d160048036038101906101cc91906134a7565b611276565b6040516101de919061364f565b60405180910390f35b3480156101f357600080fd5b506101fc611498565b005b34801561020a57600080fd5b5061022560048036038101906102209190613671565b611664565b005b34801561023357600080fd5b5061023c611b93565b6040516102499190613724565b60405180910390f35b34801561025e57600080fd5b50610267611bb7565b005b34801561027557600080fd5b50610290600480360381019061028b919061373f565b611d98565b005b34801561029e57600080fd5b506102b960048036038101906102b4919061347a565b6125b6565b6040516102c69190613286565b60405180910390f35b6102d761265b565b005b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461033157600080fd5b600060028660405161034391906138da565b90815260200160405180910390209050600160ff168160000160000160009054906101000a900460ff1660ff16146103b0576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016103a79061394e565b60405180910390fd5b8060000160040154421015610434576103c8426127ba565b6103d882600001600401546127ba565b6040516020016103e9929190613a2c565b6040516020818303038152906040526040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161042b9190613ab2565b60405180910390fd5b84600160008360000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060020154146104df576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016104d690613b20565b60405180910390fd5b6000868260000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168760405160200161051d93929190613b40565b604051602081830303815290604052805190602001209050600080610543838888612942565b915091508060000151156105925780602001516040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016105899190613ab2565b60405180910390fd5b8360000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614610627576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161061e90613bca565b60405180910390fd5b60005b85518110156106ee5784600501600087838151811061064c5761064b613bea565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff166106db576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016106d290613c8b565b60405180910390fd5b80806106e690613cda565b91505061062a565b5060008460000160010180549050856000016003015461070e9190613d22565b9050600086518261071f9190613d93565b905060005b87518110156107b35781600160008a848151811061074557610744613bea565b5b602002602001015173ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546107999190613dc4565b9250508190555080806107ab90613cda565b915050610724565b50600160008760000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600201600081548092919061082e90613cda565b919050555060028660000160000160006101000a81548160ff021916908360ff160217905550600086600001600301819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a8b6040516020016108939190613e1e565b6040516020818303038152906040526040516108af9190613ab2565b60405180910390a15050505050505050505050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461091c57600080fd5b600060028360405161092e91906138da565b90815260200160405180910390209050600160ff168160000160000160009054906101000a900460ff1660ff161461099b576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016109929061394e565b60405180910390fd5b60008282600001600301546109b09190613e44565b905060005b8260000160010180549050811015610af05781600160008560000160010184815481106109e5576109e4613bea565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001016000828254610a5c9190613dc4565b9250508190555083600160008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001016000828254610ad69190613dc4565b925050819055508080610ae890613cda565b9150506109b5565b5060038260000160000160006101000a81548160ff021916908360ff160217905550600082600001600301819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a84604051602001610b519190613e9e565b604051602081830303815290604052604051610b6d9190613ab2565b60405180910390a150505050565b6000600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010154905090565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610c1d57600080fd5b6000600286604051610c2f91906138da565b90815260200160405180910390209050600160ff168160000160000160009054906101000a900460ff1660ff1614610c9c576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610c939061394e565b60405180910390fd5b828290508160000160010180549050141580610cc357508351816000016001018054905014155b15610d03576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610cfa90613f36565b60405180910390fd5b60005b8160000160010180549050811015610fed576000826000016001018281548110610d3357610d32613bea565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1690506000868381518110610d7557610d74613bea565b5b60200260200101519050366000878786818110610d9557610d94613bea565b5b9050602002810190610da79190613f65565b9150915082600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002015414610e5857610dfd84612adb565b604051602001610e0d9190613fee565b6040516020818303038152906040526040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610e4f9190613ab2565b60405180910390fd5b60008b8585604051602001610e6f93929190613b40565b604051602081830303815290604052805190602001209050600080610e95838686612942565b91509150806000015115610ee45780602001516040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610edb9190613ab2565b60405180910390fd5b8673ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614610f7b57610f2087612adb565b604051602001610f30919061403a565b6040516020818303038152906040526040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610f729190613ab2565b60405180910390fd5b600160008873ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002016000815480929190610fce90613cda565b9190505550505050505050508080610fe590613cda565b915050610d06565b5060008582600001600301546110039190613e44565b905060005b826000016001018054905081101561114357816001600085600001600101848154811061103857611037613bea565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546110af9190613dc4565b9250508190555086600160008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546111299190613dc4565b92505081905550808061113b90613cda565b915050611008565b5060038260000160000160006101000a81548160ff021916908360ff160217905550600082600001600301819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a876040516020016111a491906140d2565b6040516020818303038152906040526040516111c09190613ab2565b60405180910390a150505050505050565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461122c57600080fd5b600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600201549050919050565b61127e612d3f565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146112d657600080fd5b600060ff166002836040516112eb91906138da565b908152602001604051809103902060000160000160009054906101000a900460ff1660ff1603611350576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161134790614140565b60405180910390fd5b60028260405161136091906138da565b90815260200160405180910390206000016040518060a00160405290816000820160009054906101000a900460ff1660ff1660ff1681526020016001820180548060200260200160405190810160405280929190818152602001828054801561141e57602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190600101908083116113d4575b505050505081526020016002820160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001600382015481526020016004820154815250509050919050565b60003390506000600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015403611522576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611519906141ac565b60405180910390fd5b6000600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015490506000600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101819055508173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f193505050501580156115f7573d6000803e3d6000fd5b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61162233612adb565b61162b836127ba565b60405160200161163c929190614218565b6040516020818303038152906040526040516116589190613ab2565b60405180910390a15050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146116bc57600080fd5b60006002866040516116ce91906138da565b90815260200160405180910390209050600160ff168160000160000160009054906101000a900460ff1660ff161461173b576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016117329061394e565b60405180910390fd5b83600160008360000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060020154146117e6576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016117dd90613b20565b60405180910390fd5b6000868260000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168660405160200161182493929190613b40565b60405160208183030381529060405280519060200120905060008061184a838787612942565b915091508060000151156118995780602001516040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016118909190613ab2565b60405180910390fd5b8360000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161461192e576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161192590613bca565b60405180910390fd5b60008885600001600301546119439190613e44565b905060005b8560000160010180549050811015611a8357816001600088600001600101848154811061197857611977613bea565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546119ef9190613dc4565b9250508190555089600160008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001016000828254611a699190613dc4565b925050819055508080611a7b90613cda565b915050611948565b50600160008660000160020160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002016000815480929190611afe90613cda565b919050555060038560000160000160006101000a81548160ff021916908360ff160217905550600085600001600301819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a8a604051602001611b63919061428f565b604051602081830303815290604052604051611b7f9190613ab2565b60405180910390a150505050505050505050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614611c0f57600080fd5b60003390506000600160008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206001015490506000600160008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101819055508173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f19350505050158015611d2b573d6000803e3d6000fd5b507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a611d5683612adb565b611d5f836127ba565b604051602001611d709291906142db565b604051602081830303815290604052604051611d8c9190613ab2565b60405180910390a15050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614611df057600080fd5b600060ff1660028a604051611e0591906138da565b908152602001604051809103902060000160000160009054906101000a900460ff1660ff1614611e6a576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611e6190614378565b60405180910390fd5b60008789611e789190613dc4565b905060005b8551811015612188576000868281518110611e9b57611e9a613bea565b5b602002602001015190506000868381518110611eba57611eb9613bea565b5b60200260200101519050366000878786818110611eda57611ed9613bea565b5b9050602002810190611eec9190613f65565b9150915085600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101541015611f9e57611f4384612adb565b604051602001611f5391906143be565b6040516020818303038152906040526040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611f959190613ab2565b60405180910390fd5b82600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600201541461204b57611ff084612adb565b6040516020016120009190613fee565b6040516020818303038152906040526040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016120429190613ab2565b60405180910390fd5b60008f858560405160200161206293929190613b40565b604051602081830303815290604052805190602001209050600080612088838686612942565b915091508060000151156120d75780602001516040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016120ce9190613ab2565b60405180910390fd5b8673ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161461216e5761211387612adb565b604051602001612123919061403a565b6040516020818303038152906040526040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016121659190613ab2565b60405180910390fd5b50505050505050808061218090613cda565b915050611e7d565b506040518060a00160405280600160ff1681526020018681526020018773ffffffffffffffffffffffffffffffffffffffff1681526020018a81526020018881525060028b6040516121da91906138da565b908152602001604051809103902060000160008201518160000160006101000a81548160ff021916908360ff1602179055506020820151816001019080519060200190612228929190612d87565b5060408201518160020160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550606082015181600301556080820151816004015590505060005b85518110156124685760008682815181106122a8576122a7613bea565b5b6020026020010151905082600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546123049190613e44565b92505081905550600160008273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600201600081548092919061235e90613cda565b919050555089600160008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546123d69190613dc4565b92505081905550600160028d6040516123ef91906138da565b908152602001604051809103902060050160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff02191690831515021790555050808061246090613cda565b91505061228a565b50600160008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160009054906101000a900460ff16612554576040518060600160405280600115158152602001600081526020016000815250600160008873ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008201518160000160006101000a81548160ff02191690831515021790555060208201518160010155604082015181600201559050505b7fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a8a604051602001612586919061440a565b6040516020818303038152906040526040516125a29190613ab2565b60405180910390a150505050505050505050565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461261157600080fd5b600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101549050919050565b60018060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060000160006101000a81548160ff02191690831515021790555034600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160008282546127079190613dc4565b925050819055507fd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a61273833612adb565b612783600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600101546127ba565b60405160200161279492919061447c565b6040516020818303038152906040526040516127b09190613ab2565b60405180910390a1565b606060008203612801576040518060400160405280600181526020017f3000000000000000000000000000000000000000000000000000000000000000815250905061293d565b600082905060005b6000821461283357808061281c90613cda565b915050600a8261282c9190613d93565b9150612809565b60008167ffffffffffffffff81111561284f5761284e612e79565b5b6040519080825280601f01601f1916602001820160405280156128815781602001600182028036833780820191505090505b50905060008290505b600086146129355760018161289f9190613e44565b90506000600a80886128b19190613d93565b6128bb9190613d22565b876128c69190613e44565b60306128d291906144cd565b905060008160f81b9050808484815181106128f0576128ef613bea565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350600a8861292c9190613d93565b9750505061288a565b819450505050505b919050565b600061294c612e11565b604184849050146129a05760006129976040518060400160405280601881526020017f696e76616c6964207369676e6174757265206c656e6774680000000000000000815250612c9e565b91509150612ad3565b60006040518060400160405280601c81526020017f19457468657265756d205369676e6564204d6573736167653a0a3332000000008152509050600081876040516020016129ef929190614574565b60405160208183030381529060405280519060200120905060008686600090602092612a1d939291906145a6565b90612a2891906145f9565b905060008787602090604092612a40939291906145a6565b90612a4b91906145f9565b9050600088886040818110612a6357612a62613bea565b5b9050013560f81c60f81b60f81c905060018482858560405160008152602001604052604051612a959493929190614676565b6020604051602081039080840390855afa158015612ab7573d6000803e3d6000fd5b50505060206040510351612ac9612cc5565b9650965050505050505b935093915050565b60606000602867ffffffffffffffff811115612afa57612af9612e79565b5b6040519080825280601f01601f191660200182016040528015612b2c5781602001600182028036833780820191505090505b50905060005b6014811015612c94576000816013612b4a9190613e44565b6008612b569190613d22565b6002612b6291906147ee565b8573ffffffffffffffffffffffffffffffffffffffff16612b839190613d93565b60f81b9050600060108260f81c612b9a9190614839565b60f81b905060008160f81c6010612bb1919061486a565b8360f81c612bbf91906148a7565b60f81b9050612bcd82612cf9565b85856002612bdb9190613d22565b81518110612bec57612beb613bea565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350612c2481612cf9565b856001866002612c349190613d22565b612c3e9190613dc4565b81518110612c4f57612c4e613bea565b5b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a9053505050508080612c8c90613cda565b915050612b32565b5080915050919050565b612ca6612e11565b6040518060400160405280600115158152602001838152509050919050565b612ccd612e11565b604051806040016040528060001515815260200160405180602001604052806000815250815250905090565b6000600a8260f81c60ff161015612d245760308260f81c612d1a91906144cd565b60f81b9050612d3a565b60578260f81c612d3491906144cd565b60f81b90505b919050565b6040518060a00160405280600060ff16815260200160608152602001600073ffffffffffffffffffffffffffffffffffffffff16815260200160008152602001600081525090565b828054828255906000526020600020908101928215612e00579160200282015b82811115612dff5782518260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555091602001919060010190612da7565b5b509050612e0d9190612e2d565b5090565b6040518060400160405280600015158152602001606081525090565b5b80821115612e46576000816000905550600101612e2e565b5090565b6000604051905090565b600080fd5b600080fd5b600080fd5b600080fd5b6000601f19601f8301169050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b612eb182612e68565b810181811067ffffffffffffffff82111715612ed057612ecf612e79565b5b80604052505050565b6000612ee3612e4a565b9050612eef8282612ea8565b919050565b600067ffffffffffffffff821115612f0f57612f0e612e79565b5b612f1882612e68565b9050602081019050919050565b82818337600083830152505050565b6000612f47612f4284612ef4565b612ed9565b905082815260208101848484011115612f6357612f62612e63565b5b612f6e848285612f25565b509392505050565b600082601f830112612f8b57612f8a612e5e565b5b8135612f9b848260208601612f34565b91505092915050565b6000819050919050565b612fb781612fa4565b8114612fc257600080fd5b50565b600081359050612fd481612fae565b92915050565b600080fd5b600080fd5b60008083601f840112612ffa57612ff9612e5e565b5b8235905067ffffffffffffffff81111561301757613016612fda565b5b60208301915083600182028301111561303357613032612fdf565b5b9250929050565b600067ffffffffffffffff82111561305557613054612e79565b5b602082029050602081019050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061309182613066565b9050919050565b6130a181613086565b81146130ac57600080fd5b50565b6000813590506130be81613098565b92915050565b60006130d76130d28461303a565b612ed9565b905080838252602082019050602084028301858111156130fa576130f9612fdf565b5b835b81811015613123578061310f88826130af565b8452602084019350506020810190506130fc565b5050509392505050565b600082601f83011261314257613141612e5e565b5b81356131528482602086016130c4565b91505092915050565b60008060008060006080868803121561317757613176612e54565b5b600086013567ffffffffffffffff81111561319557613194612e59565b5b6131a188828901612f76565b95505060206131b288828901612fc5565b945050604086013567ffffffffffffffff8111156131d3576131d2612e59565b5b6131df88828901612fe4565b9350935050606086013567ffffffffffffffff81111561320257613201612e59565b5b61320e8882890161312d565b9150509295509295909350565b6000806040838503121561323257613231612e54565b5b600083013567ffffffffffffffff8111156132505761324f612e59565b5b61325c85828601612f76565b925050602061326d85828601612fc5565b9150509250929050565b61328081612fa4565b82525050565b600060208201905061329b6000830184613277565b92915050565b600067ffffffffffffffff8211156132bc576132bb612e79565b5b602082029050602081019050919050565b60006132e06132db846132a1565b612ed9565b9050808382526020820190506020840283018581111561330357613302612fdf565b5b835b8181101561332c57806133188882612fc5565b845260208401935050602081019050613305565b5050509392505050565b600082601f83011261334b5761334a612e5e565b5b813561335b8482602086016132cd565b91505092915050565b60008083601f84011261337a57613379612e5e565b5b8235905067ffffffffffffffff81111561339757613396612fda565b5b6020830191508360208202830111156133b3576133b2612fdf565b5b9250929050565b6000806000806000608086880312156133d6576133d5612e54565b5b600086013567ffffffffffffffff8111156133f4576133f3612e59565b5b61340088828901612f76565b955050602061341188828901612fc5565b945050604086013567ffffffffffffffff81111561343257613431612e59565b5b61343e88828901613336565b935050606086013567ffffffffffffffff81111561345f5761345e612e59565b5b61346b88828901613364565b92509250509295509295909350565b6000602082840312156134905761348f612e54565b5b600061349e848285016130af565b91505092915050565b6000602082840312156134bd576134bc612e54565b5b600082013567ffffffffffffffff8111156134db576134da612e59565b5b6134e784828501612f76565b91505092915050565b600060ff82169050919050565b613506816134f0565b82525050565b600081519050919050565b600082825260208201905092915050565b6000819050602082019050919050565b61354181613086565b82525050565b60006135538383613538565b60208301905092915050565b6000602082019050919050565b60006135778261350c565b6135818185613517565b935061358c83613528565b8060005b838110156135bd5781516135a48882613547565b97506135af8361355f565b925050600181019050613590565b5085935050505092915050565b6135d381612fa4565b82525050565b600060a0830160008301516135f160008601826134fd565b5060208301518482036020860152613609828261356c565b915050604083015161361e6040860182613538565b50606083015161363160608601826135ca565b50608083015161364460808601826135ca565b508091505092915050565b6000602082019050818103600083015261366981846135d9565b905092915050565b60008060008060006080868803121561368d5761368c612e54565b5b600086013567ffffffffffffffff8111156136ab576136aa612e59565b5b6136b788828901612f76565b95505060206136c888828901612fc5565b94505060406136d988828901612fc5565b935050606086013567ffffffffffffffff8111156136fa576136f9612e59565b5b61370688828901612fe4565b92509250509295509295909350565b61371e81613086565b82525050565b60006020820190506137396000830184613715565b92915050565b60008060008060008060008060006101008a8c03121561376257613761612e54565b5b60008a013567ffffffffffffffff8111156137805761377f612e59565b5b61378c8c828d01612f76565b995050602061379d8c828d01612fc5565b98505060406137ae8c828d01612fc5565b97505060606137bf8c828d01612fc5565b96505060806137d08c828d016130af565b95505060a08a013567ffffffffffffffff8111156137f1576137f0612e59565b5b6137fd8c828d0161312d565b94505060c08a013567ffffffffffffffff81111561381e5761381d612e59565b5b61382a8c828d01613336565b93505060e08a013567ffffffffffffffff81111561384b5761384a612e59565b5b6138578c828d01613364565b92509250509295985092959850929598565b600081519050919050565b600081905092915050565b60005b8381101561389d578082015181840152602081019050613882565b60008484015250505050565b60006138b482613869565b6138be8185613874565b93506138ce81856020860161387f565b80840191505092915050565b60006138e682846138a9565b915081905092915050565b600082825260208201905092915050565b7f626574206973206e6f74206c6976650000000000000000000000000000000000600082015250565b6000613938600f836138f1565b915061394382613902565b602082019050919050565b600060208201905081810360008301526139678161392b565b9050919050565b7f62657420686173206e6f74207965742065787069726564203a20626c6f636b2e60008201527f74696d657374616d705b00000000000000000000000000000000000000000000602082015250565b60006139ca602a83613874565b91506139d58261396e565b602a82019050919050565b7f5d2065787069726174696f6e5b00000000000000000000000000000000000000815250565b7f5d00000000000000000000000000000000000000000000000000000000000000815250565b6000613a37826139bd565b9150613a4382856138a9565b9150613a4e826139e0565b600d82019150613a5e82846138a9565b9150613a6982613a06565b6001820191508190509392505050565b6000613a8482613869565b613a8e81856138f1565b9350613a9e81856020860161387f565b613aa781612e68565b840191505092915050565b60006020820190508181036000830152613acc8184613a79565b905092915050565b7f696e76616c6964206d6f64657261746f72206e6f6e6365000000000000000000600082015250565b6000613b0a6017836138f1565b9150613b1582613ad4565b602082019050919050565b60006020820190508181036000830152613b3981613afd565b9050919050565b60006060820190508181036000830152613b5a8186613a79565b9050613b696020830185613715565b613b766040830184613277565b949350505050565b7f696e76616c6964206d6f64657261746f72207369676e61747572650000000000600082015250565b6000613bb4601b836138f1565b9150613bbf82613b7e565b602082019050919050565b60006020820190508181036000830152613be381613ba7565b9050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f77696e6e65722061646472657373206973206e6f74206120706172746963697060008201527f616e740000000000000000000000000000000000000000000000000000000000602082015250565b6000613c756023836138f1565b9150613c8082613c19565b604082019050919050565b60006020820190508181036000830152613ca481613c68565b9050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000613ce582612fa4565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8203613d1757613d16613cab565b5b600182019050919050565b6000613d2d82612fa4565b9150613d3883612fa4565b9250828202613d4681612fa4565b91508282048414831517613d5d57613d5c613cab565b5b5092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b6000613d9e82612fa4565b9150613da983612fa4565b925082613db957613db8613d64565b5b828204905092915050565b6000613dcf82612fa4565b9150613dda83612fa4565b9250828201905080821115613df257613df1613cab565b5b92915050565b7f20686173206265656e207265636f6e63696c6564000000000000000000000000815250565b6000613e2a82846138a9565b9150613e3582613df8565b60148201915081905092915050565b6000613e4f82612fa4565b9150613e5a83612fa4565b9250828203905081811115613e7257613e71613cab565b5b92915050565b7f20686173206265656e2063616e63656c6c6564206279206f776e657200000000815250565b6000613eaa82846138a9565b9150613eb582613e78565b601c8201915081905092915050565b7f696e76616c6964206e756d626572206f66207369676e617475726573206f722060008201527f6e6f6e6365730000000000000000000000000000000000000000000000000000602082015250565b6000613f206026836138f1565b9150613f2b82613ec4565b604082019050919050565b60006020820190508181036000830152613f4f81613f13565b9050919050565b600080fd5b600080fd5b600080fd5b60008083356001602003843603038112613f8257613f81613f56565b5b80840192508235915067ffffffffffffffff821115613fa457613fa3613f5b565b5b602083019250600182023603831315613fc057613fbf613f60565b5b509250929050565b7f2068617320616e20696e76616c6964206e6f6e63650000000000000000000000815250565b6000613ffa82846138a9565b915061400582613fc8565b60158201915081905092915050565b7f206164647265737320646f65736e2774206d61746368207369676e6174757265815250565b600061404682846138a9565b915061405182614014565b60208201915081905092915050565b7f20686173206265656e2063616e63656c6c656420627920616c6c20706172746960008201527f636970616e747300000000000000000000000000000000000000000000000000602082015250565b60006140bc602783613874565b91506140c782614060565b602782019050919050565b60006140de82846138a9565b91506140e9826140af565b915081905092915050565b7f62657420696420646f6573206e6f742065786973740000000000000000000000600082015250565b600061412a6015836138f1565b9150614135826140f4565b602082019050919050565b600060208201905081810360008301526141598161411d565b9050919050565b7f6e6f7420656e6f7567682062616c616e63650000000000000000000000000000600082015250565b60006141966012836138f1565b91506141a182614160565b602082019050919050565b600060208201905081810360008301526141c581614189565b9050919050565b7f77697468647261775b0000000000000000000000000000000000000000000000815250565b7f5d20616d6f756e745b0000000000000000000000000000000000000000000000815250565b6000614223826141cc565b60098201915061423382856138a9565b915061423e826141f2565b60098201915061424e82846138a9565b915061425982613a06565b6001820191508190509392505050565b7f20686173206265656e2063616e63656c6c6564206279206d6f64657261746f72815250565b600061429b82846138a9565b91506142a682614269565b60208201915081905092915050565b7f647261696e5b0000000000000000000000000000000000000000000000000000815250565b60006142e6826142b5565b6006820191506142f682856138a9565b9150614301826141f2565b60098201915061431182846138a9565b915061431c82613a06565b6001820191508190509392505050565b7f62657420696420616c7265616479206578697374730000000000000000000000600082015250565b60006143626015836138f1565b915061436d8261432c565b602082019050919050565b6000602082019050818103600083015261439181614355565b9050919050565b7f2068617320616e20696e73756666696369656e742062616c616e636500000000815250565b60006143ca82846138a9565b91506143d582614398565b601c8201915081905092915050565b7f20686173206265656e20616464656420746f207468652073797374656d000000815250565b600061441682846138a9565b9150614421826143e4565b601d8201915081905092915050565b7f6465706f7369745b000000000000000000000000000000000000000000000000815250565b7f5d2062616c616e63655b00000000000000000000000000000000000000000000815250565b600061448782614430565b60088201915061449782856138a9565b91506144a282614456565b600a820191506144b282846138a9565b91506144bd82613a06565b6001820191508190509392505050565b60006144d8826134f0565b91506144e3836134f0565b9250828201905060ff8111156144fc576144fb613cab565b5b92915050565b600081519050919050565b600081905092915050565b600061452382614502565b61452d818561450d565b935061453d81856020860161387f565b80840191505092915050565b6000819050919050565b6000819050919050565b61456e61456982614549565b614553565b82525050565b60006145808285614518565b915061458c828461455d565b6020820191508190509392505050565b600080fd5b600080fd5b600080858511156145ba576145b961459c565b5b838611156145cb576145ca6145a1565b5b6001850283019150848603905094509492505050565b600082905092915050565b600082821b905092915050565b600061460583836145e1565b826146108135614549565b925060208210156146505761464b7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff836020036008026145ec565b831692505b505092915050565b61466181614549565b82525050565b614670816134f0565b82525050565b600060808201905061468b6000830187614658565b6146986020830186614667565b6146a56040830185614658565b6146b26060830184614658565b95945050505050565b60008160011c9050919050565b6000808291508390505b6001851115614712578086048111156146ee576146ed613cab565b5b60018516156146fd5780820291505b808102905061470b856146bb565b94506146d2565b94509492505050565b60008261472b57600190506147e7565b8161473957600090506147e7565b816001811461474f576002811461475957614788565b60019150506147e7565b60ff84111561476b5761476a613cab565b5b8360020a91508482111561478257614781613cab565b5b506147e7565b5060208310610133831016604e8410600b84101617156147bd5782820a9050838111156147b8576147b7613cab565b5b6147e7565b6147ca84848460016146c8565b925090508184048111156147e1576147e0613cab565b5b81810290505b9392505050565b60006147f982612fa4565b915061480483612fa4565b92506148317fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff848461471b565b905092915050565b6000614844826134f0565b915061484f836134f0565b92508261485f5761485e613d64565b5b828204905092915050565b6000614875826134f0565b9150614880836134f0565b925082820261488e816134f0565b91508082146148a05761489f613cab565b5b5092915050565b60006148b2826134f0565b91506148bd836134f0565b9250828203905060ff8111156148d6576148d5613cab565b5b9291505056fea264697066735822122057ead361b34d0e4bff653b8d1b89fe695ff133358183afbbb7e9d7a3af5d2e3764736f6c63430008150033",
+}
+
+// BookABI is the input ABI used to generate the binding from.
+// Deprecated: Use BookMetaData.ABI instead.
+var BookABI = BookMetaData.ABI
+
+// BookBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BookMetaData.Bin instead.
+var BookBin = BookMetaData.Bin
+
+// DeployBook deploys a new Ethereum contract, binding an instance of Book to it.
+func DeployBook(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Book, error) {
+	parsed, err := BookMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BookBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Book{BookCaller: BookCaller{contract: contract}, BookTransactor: BookTransactor{contract: contract}, BookFilterer: BookFilterer{contract: contract}}, nil
+}
+
+// Book is an auto generated Go binding around an Ethereum contract.
+type Book struct {
+	BookCaller     // Read-only binding to the contract
+	BookTransactor // Write-only binding to the contract
+	BookFilterer   // Log filterer for contract events
+}
+
+// BookCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BookCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BookTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BookTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BookFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BookFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BookSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BookSession struct {
+	Contract     *Book             // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BookCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BookCallerSession struct {
+	Contract *BookCaller   // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// BookTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BookTransactorSession struct {
+	Contract     *BookTransactor   // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BookRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BookRaw struct {
+	Contract *Book // Generic contract binding to access the raw methods on
+}
+
+// BookCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BookCallerRaw struct {
+	Contract *BookCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BookTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BookTransactorRaw struct {
+	Contract *BookTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBook creates a new instance of Book, bound to a specific deployed contract.
+func NewBook(address common.Address, backend bind.ContractBackend) (*Book, error) {
+	contract, err := bindBook(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Book{BookCaller: BookCaller{contract: contract}, BookTransactor: BookTransactor{contract: contract}, BookFilterer: BookFilterer{contract: contract}}, nil
+}
+
+// NewBookCaller creates a new read-only instance of Book, bound to a specific deployed contract.
+func NewBookCaller(address common.Address, caller bind.ContractCaller) (*BookCaller, error) {
+	contract, err := bindBook(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BookCaller{contract: contract}, nil
+}
+
+// NewBookTransactor creates a new write-only instance of Book, bound to a specific deployed contract.
+func NewBookTransactor(address common.Address, transactor bind.ContractTransactor) (*BookTransactor, error) {
+	contract, err := bindBook(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BookTransactor{contract: contract}, nil
+}
+
+// NewBookFilterer creates a new log filterer instance of Book, bound to a specific deployed contract.
+func NewBookFilterer(address common.Address, filterer bind.ContractFilterer) (*BookFilterer, error) {
+	contract, err := bindBook(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BookFilterer{contract: contract}, nil
+}
+
+// bindBook binds a generic wrapper to an already deployed contract.
+func bindBook(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BookMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Book *BookRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Book.Contract.BookCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Book *BookRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Book.Contract.BookTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Book *BookRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Book.Contract.BookTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Book *BookCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Book.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Book *BookTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Book.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Book *BookTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Book.Contract.contract.Transact(opts, method, params...)
+}
+
+// AccountBalance is a free data retrieval call binding the contract method 0xe63f341f.
+//
+// Solidity: function AccountBalance(address account) view returns(uint256)
+func (_Book *BookCaller) AccountBalance(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Book.contract.Call(opts, &out, "AccountBalance", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AccountBalance is a free data retrieval call binding the contract method 0xe63f341f.
+//
+// Solidity: function AccountBalance(address account) view returns(uint256)
+func (_Book *BookSession) AccountBalance(account common.Address) (*big.Int, error) {
+	return _Book.Contract.AccountBalance(&_Book.CallOpts, account)
+}
+
+// AccountBalance is a free data retrieval call binding the contract method 0xe63f341f.
+//
+// Solidity: function AccountBalance(address account) view returns(uint256)
+func (_Book *BookCallerSession) AccountBalance(account common.Address) (*big.Int, error) {
+	return _Book.Contract.AccountBalance(&_Book.CallOpts, account)
+}
+
+// Balance is a free data retrieval call binding the contract method 0x0ef67887.
+//
+// Solidity: function Balance() view returns(uint256)
+func (_Book *BookCaller) Balance(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Book.contract.Call(opts, &out, "Balance")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Balance is a free data retrieval call binding the contract method 0x0ef67887.
+//
+// Solidity: function Balance() view returns(uint256)
+func (_Book *BookSession) Balance() (*big.Int, error) {
+	return _Book.Contract.Balance(&_Book.CallOpts)
+}
+
+// Balance is a free data retrieval call binding the contract method 0x0ef67887.
+//
+// Solidity: function Balance() view returns(uint256)
+func (_Book *BookCallerSession) Balance() (*big.Int, error) {
+	return _Book.Contract.Balance(&_Book.CallOpts)
+}
+
+// BetDetails is a free data retrieval call binding the contract method 0x364529e5.
+//
+// Solidity: function BetDetails(string betID) view returns((uint8,address[],address,uint256,uint256))
+func (_Book *BookCaller) BetDetails(opts *bind.CallOpts, betID string) (BookBetInfo, error) {
+	var out []interface{}
+	err := _Book.contract.Call(opts, &out, "BetDetails", betID)
+
+	if err != nil {
+		return *new(BookBetInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(BookBetInfo)).(*BookBetInfo)
+
+	return out0, err
+
+}
+
+// BetDetails is a free data retrieval call binding the contract method 0x364529e5.
+//
+// Solidity: function BetDetails(string betID) view returns((uint8,address[],address,uint256,uint256))
+func (_Book *BookSession) BetDetails(betID string) (BookBetInfo, error) {
+	return _Book.Contract.BetDetails(&_Book.CallOpts, betID)
+}
+
+// BetDetails is a free data retrieval call binding the contract method 0x364529e5.
+//
+// Solidity: function BetDetails(string betID) view returns((uint8,address[],address,uint256,uint256))
+func (_Book *BookCallerSession) BetDetails(betID string) (BookBetInfo, error) {
+	return _Book.Contract.BetDetails(&_Book.CallOpts, betID)
+}
+
+// Nonce is a free data retrieval call binding the contract method 0x30d0cee9.
+//
+// Solidity: function Nonce(address account) view returns(uint256)
+func (_Book *BookCaller) Nonce(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Book.contract.Call(opts, &out, "Nonce", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Nonce is a free data retrieval call binding the contract method 0x30d0cee9.
+//
+// Solidity: function Nonce(address account) view returns(uint256)
+func (_Book *BookSession) Nonce(account common.Address) (*big.Int, error) {
+	return _Book.Contract.Nonce(&_Book.CallOpts, account)
+}
+
+// Nonce is a free data retrieval call binding the contract method 0x30d0cee9.
+//
+// Solidity: function Nonce(address account) view returns(uint256)
+func (_Book *BookCallerSession) Nonce(account common.Address) (*big.Int, error) {
+	return _Book.Contract.Nonce(&_Book.CallOpts, account)
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Book *BookCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Book.contract.Call(opts, &out, "Owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Book *BookSession) Owner() (common.Address, error) {
+	return _Book.Contract.Owner(&_Book.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0xb4a99a4e.
+//
+// Solidity: function Owner() view returns(address)
+func (_Book *BookCallerSession) Owner() (common.Address, error) {
+	return _Book.Contract.Owner(&_Book.CallOpts)
+}
+
+// CancelBetModerator is a paid mutator transaction binding the contract method 0x7c64ce36.
+//
+// Solidity: function CancelBetModerator(string betID, uint256 amountFeeWei, uint256 nonce, bytes signatures) returns()
+func (_Book *BookTransactor) CancelBetModerator(opts *bind.TransactOpts, betID string, amountFeeWei *big.Int, nonce *big.Int, signatures []byte) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "CancelBetModerator", betID, amountFeeWei, nonce, signatures)
+}
+
+// CancelBetModerator is a paid mutator transaction binding the contract method 0x7c64ce36.
+//
+// Solidity: function CancelBetModerator(string betID, uint256 amountFeeWei, uint256 nonce, bytes signatures) returns()
+func (_Book *BookSession) CancelBetModerator(betID string, amountFeeWei *big.Int, nonce *big.Int, signatures []byte) (*types.Transaction, error) {
+	return _Book.Contract.CancelBetModerator(&_Book.TransactOpts, betID, amountFeeWei, nonce, signatures)
+}
+
+// CancelBetModerator is a paid mutator transaction binding the contract method 0x7c64ce36.
+//
+// Solidity: function CancelBetModerator(string betID, uint256 amountFeeWei, uint256 nonce, bytes signatures) returns()
+func (_Book *BookTransactorSession) CancelBetModerator(betID string, amountFeeWei *big.Int, nonce *big.Int, signatures []byte) (*types.Transaction, error) {
+	return _Book.Contract.CancelBetModerator(&_Book.TransactOpts, betID, amountFeeWei, nonce, signatures)
+}
+
+// CancelBetOwner is a paid mutator transaction binding the contract method 0x0ee216b7.
+//
+// Solidity: function CancelBetOwner(string betID, uint256 amountFeeWei) returns()
+func (_Book *BookTransactor) CancelBetOwner(opts *bind.TransactOpts, betID string, amountFeeWei *big.Int) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "CancelBetOwner", betID, amountFeeWei)
+}
+
+// CancelBetOwner is a paid mutator transaction binding the contract method 0x0ee216b7.
+//
+// Solidity: function CancelBetOwner(string betID, uint256 amountFeeWei) returns()
+func (_Book *BookSession) CancelBetOwner(betID string, amountFeeWei *big.Int) (*types.Transaction, error) {
+	return _Book.Contract.CancelBetOwner(&_Book.TransactOpts, betID, amountFeeWei)
+}
+
+// CancelBetOwner is a paid mutator transaction binding the contract method 0x0ee216b7.
+//
+// Solidity: function CancelBetOwner(string betID, uint256 amountFeeWei) returns()
+func (_Book *BookTransactorSession) CancelBetOwner(betID string, amountFeeWei *big.Int) (*types.Transaction, error) {
+	return _Book.Contract.CancelBetOwner(&_Book.TransactOpts, betID, amountFeeWei)
+}
+
+// CancelBetParticipants is a paid mutator transaction binding the contract method 0x221da6a5.
+//
+// Solidity: function CancelBetParticipants(string betID, uint256 amountFeeWei, uint256[] nonces, bytes[] signatures) returns()
+func (_Book *BookTransactor) CancelBetParticipants(opts *bind.TransactOpts, betID string, amountFeeWei *big.Int, nonces []*big.Int, signatures [][]byte) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "CancelBetParticipants", betID, amountFeeWei, nonces, signatures)
+}
+
+// CancelBetParticipants is a paid mutator transaction binding the contract method 0x221da6a5.
+//
+// Solidity: function CancelBetParticipants(string betID, uint256 amountFeeWei, uint256[] nonces, bytes[] signatures) returns()
+func (_Book *BookSession) CancelBetParticipants(betID string, amountFeeWei *big.Int, nonces []*big.Int, signatures [][]byte) (*types.Transaction, error) {
+	return _Book.Contract.CancelBetParticipants(&_Book.TransactOpts, betID, amountFeeWei, nonces, signatures)
+}
+
+// CancelBetParticipants is a paid mutator transaction binding the contract method 0x221da6a5.
+//
+// Solidity: function CancelBetParticipants(string betID, uint256 amountFeeWei, uint256[] nonces, bytes[] signatures) returns()
+func (_Book *BookTransactorSession) CancelBetParticipants(betID string, amountFeeWei *big.Int, nonces []*big.Int, signatures [][]byte) (*types.Transaction, error) {
+	return _Book.Contract.CancelBetParticipants(&_Book.TransactOpts, betID, amountFeeWei, nonces, signatures)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Book *BookTransactor) Deposit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "Deposit")
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Book *BookSession) Deposit() (*types.Transaction, error) {
+	return _Book.Contract.Deposit(&_Book.TransactOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xed21248c.
+//
+// Solidity: function Deposit() payable returns()
+func (_Book *BookTransactorSession) Deposit() (*types.Transaction, error) {
+	return _Book.Contract.Deposit(&_Book.TransactOpts)
+}
+
+// Drain is a paid mutator transaction binding the contract method 0xd67a073f.
+//
+// Solidity: function Drain() returns()
+func (_Book *BookTransactor) Drain(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "Drain")
+}
+
+// Drain is a paid mutator transaction binding the contract method 0xd67a073f.
+//
+// Solidity: function Drain() returns()
+func (_Book *BookSession) Drain() (*types.Transaction, error) {
+	return _Book.Contract.Drain(&_Book.TransactOpts)
+}
+
+// Drain is a paid mutator transaction binding the contract method 0xd67a073f.
+//
+// Solidity: function Drain() returns()
+func (_Book *BookTransactorSession) Drain() (*types.Transaction, error) {
+	return _Book.Contract.Drain(&_Book.TransactOpts)
+}
+
+// PlaceBet is a paid mutator transaction binding the contract method 0xe2a06aca.
+//
+// Solidity: function PlaceBet(string betID, uint256 amountBetWei, uint256 amountFeeWei, uint256 expiration, address moderator, address[] participants, uint256[] nonces, bytes[] signatures) returns()
+func (_Book *BookTransactor) PlaceBet(opts *bind.TransactOpts, betID string, amountBetWei *big.Int, amountFeeWei *big.Int, expiration *big.Int, moderator common.Address, participants []common.Address, nonces []*big.Int, signatures [][]byte) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "PlaceBet", betID, amountBetWei, amountFeeWei, expiration, moderator, participants, nonces, signatures)
+}
+
+// PlaceBet is a paid mutator transaction binding the contract method 0xe2a06aca.
+//
+// Solidity: function PlaceBet(string betID, uint256 amountBetWei, uint256 amountFeeWei, uint256 expiration, address moderator, address[] participants, uint256[] nonces, bytes[] signatures) returns()
+func (_Book *BookSession) PlaceBet(betID string, amountBetWei *big.Int, amountFeeWei *big.Int, expiration *big.Int, moderator common.Address, participants []common.Address, nonces []*big.Int, signatures [][]byte) (*types.Transaction, error) {
+	return _Book.Contract.PlaceBet(&_Book.TransactOpts, betID, amountBetWei, amountFeeWei, expiration, moderator, participants, nonces, signatures)
+}
+
+// PlaceBet is a paid mutator transaction binding the contract method 0xe2a06aca.
+//
+// Solidity: function PlaceBet(string betID, uint256 amountBetWei, uint256 amountFeeWei, uint256 expiration, address moderator, address[] participants, uint256[] nonces, bytes[] signatures) returns()
+func (_Book *BookTransactorSession) PlaceBet(betID string, amountBetWei *big.Int, amountFeeWei *big.Int, expiration *big.Int, moderator common.Address, participants []common.Address, nonces []*big.Int, signatures [][]byte) (*types.Transaction, error) {
+	return _Book.Contract.PlaceBet(&_Book.TransactOpts, betID, amountBetWei, amountFeeWei, expiration, moderator, participants, nonces, signatures)
+}
+
+// ReconcileBet is a paid mutator transaction binding the contract method 0x0e302132.
+//
+// Solidity: function ReconcileBet(string betID, uint256 nonce, bytes signature, address[] winners) returns()
+func (_Book *BookTransactor) ReconcileBet(opts *bind.TransactOpts, betID string, nonce *big.Int, signature []byte, winners []common.Address) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "ReconcileBet", betID, nonce, signature, winners)
+}
+
+// ReconcileBet is a paid mutator transaction binding the contract method 0x0e302132.
+//
+// Solidity: function ReconcileBet(string betID, uint256 nonce, bytes signature, address[] winners) returns()
+func (_Book *BookSession) ReconcileBet(betID string, nonce *big.Int, signature []byte, winners []common.Address) (*types.Transaction, error) {
+	return _Book.Contract.ReconcileBet(&_Book.TransactOpts, betID, nonce, signature, winners)
+}
+
+// ReconcileBet is a paid mutator transaction binding the contract method 0x0e302132.
+//
+// Solidity: function ReconcileBet(string betID, uint256 nonce, bytes signature, address[] winners) returns()
+func (_Book *BookTransactorSession) ReconcileBet(betID string, nonce *big.Int, signature []byte, winners []common.Address) (*types.Transaction, error) {
+	return _Book.Contract.ReconcileBet(&_Book.TransactOpts, betID, nonce, signature, winners)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() returns()
+func (_Book *BookTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Book.contract.Transact(opts, "Withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() returns()
+func (_Book *BookSession) Withdraw() (*types.Transaction, error) {
+	return _Book.Contract.Withdraw(&_Book.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x57ea89b6.
+//
+// Solidity: function Withdraw() returns()
+func (_Book *BookTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _Book.Contract.Withdraw(&_Book.TransactOpts)
+}
+
+// BookEventLogIterator is returned from FilterEventLog and is used to iterate over the raw logs and unpacked data for EventLog events raised by the Book contract.
+type BookEventLogIterator struct {
+	Event *BookEventLog // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BookEventLogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BookEventLog)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BookEventLog)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BookEventLogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BookEventLogIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BookEventLog represents a EventLog event raised by the Book contract.
+type BookEventLog struct {
+	Value string
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterEventLog is a free log retrieval operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Book *BookFilterer) FilterEventLog(opts *bind.FilterOpts) (*BookEventLogIterator, error) {
+
+	logs, sub, err := _Book.contract.FilterLogs(opts, "EventLog")
+	if err != nil {
+		return nil, err
+	}
+	return &BookEventLogIterator{contract: _Book.contract, event: "EventLog", logs: logs, sub: sub}, nil
+}
+
+// WatchEventLog is a free log subscription operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Book *BookFilterer) WatchEventLog(opts *bind.WatchOpts, sink chan<- *BookEventLog) (event.Subscription, error) {
+
+	logs, sub, err := _Book.contract.WatchLogs(opts, "EventLog")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BookEventLog)
+				if err := _Book.contract.UnpackLog(event, "EventLog", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEventLog is a log parse operation binding the contract event 0xd3c51ea1865a5f43e30629abcc5e5f1f5a8a28d7cd45aface7cb4bb5c4a1a18a.
+//
+// Solidity: event EventLog(string value)
+func (_Book *BookFilterer) ParseEventLog(log types.Log) (*BookEventLog, error) {
+	event := new(BookEventLog)
+	if err := _Book.contract.UnpackLog(event, "EventLog", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,526 @@
+package book_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+const (
+	ownerAcc = iota
+	moderatorAcc
+	player1Acc
+	player2Acc
+	numAccounts
+)
+
+const gasLimit = 5_000_000
+
+func TestBook(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(numAccounts, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	clients := make([]*ethereum.Client, numAccounts)
+	for i := range clients {
+		if clients[i], err = ethereum.NewClient(backend, backend.PrivateKeys[i]); err != nil {
+			t.Fatalf("unable to create client %d: %s", i, err)
+		}
+	}
+	owner := clients[ownerAcc]
+	moderator := clients[moderatorAcc]
+	players := []*ethereum.Client{clients[player1Acc], clients[player2Acc]}
+	participants := []common.Address{players[0].Address(), players[1].Address()}
+
+	callOpts, err := owner.NewCallOpts(ctx)
+	if err != nil {
+		t.Fatalf("unable to create call opts: %s", err)
+	}
+
+	txOpts := func(t *testing.T, client *ethereum.Client) *bind.TransactOpts {
+		t.Helper()
+
+		opts, err := client.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		return opts
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	address, tx, contract, err := book.DeployBook(txOpts(t, owner), owner.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy book: %s", err)
+	}
+
+	if _, err := owner.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	// bindBook returns the contract bound to the client's backend, so
+	// transactions are sent from the client's account.
+	bindBook := func(t *testing.T, client *ethereum.Client) *book.Book {
+		t.Helper()
+
+		contract, err := book.NewBook(address, client.Backend)
+		if err != nil {
+			t.Fatalf("unable to bind book: %s", err)
+		}
+
+		return contract
+	}
+
+	// mined waits for the transaction and returns the error it failed with.
+	mined := func(t *testing.T, client *ethereum.Client, tx *types.Transaction, err error) error {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		_, err = client.WaitMined(ctx, tx)
+		return err
+	}
+
+	// expectRevert checks the transaction reverted with the reason.
+	expectRevert := func(t *testing.T, err error, reason string) {
+		t.Helper()
+
+		var revertErr *ethereum.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("should revert with %q, got %v", reason, err)
+		}
+
+		if !strings.Contains(revertErr.Reason, reason) {
+			t.Fatalf("wrong revert reason, got %q  exp %q", revertErr.Reason, reason)
+		}
+	}
+
+	// sign signs the bet for the account with the nonce the contract
+	// expects.
+	sign := func(t *testing.T, signer ethereum.Signer, betID string, account common.Address) (*big.Int, []byte) {
+		t.Helper()
+
+		nonce, err := contract.Nonce(callOpts, account)
+		if err != nil {
+			t.Fatalf("unable to retrieve nonce: %s", err)
+		}
+
+		sig, err := ethereum.SignWith(ctx, signer, betID, account, nonce)
+		if err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		return nonce, sig
+	}
+
+	// placeBet places the bet with signatures from both players, expiring
+	// at the specified unix time.
+	placeBet := func(t *testing.T, betID string, expiration uint64) error {
+		t.Helper()
+
+		var nonces []*big.Int
+		var sigs [][]byte
+		for _, player := range players {
+			nonce, sig := sign(t, player.Signer(), betID, player.Address())
+			nonces = append(nonces, nonce)
+			sigs = append(sigs, sig)
+		}
+
+		// Bets are placed for nothing so the state transitions can be tested
+		// without funding the participants. The funds subtest covers balances.
+		tx, err := contract.PlaceBet(txOpts(t, owner), betID, big.NewInt(0), big.NewInt(0), new(big.Int).SetUint64(expiration), moderator.Address(), participants, nonces, sigs)
+		return mined(t, owner, tx, err)
+	}
+
+	// expectState checks the bet is in the specified state.
+	expectState := func(t *testing.T, betID string, state uint8) {
+		t.Helper()
+
+		details, err := contract.BetDetails(callOpts, betID)
+		if err != nil {
+			t.Fatalf("unable to retrieve bet details: %s", err)
+		}
+
+		if details.State != state {
+			t.Fatalf("wrong state for bet %s, got %s  exp %s", betID, book.StateName(details.State), book.StateName(state))
+		}
+	}
+
+	// expectNonce checks the account's nonce in the contract.
+	expectNonce := func(t *testing.T, account common.Address, exp int64) {
+		t.Helper()
+
+		nonce, err := contract.Nonce(callOpts, account)
+		if err != nil {
+			t.Fatalf("unable to retrieve nonce: %s", err)
+		}
+
+		if nonce.Int64() != exp {
+			t.Fatalf("wrong nonce for %s, got %d  exp %d", account, nonce, exp)
+		}
+	}
+
+	header, err := owner.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatalf("unable to retrieve header: %s", err)
+	}
+
+	// Bets that expire now can be reconciled in the next block, bets that
+	// expire in an hour can't be reconciled during the test.
+	expired := header.Time
+	future := header.Time + 3600
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("owner", func(t *testing.T) {
+		got, err := contract.Owner(callOpts)
+		if err != nil {
+			t.Fatalf("unable to retrieve owner: %s", err)
+		}
+
+		if got != owner.Address() {
+			t.Fatalf("wrong owner, got %s  exp %s", got, owner.Address())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("place bet", func(t *testing.T) {
+		if err := placeBet(t, "reconcile", expired); err != nil {
+			t.Fatalf("unable to place bet: %s", err)
+		}
+
+		details, err := contract.BetDetails(callOpts, "reconcile")
+		if err != nil {
+			t.Fatalf("unable to retrieve bet details: %s", err)
+		}
+
+		if details.State != book.StateLive || details.Moderator != moderator.Address() || details.Expiration.Uint64() != expired {
+			t.Fatalf("wrong bet details, got %+v", details)
+		}
+
+		if len(details.Participants) != 2 || details.Participants[0] != participants[0] || details.Participants[1] != participants[1] {
+			t.Fatalf("wrong participants, got %v  exp %v", details.Participants, participants)
+		}
+
+		for _, participant := range participants {
+			expectNonce(t, participant, 1)
+		}
+
+		if _, err := contract.BetDetails(callOpts, "unknown"); err == nil || !strings.Contains(err.Error(), "bet id does not exist") {
+			t.Fatalf("should fail for an unknown bet, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("place bet errors", func(t *testing.T) {
+		expectRevert(t, placeBet(t, "reconcile", expired), "bet id already exists")
+
+		// Player 1 signs for player 2.
+		nonce1, sig1 := sign(t, players[0].Signer(), "bad", participants[0])
+		nonce2, sig2 := sign(t, players[0].Signer(), "bad", participants[1])
+
+		tx, err := contract.PlaceBet(txOpts(t, owner), "bad", big.NewInt(0), big.NewInt(0), big.NewInt(0), moderator.Address(), participants, []*big.Int{nonce1, nonce2}, [][]byte{sig1, sig2})
+		expectRevert(t, mined(t, owner, tx, err), "address doesn't match signature")
+
+		stale := new(big.Int).Sub(nonce2, big.NewInt(1))
+		_, sig2 = sign(t, players[1].Signer(), "bad", participants[1])
+
+		tx, err = contract.PlaceBet(txOpts(t, owner), "bad", big.NewInt(0), big.NewInt(0), big.NewInt(0), moderator.Address(), participants, []*big.Int{nonce1, stale}, [][]byte{sig1, sig2})
+		expectRevert(t, mined(t, owner, tx, err), "has an invalid nonce")
+
+		// Only the owner can place bets.
+		tx, err = bindBook(t, players[0]).PlaceBet(txOpts(t, players[0]), "bad", big.NewInt(0), big.NewInt(0), big.NewInt(0), moderator.Address(), participants, []*big.Int{nonce1, nonce2}, [][]byte{sig1, sig2})
+		if err := mined(t, players[0], tx, err); err == nil {
+			t.Fatal("should fail when not called by the owner")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("live to reconciled", func(t *testing.T) {
+		if err := placeBet(t, "not expired", future); err != nil {
+			t.Fatalf("unable to place bet: %s", err)
+		}
+
+		reconcile := func(t *testing.T, betID string, signer ethereum.Signer, winners []common.Address) error {
+			nonce, sig := sign(t, signer, betID, moderator.Address())
+
+			tx, err := contract.ReconcileBet(txOpts(t, owner), betID, nonce, sig, winners)
+			return mined(t, owner, tx, err)
+		}
+
+		winners := participants[:1]
+
+		expectRevert(t, reconcile(t, "not expired", moderator.Signer(), winners), "bet has not yet expired")
+		expectRevert(t, reconcile(t, "reconcile", players[0].Signer(), winners), "invalid moderator signature")
+		expectRevert(t, reconcile(t, "reconcile", moderator.Signer(), []common.Address{moderator.Address()}), "winner address is not a participant")
+
+		if err := reconcile(t, "reconcile", moderator.Signer(), winners); err != nil {
+			t.Fatalf("unable to reconcile bet: %s", err)
+		}
+
+		expectState(t, "reconcile", book.StateReconciled)
+		expectNonce(t, moderator.Address(), 1)
+
+		expectRevert(t, reconcile(t, "reconcile", moderator.Signer(), winners), "bet is not live")
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("live to cancelled by moderator", func(t *testing.T) {
+		if err := placeBet(t, "moderator", future); err != nil {
+			t.Fatalf("unable to place bet: %s", err)
+		}
+
+		nonce, sig := sign(t, players[0].Signer(), "moderator", moderator.Address())
+		tx, err := contract.CancelBetModerator(txOpts(t, owner), "moderator", big.NewInt(0), nonce, sig)
+		expectRevert(t, mined(t, owner, tx, err), "invalid moderator signature")
+
+		nonce, sig = sign(t, moderator.Signer(), "moderator", moderator.Address())
+		tx, err = contract.CancelBetModerator(txOpts(t, owner), "moderator", big.NewInt(0), nonce, sig)
+		if err := mined(t, owner, tx, err); err != nil {
+			t.Fatalf("unable to cancel bet: %s", err)
+		}
+
+		expectState(t, "moderator", book.StateCancelled)
+		expectNonce(t, moderator.Address(), nonce.Int64()+1)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("live to cancelled by participants", func(t *testing.T) {
+		if err := placeBet(t, "participants", future); err != nil {
+			t.Fatalf("unable to place bet: %s", err)
+		}
+
+		var nonces []*big.Int
+		var sigs [][]byte
+		for _, player := range players {
+			nonce, sig := sign(t, player.Signer(), "participants", player.Address())
+			nonces = append(nonces, nonce)
+			sigs = append(sigs, sig)
+		}
+
+		tx, err := contract.CancelBetParticipants(txOpts(t, owner), "participants", big.NewInt(0), nonces, sigs[:1])
+		expectRevert(t, mined(t, owner, tx, err), "invalid number of signatures or nonces")
+
+		tx, err = contract.CancelBetParticipants(txOpts(t, owner), "participants", big.NewInt(0), nonces, sigs)
+		if err := mined(t, owner, tx, err); err != nil {
+			t.Fatalf("unable to cancel bet: %s", err)
+		}
+
+		expectState(t, "participants", book.StateCancelled)
+		for i, participant := range participants {
+			expectNonce(t, participant, nonces[i].Int64()+1)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("live to cancelled by owner", func(t *testing.T) {
+		tx, err := bindBook(t, players[0]).CancelBetOwner(txOpts(t, players[0]), "not expired", big.NewInt(0))
+		if err := mined(t, players[0], tx, err); err == nil {
+			t.Fatal("should fail when not called by the owner")
+		}
+
+		tx, err = contract.CancelBetOwner(txOpts(t, owner), "not expired", big.NewInt(0))
+		if err := mined(t, owner, tx, err); err != nil {
+			t.Fatalf("unable to cancel bet: %s", err)
+		}
+
+		expectState(t, "not expired", book.StateCancelled)
+
+		tx, err = contract.CancelBetOwner(txOpts(t, owner), "not expired", big.NewInt(0))
+		expectRevert(t, mined(t, owner, tx, err), "bet is not live")
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("funds from deposit to withdraw", func(t *testing.T) {
+		gwei := func(v float64) *big.Int {
+			return currency.GWei2Wei(big.NewFloat(v))
+		}
+
+		depositWei := gwei(1_000_000_000)
+		amountWei := gwei(300_000_000)
+		feeWei := gwei(10_000_000)
+
+		// expectBalance checks the account's balance in the book, both as
+		// the owner sees it and as the account sees it.
+		expectBalance := func(t *testing.T, client *ethereum.Client, exp *big.Int) {
+			t.Helper()
+
+			got, err := contract.AccountBalance(callOpts, client.Address())
+			if err != nil {
+				t.Fatalf("unable to retrieve account balance: %s", err)
+			}
+
+			if got.Cmp(exp) != 0 {
+				t.Fatalf("wrong account balance for %s, got %v  exp %v", client.Address(), got, exp)
+			}
+
+			got, err = bindBook(t, client).Balance(&bind.CallOpts{Context: ctx, From: client.Address()})
+			if err != nil {
+				t.Fatalf("unable to retrieve balance: %s", err)
+			}
+
+			if got.Cmp(exp) != 0 {
+				t.Fatalf("wrong balance for %s, got %v  exp %v", client.Address(), got, exp)
+			}
+		}
+
+		ownerStart, err := contract.AccountBalance(callOpts, owner.Address())
+		if err != nil {
+			t.Fatalf("unable to retrieve owner balance: %s", err)
+		}
+
+		for _, player := range players {
+			opts, err := player.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(1_000_000_000))
+			if err != nil {
+				t.Fatalf("unable to create transaction opts: %s", err)
+			}
+
+			tx, err := bindBook(t, player).Deposit(opts)
+			if err := mined(t, player, tx, err); err != nil {
+				t.Fatalf("unable to deposit: %s", err)
+			}
+
+			expectBalance(t, player, depositWei)
+		}
+
+		// place places the bet with the specified amount for both players.
+		place := func(t *testing.T, betID string, amount *big.Int) error {
+			var nonces []*big.Int
+			var sigs [][]byte
+			for _, player := range players {
+				nonce, sig := sign(t, player.Signer(), betID, player.Address())
+				nonces = append(nonces, nonce)
+				sigs = append(sigs, sig)
+			}
+
+			tx, err := contract.PlaceBet(txOpts(t, owner), betID, amount, feeWei, new(big.Int).SetUint64(expired), moderator.Address(), participants, nonces, sigs)
+			return mined(t, owner, tx, err)
+		}
+
+		expectRevert(t, place(t, "too much", depositWei), "has an insufficient balance")
+
+		if err := place(t, "funds", amountWei); err != nil {
+			t.Fatalf("unable to place bet: %s", err)
+		}
+
+		// Each player pays the bet amount and the fee.
+		remaining := new(big.Int).Sub(depositWei, new(big.Int).Add(amountWei, feeWei))
+		for _, player := range players {
+			expectBalance(t, player, remaining)
+		}
+
+		nonce, sig := sign(t, moderator.Signer(), "funds", moderator.Address())
+		tx, err := contract.ReconcileBet(txOpts(t, owner), "funds", nonce, sig, participants[:1])
+		if err := mined(t, owner, tx, err); err != nil {
+			t.Fatalf("unable to reconcile bet: %s", err)
+		}
+
+		// The winner takes the bet amount of both players.
+		won := new(big.Int).Add(remaining, new(big.Int).Mul(amountWei, big.NewInt(2)))
+		expectBalance(t, players[0], won)
+		expectBalance(t, players[1], remaining)
+
+		ownerFees := new(big.Int).Add(ownerStart, new(big.Int).Mul(feeWei, big.NewInt(2)))
+		expectBalance(t, owner, ownerFees)
+
+		// withdraw withdraws the client's balance and checks it arrived in
+		// the client's account.
+		withdraw := func(t *testing.T, client *ethereum.Client, exp *big.Int) {
+			t.Helper()
+
+			before, err := client.Balance(ctx)
+			if err != nil {
+				t.Fatalf("unable to retrieve balance: %s", err)
+			}
+
+			tx, err := bindBook(t, client).Withdraw(txOpts(t, client))
+			if err != nil {
+				t.Fatalf("unable to withdraw: %s", err)
+			}
+
+			receipt, err := client.WaitMined(ctx, tx)
+			if err != nil {
+				t.Fatalf("unable to withdraw: %s", err)
+			}
+
+			after, err := client.Balance(ctx)
+			if err != nil {
+				t.Fatalf("unable to retrieve balance: %s", err)
+			}
+
+			gasCost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tx.GasPrice())
+			received := new(big.Int).Add(new(big.Int).Sub(after, before), gasCost)
+			if received.Cmp(exp) != 0 {
+				t.Fatalf("wrong amount withdrawn for %s, got %v  exp %v", client.Address(), received, exp)
+			}
+
+			expectBalance(t, client, big.NewInt(0))
+		}
+
+		// Withdraw doesn't accept ether, it has to be deposited.
+		opts := txOpts(t, players[0])
+		opts.Value = big.NewInt(1)
+
+		tx, err = bindBook(t, players[0]).Withdraw(opts)
+		if err := mined(t, players[0], tx, err); err == nil || !strings.Contains(err.Error(), "execution reverted") {
+			t.Fatalf("withdraw with value should revert, got %v", err)
+		}
+
+		// The owner drains only the fees, the players can still withdraw.
+		before, err := owner.Balance(ctx)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		tx, err = contract.Drain(txOpts(t, owner))
+		if err != nil {
+			t.Fatalf("unable to drain: %s", err)
+		}
+
+		receipt, err := owner.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("unable to drain: %s", err)
+		}
+
+		after, err := owner.Balance(ctx)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		gasCost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tx.GasPrice())
+		if drained := new(big.Int).Add(new(big.Int).Sub(after, before), gasCost); drained.Cmp(ownerFees) != 0 {
+			t.Fatalf("wrong amount drained, got %v  exp %v", drained, ownerFees)
+		}
+		expectBalance(t, owner, big.NewInt(0))
+
+		withdraw(t, players[0], won)
+		withdraw(t, players[1], remaining)
+
+		tx, err = bindBook(t, players[0]).Withdraw(txOpts(t, players[0]))
+		expectRevert(t, mined(t, players[0], tx, err), "not enough balance")
+	})
+}
@@ -0,0 +1,2 @@
+// Package book is generated code for accessing the book smart contract.
+package book
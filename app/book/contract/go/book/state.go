@@ -0,0 +1,29 @@
+package book
+
+// Set of states a bet can be in, matching the STATE constants of the contract.
+const (
+	StateNotExists  uint8 = 0
+	StateLive       uint8 = 1
+	StateReconciled uint8 = 2
+	StateCancelled  uint8 = 3
+)
+
+// ReasonBetNotFound is the reason the contract reverts with when BetDetails is
+// called for a bet that doesn't exist.
+const ReasonBetNotFound = "bet id does not exist"
+
+// StateName returns a readable name for the bet state.
+func StateName(state uint8) string {
+	switch state {
+	case StateNotExists:
+		return "not exists"
+	case StateLive:
+		return "live"
+	case StateReconciled:
+		return "reconciled"
+	case StateCancelled:
+		return "cancelled"
+	}
+
+	return "unknown"
+}
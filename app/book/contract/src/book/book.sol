@@ -65,13 +65,18 @@ contract Book {
         _;
     }
 
-    // Drain the full value of the smart contract to the contract owner.
-    function Drain() onlyOwner payable public {
+    // Drain the owner's account balance to the contract owner. The rest of the
+    // contract's value belongs to the accounts that deposited it.
+    function Drain() onlyOwner public {
         address payable account = payable(msg.sender);
-        uint256 bal = address(this).balance;
 
+        // The balance is cleared before the transfer so it can't be
+        // drained twice by a reentrant call.
+        uint256 bal = accounts[Owner].Balance;
+        accounts[Owner].Balance = 0;
         account.transfer(bal);
-        emit EvenLog(string.concat("drain[", Error.Addrtoa(account), "] amount[", Error.Itoa(bal), "]"));
+
+        emit EventLog(string.concat("drain[", Error.Addrtoa(account), "] amount[", Error.Itoa(bal), "]"));
     }
 
     // AccountBalance returns the specified account's balance and amount bet.
@@ -186,7 +191,7 @@ contract Book {
 
         // Ensure the bet is live.
         if (bet.Info.State != STATE_LIVE) {
-            REVERT("bet is not live");
+            revert("bet is not live");
         }
 
         // Ensure the bet has passed its expiration.
@@ -222,7 +227,7 @@ contract Book {
         }
 
         // Calculate the total winnings for each winner.
-        uint256 totalWinnings   = bet.Info.AmountBetWei * 2; /////////////////////////////
+        uint256 totalWinnings   = bet.Info.AmountBetWei * bet.Info.Participants.length;
         uint256 amountPerWinner = totalWinnings / winners.length;
 
         // Give each of the winners the amount listed in the bet.
@@ -252,7 +257,7 @@ contract Book {
 
         // Ensure the bet is live.
         if (bet.Info.State != STATE_LIVE) {
-            REVERT("bet is not live");
+            revert("bet is not live");
         }
 
         // Ensure the none used by the moderator is the expected nonce.
@@ -296,15 +301,15 @@ contract Book {
     function CancelBetParticipants(
         string  memory    betID,
         uint256           amountFeeWei,
-        uint              nonce,
-        bytes   calldata  signatures
+        uint[]  memory    nonces,
+        bytes[] calldata  signatures
     ) onlyOwner public {
         // Capture the bet information.
         Bet storage bet = bets[betID];
 
         // Ensure the bet is live.
         if (bet.Info.State != STATE_LIVE) {
-            REVERT("bet is not live");
+            revert("bet is not live");
         }
 
         // Ensure we have the proper amount of signatures and nonces.
@@ -319,8 +324,8 @@ contract Book {
             bytes calldata  signature   = signatures[i];
 
             // Ensure the nonce used by the participant is the expected nonce.
-            if (accounts[participant].Nonce != none) {
-                revert(string.concat(Error.Addrtoa(participant), "] has an invalid nonce"));
+            if (accounts[participant].Nonce != nonce) {
+                revert(string.concat(Error.Addrtoa(participant), " has an invalid nonce"));
             }
 
             // Reconstruct the data that was signed by the participant.
@@ -359,13 +364,13 @@ contract Book {
     function CancelBetOwner(
         string  memory    betID,
         uint256           amountFeeWei
-    ) onlyOnwer public {
+    ) onlyOwner public {
         // Capture the bet information.
         Bet storage bet = bets[betID];
 
         // Ensure the bet is live.
         if (bet.Info.State != STATE_LIVE) {
-            REVERT("bet is not live");
+            revert("bet is not live");
         }
 
         // Return the money back to the participants minus the fee.
@@ -382,6 +387,39 @@ contract Book {
         emit EventLog(string.concat(betID, " has been cancelled by owner"));
     }
 
+    // /////////////////////////////////////////////////////////////
+    // Account Only Calls
+
+    // Balance returns the balance of the caller.
+    function Balance() view public returns (uint) {
+        return accounts[msg.sender].Balance;
+    }
+
+    // Deposit the given amount to the caller's account balance.
+    function Deposit() payable public {
+        accounts[msg.sender].Exists = true;
+        accounts[msg.sender].Balance += msg.value;
+
+        emit EventLog(string.concat("deposit[", Error.Addrtoa(msg.sender), "] balance[", Error.Itoa(accounts[msg.sender].Balance), "]"));
+    }
+
+    // Withdraw the caller's full account balance.
+    function Withdraw() public {
+        address payable account = payable(msg.sender);
+
+        if (accounts[msg.sender].Balance == 0) {
+            revert("not enough balance");
+        }
+
+        // The balance is cleared before the transfer so it can't be
+        // withdrawn twice by a reentrant call.
+        uint256 amount = accounts[msg.sender].Balance;
+        accounts[msg.sender].Balance = 0;
+        account.transfer(amount);
+
+        emit EventLog(string.concat("withdraw[", Error.Addrtoa(msg.sender), "] amount[", Error.Itoa(amount), "]"));
+    }
+
     // /////////////////////////////////////////////////////////////
     // Private Functions
 
@@ -397,7 +435,7 @@ contract Book {
 
         bytes32 r = bytes32(sig[:32]);
         bytes32 s = bytes32(sig[32:64]);
-        uint8   v = uint8(sig[644]);
+        uint8   v = uint8(sig[64]);
 
         return (ecrecover(saltedData, v, r, s), Error.None());
     }
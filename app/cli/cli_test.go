@@ -0,0 +1,164 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/adamwoolhether/smartcontract/app/basic/contract/go/basic"
+	"github.com/adamwoolhether/smartcontract/app/cli"
+	"github.com/adamwoolhether/smartcontract/app/config"
+)
+
+func TestOutput(t *testing.T) {
+	if _, err := cli.NewOutput(&bytes.Buffer{}, "yaml", "basic get"); err == nil {
+		t.Fatal("should fail for an unknown format")
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("text", func(t *testing.T) {
+		var b bytes.Buffer
+		out, err := cli.NewOutput(&b, cli.OutputText, "basic get")
+		if err != nil {
+			t.Fatalf("unable to create output: %s", err)
+		}
+
+		out.Section("Read Value")
+		out.Value("value", 42)
+
+		if err := out.Flush(errors.New("failed")); err != nil {
+			t.Fatalf("unable to flush: %s", err)
+		}
+
+		if !strings.Contains(b.String(), "Read Value\n") || !strings.Contains(b.String(), "value: 42\n") {
+			t.Fatalf("wrong text output:\n%s", b.String())
+		}
+
+		if strings.Contains(b.String(), "failed") {
+			t.Fatalf("text output should leave the error to the caller:\n%s", b.String())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("json", func(t *testing.T) {
+		var b bytes.Buffer
+		out, err := cli.NewOutput(&b, cli.OutputJSON, "basic get")
+		if err != nil {
+			t.Fatalf("unable to create output: %s", err)
+		}
+
+		out.Section("Read Value")
+		out.Value("value", 42)
+
+		if b.Len() != 0 {
+			t.Fatalf("json output should only be written on flush:\n%s", b.String())
+		}
+
+		if err := out.Flush(errors.New("failed")); err != nil {
+			t.Fatalf("unable to flush: %s", err)
+		}
+
+		var result cli.Result
+		if err := json.Unmarshal(b.Bytes(), &result); err != nil {
+			t.Fatalf("unable to decode result: %s\n%s", err, b.String())
+		}
+
+		if result.Command != "basic get" || result.Values["value"] != float64(42) || result.Error != "failed" {
+			t.Fatalf("wrong result: %+v", result)
+		}
+	})
+}
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("SMART_NETWORK", "")
+	t.Setenv("KEYSTORE_PASSPHRASE_FILE", "../../zarf/ethereum/password")
+
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), []string{
+		"-network", "simulated",
+		"-keystore", "../../zarf/ethereum/keystore",
+		"-aliases", "../../zarf/ethereum/accounts.json",
+		"-contracts", t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("unable to load config: %s", err)
+	}
+
+	var b bytes.Buffer
+	out, err := cli.NewOutput(&b, cli.OutputJSON, "deploy basic")
+	if err != nil {
+		t.Fatalf("unable to create output: %s", err)
+	}
+
+	s, err := cli.NewSession(ctx, cfg, out)
+	if err != nil {
+		t.Fatalf("unable to create session: %s", err)
+	}
+
+	clt, err := s.Client("owner")
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	s.UseABI(basic.BasicMetaData.ABI)
+
+	tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, 0)
+	if err != nil {
+		t.Fatalf("unable to create transaction opts: %s", err)
+	}
+
+	_, tx, _, err := basic.DeployBasic(tranOpts, clt.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy: %s", err)
+	}
+
+	receipt, err := s.Wait(ctx, clt, tx)
+	if err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	if err := s.Record(ctx, "basic", basic.BasicMetaData, tx, receipt); err != nil {
+		t.Fatalf("unable to record deployment: %s", err)
+	}
+
+	address, err := s.Contract(ctx, "basic", basic.BasicMetaData)
+	if err != nil {
+		t.Fatalf("unable to look up deployment: %s", err)
+	}
+
+	if address != receipt.ContractAddress {
+		t.Fatalf("wrong contract address, got %s  exp %s", address, receipt.ContractAddress)
+	}
+
+	if err := s.Close(ctx); err != nil {
+		t.Fatalf("unable to close session: %s", err)
+	}
+
+	if err := out.Flush(nil); err != nil {
+		t.Fatalf("unable to flush: %s", err)
+	}
+
+	var result cli.Result
+	if err := json.Unmarshal(b.Bytes(), &result); err != nil {
+		t.Fatalf("unable to decode result: %s\n%s", err, b.String())
+	}
+
+	if len(result.Transactions) != 1 {
+		t.Fatalf("wrong number of transactions, got %d  exp 1", len(result.Transactions))
+	}
+
+	got := result.Transactions[0]
+	if got.Details.Hash != tx.Hash().Hex() || got.Receipt == nil || got.Receipt.GasUsed != receipt.GasUsed {
+		t.Fatalf("wrong transaction: %+v", got)
+	}
+
+	if result.Balance == nil || result.Balance.DiffGWei == "0" {
+		t.Fatalf("should report the cost of the deploy, got %+v", result.Balance)
+	}
+}
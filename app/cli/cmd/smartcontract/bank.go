@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/cli"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// bankProxyUpgrade compiles a BankAPI implementation and refuses it when its
+// storage layout differs from the bank proxy's, since the proxy delegate
+// calls the implementation against its own storage. A compatible
+// implementation is deployed and the proxy pointed at it.
+func bankProxyUpgrade(fs *flag.FlagSet) runFunc {
+	source := fs.String("source", "", "source of the BankAPI, such as app/bank/proxy/contract/src/bankapi/v3/api.sol")
+	contract := fs.String("contract", "BankAPI", "contract to deploy from the source")
+	proxySource := fs.String("proxy-source", "app/bank/proxy/contract/src/bank/bank.sol", "source of the bank proxy")
+	proxyContract := fs.String("proxy-contract", "bank", "proxy contract in the proxy source")
+	solc := fs.String("solc", defaultSolc(), "solc command to compile with (env SOLC)")
+	check := fs.Bool("check", false, "only report the storage layouts")
+
+	return func(ctx context.Context, s *cli.Session) error {
+		if *source == "" {
+			return errors.New("source is required")
+		}
+
+		s.Out.Section("Input Values")
+		s.Out.Value("source", *source)
+		s.Out.Value("proxySource", *proxySource)
+
+		solcCmd := strings.Fields(*solc)
+
+		proxy, err := ethereum.Compile(ctx, solcCmd, *proxySource, *proxyContract)
+		if err != nil {
+			return err
+		}
+
+		impl, err := ethereum.Compile(ctx, solcCmd, *source, *contract)
+		if err != nil {
+			return err
+		}
+
+		s.Out.Section("Storage Layout")
+		reportLayout(s.Out, proxy.Layout, impl.Layout)
+
+		if err := ethereum.CheckStorageLayout(proxy.Layout, impl.Layout); err != nil {
+			return fmt.Errorf("refusing to upgrade to %s: %w", *source, err)
+		}
+		s.Out.Value("compatible", true)
+
+		if *check {
+			return nil
+		}
+
+		// =========================================================================
+
+		clt, err := s.Client("owner")
+		if err != nil {
+			return err
+		}
+		s.Out.Value("fromAddress", clt.Address())
+		s.UseABI(bank.BankMetaData.ABI)
+
+		contractID, err := s.Contract(ctx, "bank", bank.BankMetaData)
+		if err != nil {
+			return err
+		}
+
+		bankContract, err := bank.NewBank(contractID, clt.Backend)
+		if err != nil {
+			return fmt.Errorf("new proxy connection: %w", err)
+		}
+
+		// =========================================================================
+
+		implABI, err := abi.JSON(strings.NewReader(impl.ABI))
+		if err != nil {
+			return fmt.Errorf("parsing %s abi: %w", impl.Name, err)
+		}
+
+		tranOpts, err := s.TransactOpts(ctx, clt, 2_500_000, 0)
+		if err != nil {
+			return err
+		}
+
+		address, tx, implContract, err := bind.DeployContract(tranOpts, implABI, common.FromHex(impl.Bin), clt.Backend)
+		if err != nil {
+			return err
+		}
+
+		receipt, err := s.Wait(ctx, clt, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Record(ctx, "bankapi", impl.MetaData(), tx, receipt); err != nil {
+			return err
+		}
+
+		callOpts, err := clt.NewCallOpts(ctx)
+		if err != nil {
+			return err
+		}
+
+		var out []any
+		if err := implContract.Call(callOpts, &out, "Version"); err != nil {
+			return fmt.Errorf("reading %s version: %w", impl.Name, err)
+		}
+		implVersion := *abi.ConvertType(out[0], new(string)).(*string)
+
+		s.Out.Section("Set This Contract To Bank")
+		s.Out.Value("bankID", contractID)
+		s.Out.Value("apiID", address)
+		s.Out.Value("apiVersion", implVersion)
+
+		// =========================================================================
+
+		tranOpts, err = s.TransactOpts(ctx, clt, 1_600_000, 0)
+		if err != nil {
+			return err
+		}
+
+		tx, err = bankContract.SetContract(tranOpts, address)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.Wait(ctx, clt, tx); err != nil {
+			return err
+		}
+
+		// =========================================================================
+
+		version, err := bankContract.Version(callOpts)
+		if err != nil {
+			return err
+		}
+
+		current, err := bankContract.API(callOpts)
+		if err != nil {
+			return err
+		}
+
+		s.Out.Section("Validate Version and API")
+		s.Out.Value("version", version)
+		s.Out.Value("api", current)
+
+		if current != address {
+			return fmt.Errorf("bank uses api %s, expected %s", current, address)
+		}
+
+		if version != implVersion {
+			return fmt.Errorf("bank read back version %q, expected %q", version, implVersion)
+		}
+
+		return nil
+	}
+}
+
+// reportLayout outputs each variable of the proxy next to the variable the
+// implementation stores in its place.
+func reportLayout(out *cli.Output, proxy ethereum.StorageLayout, impl ethereum.StorageLayout) {
+	describe := func(l ethereum.StorageLayout, i int) string {
+		if i >= len(l.Storage) {
+			return "-"
+		}
+
+		v := l.Storage[i]
+		typ := v.Type
+		if t, exists := l.Types[v.Type]; exists {
+			typ = t.Label
+		}
+
+		return fmt.Sprintf("%s %s (slot %s offset %d)", typ, v.Label, v.Slot, v.Offset)
+	}
+
+	n := len(proxy.Storage)
+	if len(impl.Storage) > n {
+		n = len(impl.Storage)
+	}
+
+	for i := 0; i < n; i++ {
+		out.Value(fmt.Sprintf("variable %d", i), describe(proxy, i)+" | "+describe(impl, i))
+	}
+}
+
+// defaultSolc returns the solc command set in the environment, or solc.
+func defaultSolc() string {
+	if solc := os.Getenv("SOLC"); solc != "" {
+		return solc
+	}
+
+	return "solc"
+}
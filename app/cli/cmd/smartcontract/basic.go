@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/adamwoolhether/smartcontract/app/basic/contract/go/basic"
+	"github.com/adamwoolhether/smartcontract/app/cli"
+)
+
+// basicSet stores the value under the key in the basic contract.
+func basicSet(fs *flag.FlagSet) runFunc {
+	key := fs.String("key", "adam", "key of the item")
+	value := fs.Int64("value", 1_000_000, "value of the item")
+	from := fs.String("from", "owner", "account paying for the transaction")
+
+	return func(ctx context.Context, s *cli.Session) error {
+		clt, err := s.Client(*from)
+		if err != nil {
+			return err
+		}
+
+		s.Out.Section("Input Values")
+		s.Out.Value("fromAddress", clt.Address())
+		s.Out.Value("key", *key)
+		s.Out.Value("value", *value)
+		s.UseABI(basic.BasicMetaData.ABI)
+
+		contractID, err := s.Contract(ctx, "basic", basic.BasicMetaData)
+		if err != nil {
+			return err
+		}
+
+		contract, err := basic.NewBasic(contractID, clt.Backend)
+		if err != nil {
+			return fmt.Errorf("new basic connection: %w", err)
+		}
+
+		// =========================================================================
+
+		tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, 0)
+		if err != nil {
+			return err
+		}
+
+		tx, err := contract.SetItem(tranOpts, *key, big.NewInt(*value))
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.Wait(ctx, clt, tx); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// basicGet reads the value under the key from the basic contract.
+func basicGet(fs *flag.FlagSet) runFunc {
+	key := fs.String("key", "adam", "key of the item")
+
+	return func(ctx context.Context, s *cli.Session) error {
+		s.Out.Section("Input Values")
+		s.Out.Value("key", *key)
+
+		contractID, err := s.Contract(ctx, "basic", basic.BasicMetaData)
+		if err != nil {
+			return err
+		}
+
+		contract, err := basic.NewBasic(contractID, s.Backend)
+		if err != nil {
+			return fmt.Errorf("new basic connection: %w", err)
+		}
+
+		callOpts := &bind.CallOpts{Context: ctx}
+
+		version, err := contract.Version(callOpts)
+		if err != nil {
+			return err
+		}
+
+		value, err := contract.Items(callOpts, *key)
+		if err != nil {
+			return err
+		}
+
+		s.Out.Section("Read Value")
+		s.Out.Value("version", version)
+		s.Out.Value("value", value)
+
+		return nil
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/app/cli"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+// openBook connects the owner to the book, returning the client and contract.
+func openBook(ctx context.Context, s *cli.Session, betID string) (*ethereum.Client, *book.Book, error) {
+	if betID == "" {
+		return nil, nil, errors.New("bet is required")
+	}
+
+	clt, err := s.Client("owner")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.Out.Section("Input Values")
+	s.Out.Value("fromAddress", clt.Address())
+	s.Out.Value("betID", betID)
+	s.UseABI(book.BookMetaData.ABI)
+
+	contractID, err := s.Contract(ctx, "book", book.BookMetaData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bookContract, err := book.NewBook(contractID, clt.Backend)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new book connection: %w", err)
+	}
+
+	return clt, bookContract, nil
+}
+
+// bookPlace places a bet signed by the participants as the owner.
+func bookPlace(fs *flag.FlagSet) runFunc {
+	betID := fs.String("bet", "", "id of the bet")
+	amount := fs.Float64("amount", 50_000, "amount each participant bets in GWei")
+	fee := fs.Float64("fee", 1_000, "fee each participant pays in GWei")
+	duration := fs.Duration("duration", time.Hour, "time until the bet expires")
+	participants := fs.String("participants", "account1,account2", "comma separated participant accounts")
+	moderator := fs.String("moderator", "account3", "moderator account")
+
+	return func(ctx context.Context, s *cli.Session) error {
+		clt, bookContract, err := openBook(ctx, s, *betID)
+		if err != nil {
+			return err
+		}
+
+		moderatorAccount, err := s.Accounts.Resolve(*moderator)
+		if err != nil {
+			return err
+		}
+		s.Out.Value("moderator", moderatorAccount.Address)
+
+		callOpts, err := clt.NewCallOpts(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Each participant signs the bet with the nonce the contract expects.
+		var addresses []common.Address
+		var nonces []*big.Int
+		var sigs [][]byte
+		for _, name := range split(*participants) {
+			address, nonce, sig, err := signBet(ctx, s, bookContract, callOpts, name, *betID)
+			if err != nil {
+				return err
+			}
+			s.Out.Value("participant "+name, address)
+
+			addresses = append(addresses, address)
+			nonces = append(nonces, nonce)
+			sigs = append(sigs, sig)
+		}
+
+		// =========================================================================
+
+		tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, 0)
+		if err != nil {
+			return err
+		}
+
+		amountWei := currency.GWei2Wei(big.NewFloat(*amount))
+		feeWei := currency.GWei2Wei(big.NewFloat(*fee))
+		expiration := big.NewInt(time.Now().Add(*duration).Unix())
+
+		tx, err := bookContract.PlaceBet(tranOpts, *betID, amountWei, feeWei, expiration, moderatorAccount.Address, addresses, nonces, sigs)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.Wait(ctx, clt, tx); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// bookReconcile reconciles a bet signed by the moderator as the owner.
+func bookReconcile(fs *flag.FlagSet) runFunc {
+	betID := fs.String("bet", "", "id of the bet")
+	winners := fs.String("winners", "account1", "comma separated winning participant accounts")
+	moderator := fs.String("moderator", "account3", "moderator account")
+
+	return func(ctx context.Context, s *cli.Session) error {
+		clt, bookContract, err := openBook(ctx, s, *betID)
+		if err != nil {
+			return err
+		}
+
+		var winnerAddresses []common.Address
+		for _, name := range split(*winners) {
+			account, err := s.Accounts.Resolve(name)
+			if err != nil {
+				return fmt.Errorf("invalid winner %q: %w", name, err)
+			}
+			winnerAddresses = append(winnerAddresses, account.Address)
+		}
+		s.Out.Value("winners", winnerAddresses)
+
+		callOpts, err := clt.NewCallOpts(ctx)
+		if err != nil {
+			return err
+		}
+
+		moderatorAddress, nonce, sig, err := signBet(ctx, s, bookContract, callOpts, *moderator, *betID)
+		if err != nil {
+			return err
+		}
+		s.Out.Value("moderator", moderatorAddress)
+
+		// =========================================================================
+
+		tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, 0)
+		if err != nil {
+			return err
+		}
+
+		tx, err := bookContract.ReconcileBet(tranOpts, *betID, nonce, sig, winnerAddresses)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.Wait(ctx, clt, tx); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// bookCancel cancels a bet as the owner, or as the owner with the signature
+// of the moderator or the signatures of all the participants.
+func bookCancel(fs *flag.FlagSet) runFunc {
+	betID := fs.String("bet", "", "id of the bet")
+	by := fs.String("by", "owner", "who cancels the bet, owner, moderator or participants")
+	fee := fs.Float64("fee", 0, "fee each participant pays in GWei")
+	participants := fs.String("participants", "account1,account2", "comma separated participant accounts")
+	moderator := fs.String("moderator", "account3", "moderator account")
+
+	return func(ctx context.Context, s *cli.Session) error {
+		switch *by {
+		case "owner", "moderator", "participants":
+		default:
+			return fmt.Errorf("invalid by %q, use owner, moderator or participants", *by)
+		}
+
+		clt, bookContract, err := openBook(ctx, s, *betID)
+		if err != nil {
+			return err
+		}
+		s.Out.Value("cancelBy", *by)
+
+		callOpts, err := clt.NewCallOpts(ctx)
+		if err != nil {
+			return err
+		}
+
+		feeWei := currency.GWei2Wei(big.NewFloat(*fee))
+
+		// =========================================================================
+
+		tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, 0)
+		if err != nil {
+			return err
+		}
+
+		var tx *types.Transaction
+		switch *by {
+		case "owner":
+			tx, err = bookContract.CancelBetOwner(tranOpts, *betID, feeWei)
+
+		case "moderator":
+			var nonce *big.Int
+			var sig []byte
+			if _, nonce, sig, err = signBet(ctx, s, bookContract, callOpts, *moderator, *betID); err != nil {
+				return err
+			}
+			tx, err = bookContract.CancelBetModerator(tranOpts, *betID, feeWei, nonce, sig)
+
+		case "participants":
+			var nonces []*big.Int
+			var sigs [][]byte
+			for _, name := range split(*participants) {
+				_, nonce, sig, err := signBet(ctx, s, bookContract, callOpts, name, *betID)
+				if err != nil {
+					return err
+				}
+				nonces = append(nonces, nonce)
+				sigs = append(sigs, sig)
+			}
+			tx, err = bookContract.CancelBetParticipants(tranOpts, *betID, feeWei, nonces, sigs)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.Wait(ctx, clt, tx); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// signBet signs the bet with the named account using the account's current
+// nonce in the contract.
+func signBet(ctx context.Context, s *cli.Session, bookContract *book.Book, callOpts *bind.CallOpts, name string, betID string) (common.Address, *big.Int, []byte, error) {
+	signer, err := s.Accounts.Signer(name)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	account := signer.Address()
+
+	nonce, err := bookContract.Nonce(callOpts, account)
+	if err != nil {
+		return common.Address{}, nil, nil, fmt.Errorf("retrieving nonce for %s: %w", account, err)
+	}
+
+	sig, err := ethereum.SignWith(ctx, signer, betID, account, nonce)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	return account, nonce, sig, nil
+}
+
+// split returns the names in the comma separated list.
+func split(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
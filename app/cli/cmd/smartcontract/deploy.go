@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	proxy "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	single "github.com/adamwoolhether/smartcontract/app/bank/single/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/basic/contract/go/basic"
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/app/cli"
+)
+
+// deployer deploys a contract.
+type deployer func(auth *bind.TransactOpts, backend bind.ContractBackend) (*types.Transaction, error)
+
+// deployable represents a contract the deploy commands can deploy. The
+// deployment is recorded in the registry under the name.
+type deployable struct {
+	name     string
+	meta     *bind.MetaData
+	gasLimit uint64
+	deploy   deployer
+}
+
+// deployables is the set of contracts that can be deployed by command name.
+var deployables = map[string]deployable{
+	"basic": {"basic", basic.BasicMetaData, 1_600_000, func(auth *bind.TransactOpts, backend bind.ContractBackend) (*types.Transaction, error) {
+		_, tx, _, err := basic.DeployBasic(auth, backend)
+		return tx, err
+	}},
+	"bank": {"bank", proxy.BankMetaData, 2_500_000, func(auth *bind.TransactOpts, backend bind.ContractBackend) (*types.Transaction, error) {
+		_, tx, _, err := proxy.DeployBank(auth, backend)
+		return tx, err
+	}},
+	"bank-single": {"bank_single", single.BankMetaData, 1_700_000, func(auth *bind.TransactOpts, backend bind.ContractBackend) (*types.Transaction, error) {
+		_, tx, _, err := single.DeployBank(auth, backend)
+		return tx, err
+	}},
+	"book": {"book", book.BookMetaData, 5_000_000, func(auth *bind.TransactOpts, backend bind.ContractBackend) (*types.Transaction, error) {
+		_, tx, _, err := book.DeployBook(auth, backend)
+		return tx, err
+	}},
+}
+
+// deploy deploys the named contract as the owner and records it in the
+// registry.
+func deploy(name string) setupFunc {
+	return func(fs *flag.FlagSet) runFunc {
+		return func(ctx context.Context, s *cli.Session) error {
+			d, exists := deployables[name]
+			if !exists {
+				return fmt.Errorf("unknown contract %q", name)
+			}
+
+			clt, err := s.Client("owner")
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Input Values")
+			s.Out.Value("fromAddress", clt.Address())
+			s.UseABI(d.meta.ABI)
+
+			// =========================================================================
+
+			tranOpts, err := s.TransactOpts(ctx, clt, d.gasLimit, 0)
+			if err != nil {
+				return err
+			}
+
+			tx, err := d.deploy(tranOpts, clt.Backend)
+			if err != nil {
+				return err
+			}
+
+			receipt, err := s.Wait(ctx, clt, tx)
+			if err != nil {
+				return err
+			}
+
+			// Record the deployment! We need this to make API calls.
+			return s.Record(ctx, d.name, d.meta, tx, receipt)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// This program is the command line tool for the smart contracts. Every
+// subcommand shares the config flags and writes its result as text or JSON.
+//
+//	smartcontract <group> <command> [flags]
+//	smartcontract deploy book -network simulated -output json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/adamwoolhether/smartcontract/app/cli"
+	"github.com/adamwoolhether/smartcontract/app/config"
+)
+
+// runFunc runs a subcommand in the session.
+type runFunc func(ctx context.Context, s *cli.Session) error
+
+// setupFunc registers the flags of a subcommand on the flag set and returns
+// the function running it, which is called once the flags are parsed.
+type setupFunc func(fs *flag.FlagSet) runFunc
+
+// command represents a subcommand of the tool.
+type command struct {
+	group string
+	name  string
+	usage string
+	setup setupFunc
+}
+
+// commands is the set of subcommands of the tool.
+var commands = []command{
+	{"basic", "set", "store a value in the basic contract", basicSet},
+	{"basic", "get", "read a value from the basic contract", basicGet},
+
+	{"bank", "deposit", "deposit into the bank from an account", vaultDeposit("bank")},
+	{"bank", "withdraw", "withdraw an account's balance from the bank", vaultWithdraw("bank")},
+	{"bank", "balance", "show an account's balance in the bank", vaultBalance("bank")},
+	{"bank", "accounts", "show the bank balance of every keystore account", vaultAccounts("bank")},
+	{"bank-proxy", "upgrade", "check the storage layout of a BankAPI source, deploy it and point the bank proxy at it", bankProxyUpgrade},
+
+	{"book", "deposit", "deposit into the book from an account", vaultDeposit("book")},
+	{"book", "withdraw", "withdraw an account's balance from the book", vaultWithdraw("book")},
+	{"book", "balance", "show an account's balance and nonce in the book", vaultBalance("book")},
+	{"book", "accounts", "show the book balance of every keystore account", vaultAccounts("book")},
+	{"book", "place", "place a bet signed by the participants", bookPlace},
+	{"book", "reconcile", "reconcile a bet signed by the moderator", bookReconcile},
+	{"book", "cancel", "cancel a bet as the owner, moderator or participants", bookCancel},
+
+	{"deploy", "basic", "deploy the basic contract", deploy("basic")},
+	{"deploy", "bank", "deploy the bank proxy contract", deploy("bank")},
+	{"deploy", "bank-single", "deploy the single bank contract", deploy("bank-single")},
+	{"deploy", "book", "deploy the book contract", deploy("book")},
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) (err error) {
+	ctx := context.Background()
+
+	if len(args) < 2 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage(os.Stdout)
+		return nil
+	}
+
+	cmd, err := find(args[0], args[1])
+	if err != nil {
+		usage(os.Stderr)
+		return err
+	}
+
+	// =========================================================================
+
+	fs := flag.NewFlagSet("smartcontract "+cmd.group+" "+cmd.name, flag.ContinueOnError)
+	exec := cmd.setup(fs)
+	format := fs.String("output", cli.OutputText, "output format, text or json")
+
+	cfg, err := config.Load(fs, args[2:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", fs.Args())
+	}
+
+	if cfg.PrintConfig {
+		fmt.Print(cfg)
+		return nil
+	}
+
+	out, err := cli.NewOutput(os.Stdout, *format, cmd.group+" "+cmd.name)
+	if err != nil {
+		return err
+	}
+
+	// =========================================================================
+
+	s, err := cli.NewSession(ctx, cfg, out)
+	if err != nil {
+		out.Flush(err)
+		return err
+	}
+
+	err = exec(ctx, s)
+	if cErr := s.Close(ctx); cErr != nil && err == nil {
+		err = cErr
+	}
+
+	if fErr := out.Flush(err); fErr != nil && err == nil {
+		err = fErr
+	}
+
+	return err
+}
+
+// find returns the subcommand in the group.
+func find(group string, name string) (command, error) {
+	for _, cmd := range commands {
+		if cmd.group == group && cmd.name == name {
+			return cmd, nil
+		}
+	}
+
+	return command{}, fmt.Errorf("unknown command %q", group+" "+name)
+}
+
+// usage writes the subcommands of the tool.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: smartcontract <group> <command> [flags]")
+	fmt.Fprintln(w, "\nEvery command accepts the config flags, -output text|json and -h for its flags.")
+	fmt.Fprintln(w, "\nCommands:")
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.group+" "+cmd.name, cmd.usage)
+	}
+	tw.Flush()
+}
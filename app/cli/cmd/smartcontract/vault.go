@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	proxy "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	single "github.com/adamwoolhether/smartcontract/app/bank/single/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/app/cli"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+// vault is the account API the bank and book contracts share. Accounts
+// deposit and withdraw their own money and the owner can read the balance of
+// any account.
+type vault interface {
+	Deposit(opts *bind.TransactOpts) (*types.Transaction, error)
+	Withdraw(opts *bind.TransactOpts) (*types.Transaction, error)
+	Balance(opts *bind.CallOpts) (*big.Int, error)
+	AccountBalance(opts *bind.CallOpts, account common.Address) (*big.Int, error)
+}
+
+// accountBalance represents the balance of a keystore account in a contract.
+type accountBalance struct {
+	Aliases     []string `json:"aliases"`
+	BalanceGWei string   `json:"balanceGWei"`
+}
+
+// String implements the fmt.Stringer interface for the text output.
+func (ab accountBalance) String() string {
+	return fmt.Sprintf("%s GWei %v", ab.BalanceGWei, ab.Aliases)
+}
+
+// vaultContract registers the flag naming the contract of the group. The bank
+// group works with the proxy or the single bank, the book group only with the
+// book.
+func vaultContract(fs *flag.FlagSet, group string) *string {
+	if group != "bank" {
+		return &group
+	}
+
+	return fs.String("contract", "bank", "contract to use, bank or bank_single")
+}
+
+// openVault connects the client to the named contract, setting its ABI on the
+// session.
+func openVault(ctx context.Context, s *cli.Session, clt *ethereum.Client, name string) (vault, error) {
+	var meta *bind.MetaData
+	switch name {
+	case "bank":
+		meta = proxy.BankMetaData
+	case "bank_single":
+		meta = single.BankMetaData
+	case "book":
+		meta = book.BookMetaData
+	default:
+		return nil, fmt.Errorf("unknown contract %q", name)
+	}
+	s.UseABI(meta.ABI)
+
+	contractID, err := s.Contract(ctx, name, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var v vault
+	switch name {
+	case "bank":
+		v, err = proxy.NewBank(contractID, clt.Backend)
+	case "bank_single":
+		v, err = single.NewBank(contractID, clt.Backend)
+	case "book":
+		v, err = book.NewBook(contractID, clt.Backend)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("new %s connection: %w", name, err)
+	}
+
+	return v, nil
+}
+
+// vaultDeposit deposits the amount from the account into the contract.
+func vaultDeposit(group string) setupFunc {
+	return func(fs *flag.FlagSet) runFunc {
+		name := vaultContract(fs, group)
+		account := fs.String("account", "account1", "account depositing")
+		amount := fs.Float64("amount", 120_000, "amount to deposit in GWei")
+
+		return func(ctx context.Context, s *cli.Session) error {
+			clt, err := s.Client(*account)
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Input Values")
+			s.Out.Value("fromAddress", clt.Address())
+			s.Out.Value("amountGWei", *amount)
+
+			v, err := openVault(ctx, s, clt, *name)
+			if err != nil {
+				return err
+			}
+
+			tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, *amount)
+			if err != nil {
+				return err
+			}
+
+			tx, err := v.Deposit(tranOpts)
+			if err != nil {
+				return err
+			}
+
+			if _, err := s.Wait(ctx, clt, tx); err != nil {
+				return err
+			}
+
+			return nil
+		}
+	}
+}
+
+// vaultWithdraw withdraws the balance of the account from the contract.
+func vaultWithdraw(group string) setupFunc {
+	return func(fs *flag.FlagSet) runFunc {
+		name := vaultContract(fs, group)
+		account := fs.String("account", "account1", "account withdrawing")
+
+		return func(ctx context.Context, s *cli.Session) error {
+			clt, err := s.Client(*account)
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Input Values")
+			s.Out.Value("fromAddress", clt.Address())
+
+			v, err := openVault(ctx, s, clt, *name)
+			if err != nil {
+				return err
+			}
+
+			tranOpts, err := s.TransactOpts(ctx, clt, 1_600_000, 0)
+			if err != nil {
+				return err
+			}
+
+			tx, err := v.Withdraw(tranOpts)
+			if err != nil {
+				return err
+			}
+
+			if _, err := s.Wait(ctx, clt, tx); err != nil {
+				return err
+			}
+
+			return nil
+		}
+	}
+}
+
+// vaultBalance shows the balance of the account in the contract, read by the
+// account itself. The book also shows the account's nonce.
+func vaultBalance(group string) setupFunc {
+	return func(fs *flag.FlagSet) runFunc {
+		name := vaultContract(fs, group)
+		account := fs.String("account", "account1", "account to show")
+
+		return func(ctx context.Context, s *cli.Session) error {
+			clt, err := s.Client(*account)
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Input Values")
+			s.Out.Value("fromAddress", clt.Address())
+
+			v, err := openVault(ctx, s, clt, *name)
+			if err != nil {
+				return err
+			}
+
+			callOpts, err := clt.NewCallOpts(ctx)
+			if err != nil {
+				return err
+			}
+
+			balance, err := v.Balance(callOpts)
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Account")
+			s.Out.Value("balanceGWei", currency.Wei2GWei(balance).String())
+
+			if bookContract, ok := v.(*book.Book); ok {
+				nonce, err := bookContract.Nonce(callOpts, clt.Address())
+				if err != nil {
+					return err
+				}
+				s.Out.Value("nonce", nonce)
+			}
+
+			return nil
+		}
+	}
+}
+
+// vaultAccounts shows the balance in the contract of every keystore account,
+// read by the owner.
+func vaultAccounts(group string) setupFunc {
+	return func(fs *flag.FlagSet) runFunc {
+		name := vaultContract(fs, group)
+
+		return func(ctx context.Context, s *cli.Session) error {
+			clt, err := s.Client("owner")
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Input Values")
+			s.Out.Value("fromAddress", clt.Address())
+
+			v, err := openVault(ctx, s, clt, *name)
+			if err != nil {
+				return err
+			}
+
+			callOpts, err := clt.NewCallOpts(ctx)
+			if err != nil {
+				return err
+			}
+
+			s.Out.Section("Accounts")
+
+			for _, account := range s.Accounts.List() {
+				balance, err := v.AccountBalance(callOpts, account.Address)
+				if err != nil {
+					return fmt.Errorf("retrieving balance of %s: %w", account.Address, err)
+				}
+
+				s.Out.Value(account.Address.Hex(), accountBalance{
+					Aliases:     account.Aliases,
+					BalanceGWei: currency.Wei2GWei(balance).String(),
+				})
+			}
+
+			return nil
+		}
+	}
+}
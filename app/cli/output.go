@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+// Set of output formats a command can write.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Result represents the JSON document a command writes when it finishes.
+type Result struct {
+	Command      string                `json:"command"`
+	Values       map[string]any        `json:"values,omitempty"`
+	Transactions []Transaction         `json:"transactions,omitempty"`
+	Balance      *currency.BalanceDiff `json:"balance,omitempty"`
+	Error        string                `json:"error,omitempty"`
+}
+
+// Transaction represents a transaction a command sent, with its receipt and
+// logs once it's mined.
+type Transaction struct {
+	Details currency.TransactionDetails `json:"details"`
+	Receipt *currency.ReceiptDetails    `json:"receipt,omitempty"`
+	Logs    []currency.LogData          `json:"logs,omitempty"`
+}
+
+// Output writes what a command does. Text is written as the command runs so
+// progress is visible, JSON is written as a single document when the command
+// finishes so it can be consumed by other programs.
+type Output struct {
+	format string
+	w      io.Writer
+	result Result
+}
+
+// NewOutput constructs an output for the command in the format.
+func NewOutput(w io.Writer, format string, command string) (*Output, error) {
+	switch format {
+	case OutputText, OutputJSON:
+	default:
+		return nil, fmt.Errorf("unknown output format %q, use %s or %s", format, OutputText, OutputJSON)
+	}
+
+	o := Output{
+		format: format,
+		w:      w,
+		result: Result{
+			Command: command,
+			Values:  make(map[string]any),
+		},
+	}
+
+	return &o, nil
+}
+
+// Section starts a titled group of values in the text output.
+func (o *Output) Section(title string) {
+	if o.format != OutputText {
+		return
+	}
+
+	fmt.Fprintln(o.w, "\n"+title)
+	fmt.Fprintln(o.w, "----------------------------------------------------")
+}
+
+// Value records a named value of the command's result.
+func (o *Output) Value(key string, value any) {
+	if o.format != OutputText {
+		o.result.Values[key] = value
+		return
+	}
+
+	fmt.Fprintf(o.w, "%s: %v\n", key, value)
+}
+
+// Transaction records a transaction the command sent.
+func (o *Output) Transaction(converter *currency.Converter, tx *types.Transaction) {
+	if o.format != OutputText {
+		o.result.Transactions = append(o.result.Transactions, Transaction{
+			Details: converter.CalculateTransactionDetails(tx),
+		})
+		return
+	}
+
+	fmt.Fprint(o.w, converter.FmtTransaction(tx))
+}
+
+// Receipt records the receipt of a transaction the command sent.
+func (o *Output) Receipt(converter *currency.Converter, receipt *types.Receipt, tx *types.Transaction, baseFee *big.Int) {
+	if o.format != OutputText {
+		details := converter.CalculateReceiptDetails(receipt, tx, baseFee)
+		logs, _ := converter.ReceiptLogs(receipt)
+
+		for i := range o.result.Transactions {
+			if o.result.Transactions[i].Details.Hash == tx.Hash().Hex() {
+				o.result.Transactions[i].Receipt = &details
+				o.result.Transactions[i].Logs = logs
+				return
+			}
+		}
+
+		o.result.Transactions = append(o.result.Transactions, Transaction{
+			Details: converter.CalculateTransactionDetails(tx),
+			Receipt: &details,
+			Logs:    logs,
+		})
+		return
+	}
+
+	fmt.Fprint(o.w, converter.FmtTransactionReceipt(receipt, tx, baseFee))
+}
+
+// BalanceSheet records the change in the balance of the account the command
+// paid from.
+func (o *Output) BalanceSheet(converter *currency.Converter, startingBalance *big.Int, endingBalance *big.Int) {
+	if o.format != OutputText {
+		diff, err := converter.CalculateBalanceDiff(startingBalance, endingBalance)
+		if err == nil {
+			o.result.Balance = &diff
+		}
+		return
+	}
+
+	fmt.Fprint(o.w, converter.FmtBalanceSheet(startingBalance, endingBalance))
+}
+
+// Flush finishes the output of the command with the error it failed with, if
+// any. The JSON document is written, text has already been written.
+func (o *Output) Flush(err error) error {
+	if o.format != OutputJSON {
+		return nil
+	}
+
+	if err != nil {
+		o.result.Error = err.Error()
+	}
+
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(o.result); err != nil {
+		return fmt.Errorf("encoding result: %w", err)
+	}
+
+	return nil
+}
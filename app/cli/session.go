@@ -0,0 +1,178 @@
+// Package cli provides support for the smartcontract command line tool. A
+// session holds what every subcommand needs to talk to the network and writes
+// what the subcommand did as text or JSON.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/app/config"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+// Session represents the connection to the network of a subcommand.
+type Session struct {
+	Config   config.Config
+	Accounts *ethereum.Accounts
+	Backend  ethereum.Backend
+	Out      *Output
+
+	converter    *currency.Converter
+	payers       []payer
+	closeBackend func()
+}
+
+// payer represents an account that paid for a transaction and its balance
+// before the first one.
+type payer struct {
+	client          *ethereum.Client
+	startingBalance *big.Int
+}
+
+// NewSession opens the accounts and connects to the network of the config.
+func NewSession(ctx context.Context, cfg config.Config, out *Output) (*Session, error) {
+	accounts, err := cfg.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	backend, closeBackend, err := cfg.Backend(ctx, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	s := Session{
+		Config:       cfg,
+		Accounts:     accounts,
+		Backend:      backend,
+		Out:          out,
+		converter:    currency.NewDefaultConverter(""),
+		closeBackend: closeBackend,
+	}
+
+	return &s, nil
+}
+
+// Close reports the balance sheet of every account that paid for a
+// transaction and closes the connection to the network.
+func (s *Session) Close(ctx context.Context) error {
+	defer s.closeBackend()
+
+	for _, p := range s.payers {
+		endingBalance, err := p.client.Balance(ctx)
+		if err != nil {
+			return err
+		}
+		s.Out.BalanceSheet(s.converter, p.startingBalance, endingBalance)
+	}
+
+	return nil
+}
+
+// Client returns a client for the account with the alias, address or index.
+func (s *Session) Client(name string) (*ethereum.Client, error) {
+	return s.Accounts.Client(s.Backend, name)
+}
+
+// Contract looks up the address of the named contract in the registry,
+// verifying it's deployed from the compiled bytecode in the metadata.
+func (s *Session) Contract(ctx context.Context, name string, meta *bind.MetaData) (common.Address, error) {
+	d, err := s.Config.Registry().Lookup(ctx, s.Backend, name, meta)
+	if err != nil {
+		return common.Address{}, err
+	}
+	s.Out.Value("contractID", d.Address)
+
+	return d.Address, nil
+}
+
+// Record records the deployment of the named contract by its mined deploy
+// transaction in the registry.
+func (s *Session) Record(ctx context.Context, name string, meta *bind.MetaData, tx *types.Transaction, receipt *types.Receipt) error {
+	registry := s.Config.Registry()
+
+	d, err := registry.Record(ctx, s.Backend, name, meta, tx, receipt)
+	if err != nil {
+		return err
+	}
+
+	s.Out.Section("Deployment")
+	s.Out.Value("registry", registry.Path())
+	s.Out.Value("name", d.Name)
+	s.Out.Value("chainID", d.ChainID)
+	s.Out.Value("contractID", d.Address)
+	s.Out.Value("block", d.Block)
+	s.Out.Value("codeHash", d.CodeHash)
+
+	return nil
+}
+
+// UseABI sets the ABI of the contract the subcommand works with, used to
+// decode the logs of its transactions. The default converter values are used
+// when the CoinMarketCap API can't be reached.
+func (s *Session) UseABI(abiMetaData string) {
+	converter, err := currency.NewConverter(abiMetaData, s.Config.CoinMarketCapKey)
+	if err != nil {
+		converter = currency.NewDefaultConverter(abiMetaData)
+	}
+	s.converter = converter
+
+	oneETHToUSD, oneUSDToETH := converter.Values()
+	s.Out.Value("oneETHToUSD", oneETHToUSD)
+	s.Out.Value("oneUSDToETH", oneUSDToETH)
+}
+
+// TransactOpts returns the options for a transaction paid by the client,
+// using the configured gas limit or the subcommand's default. The balance of
+// the client is recorded the first time it pays.
+func (s *Session) TransactOpts(ctx context.Context, clt *ethereum.Client, defGasLimit uint64, valueGWei float64) (*bind.TransactOpts, error) {
+	if err := s.pays(ctx, clt); err != nil {
+		return nil, err
+	}
+
+	return clt.NewTransactOpts(ctx, s.Config.Gas(defGasLimit), s.Config.GasPrice(), big.NewFloat(valueGWei))
+}
+
+// Wait reports the transaction, waits for it to be mined and reports the
+// receipt.
+func (s *Session) Wait(ctx context.Context, clt *ethereum.Client, tx *types.Transaction) (*types.Receipt, error) {
+	s.Out.Transaction(s.converter, tx)
+
+	receipt, err := clt.WaitMined(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	baseFee, err := clt.BaseFee(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	s.Out.Receipt(s.converter, receipt, tx, baseFee)
+
+	return receipt, nil
+}
+
+// pays records the starting balance of the client.
+func (s *Session) pays(ctx context.Context, clt *ethereum.Client) error {
+	for _, p := range s.payers {
+		if p.client.Address() == clt.Address() {
+			return nil
+		}
+	}
+
+	startingBalance, err := clt.Balance(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving balance of %s: %w", clt.Address(), err)
+	}
+
+	s.payers = append(s.payers, payer{client: clt, startingBalance: startingBalance})
+
+	return nil
+}
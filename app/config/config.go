@@ -0,0 +1,538 @@
+// Package config loads the settings shared by the app commands. Settings come
+// from defaults, an optional JSON config file, environment variables and
+// command line flags, each overriding the ones before it, so the same
+// binaries can target the dev geth, a simulated chain or another network.
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+// Set of named networks a config can use in place of endpoints.
+const (
+	NetworkDev       = "dev"       // The dev geth over HTTP.
+	NetworkDevIPC    = "dev-ipc"   // The dev geth over its IPC socket.
+	NetworkSimulated = "simulated" // An in-memory chain funding the keystore accounts.
+)
+
+// RegistryFile is the name of the file in the contracts directory recording
+// the deployed contracts.
+const RegistryFile = "deployments.json"
+
+// EnvConfig is the environment variable naming the config file when the
+// -config flag isn't set.
+const EnvConfig = "SMART_CONFIG"
+
+// Set of sources a setting can come from, in increasing precedence.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// Config represents the settings shared by the app commands.
+type Config struct {
+	Network          []string // Ordered endpoints to fail over between, or a single named network
+	ChainID          uint64   // Chain the endpoints must be on (0 = any)
+	KeyStoreDir      string
+	AliasFile        string
+	ContractsDir     string  // Directory holding the registry of deployed contracts
+	GasLimit         uint64  // Gas limit of transactions (0 = the command's default)
+	GasPriceGwei     float64 // Gas price of transactions
+	CoinMarketCapKey string
+
+	// PrintConfig is set by the -print-config flag, the command should print
+	// the config and exit.
+	PrintConfig bool
+
+	file    string
+	sources map[string]string
+}
+
+// Default returns the config targeting the dev geth.
+func Default() Config {
+	cfg := Config{
+		Network:      []string{NetworkDev},
+		KeyStoreDir:  "zarf/ethereum/keystore",
+		AliasFile:    "zarf/ethereum/accounts.json",
+		ContractsDir: "zarf/ethereum",
+		GasPriceGwei: 39.576,
+		sources:      make(map[string]string),
+	}
+
+	for _, f := range fields {
+		cfg.sources[f.flag] = SourceDefault
+	}
+
+	return cfg
+}
+
+// Load parses the config flags, registered on the flag set along with any the
+// command defined, from the arguments. The config is built from the defaults,
+// the config file named by the -config flag or SMART_CONFIG, the environment
+// and the flags, in increasing precedence, and is validated. The arguments
+// left after the flags are available from the flag set.
+func Load(fs *flag.FlagSet, args []string) (Config, error) {
+	flagged := make(map[string]string)
+
+	for _, f := range fields {
+		f := f
+		fs.Func(f.flag, fmt.Sprintf("%s (env %s)", f.usage, f.env), func(v string) error {
+			flagged[f.flag] = v
+			return nil
+		})
+	}
+
+	file := fs.String("config", "", fmt.Sprintf("JSON config file (env %s)", EnvConfig))
+	printConfig := fs.Bool("print-config", false, "print the effective config and exit")
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	cfg := Default()
+	cfg.PrintConfig = *printConfig
+
+	cfg.file = *file
+	if cfg.file == "" {
+		cfg.file = os.Getenv(EnvConfig)
+	}
+
+	if cfg.file != "" {
+		if err := cfg.loadFile(cfg.file); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, f := range fields {
+		v, exists := os.LookupEnv(f.env)
+		if !exists || v == "" {
+			continue
+		}
+
+		if err := f.set(&cfg, v); err != nil {
+			return Config{}, fmt.Errorf("env %s: %w", f.env, err)
+		}
+		cfg.sources[f.flag] = SourceEnv
+	}
+
+	for _, f := range fields {
+		v, exists := flagged[f.flag]
+		if !exists {
+			continue
+		}
+
+		if err := f.set(&cfg, v); err != nil {
+			return Config{}, fmt.Errorf("flag -%s: %w", f.flag, err)
+		}
+		cfg.sources[f.flag] = SourceFlag
+	}
+
+	// Deployments to the simulated chain are gone when the command exits,
+	// and the chain shares the dev geth's chain id, so unless a directory
+	// was chosen they're recorded in the temp directory instead of over the
+	// dev geth's deployments.
+	if cfg.Simulated() && cfg.sources["contracts"] == SourceDefault {
+		cfg.ContractsDir = os.TempDir()
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// loadFile applies the settings in the JSON config file. Keys are the field
+// names of the config, such as "network" or "gasPriceGwei".
+func (cfg *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("decoding config file %s: %w", path, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f, exists := fieldByKey(key)
+		if !exists {
+			return fmt.Errorf("config file %s: unknown setting %q", path, key)
+		}
+
+		v, err := fileValue(values[key])
+		if err != nil {
+			return fmt.Errorf("config file %s: %s: %w", path, key, err)
+		}
+
+		if err := f.set(cfg, v); err != nil {
+			return fmt.Errorf("config file %s: %s: %w", path, key, err)
+		}
+		cfg.sources[f.flag] = SourceFile
+	}
+
+	return nil
+}
+
+// fileValue returns a config file value in the form of a flag value. Lists
+// are joined with commas and numbers are kept as written.
+func fileValue(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, ","), nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String(), nil
+	}
+
+	return "", errors.New("must be a string, a list of strings or a number")
+}
+
+// Validate checks the settings are usable, reporting every problem found.
+func (cfg Config) Validate() error {
+	var errs []error
+
+	switch {
+	case len(cfg.Network) == 0:
+		errs = append(errs, errors.New("network: at least one endpoint is required"))
+
+	case len(cfg.Network) > 1 && contains(cfg.Network, NetworkSimulated):
+		errs = append(errs, errors.New("network: the simulated network can't be combined with endpoints"))
+
+	default:
+		for _, endpoint := range cfg.Network {
+			if err := validateEndpoint(endpoint); err != nil {
+				errs = append(errs, fmt.Errorf("network: %w", err))
+			}
+		}
+	}
+
+	if cfg.KeyStoreDir == "" {
+		errs = append(errs, errors.New("keystore: a directory is required"))
+	}
+
+	if cfg.ContractsDir == "" {
+		errs = append(errs, errors.New("contracts: a directory is required"))
+	}
+
+	if cfg.GasLimit != 0 && cfg.GasLimit < 21_000 {
+		errs = append(errs, fmt.Errorf("gas-limit: %d is below the 21000 needed by any transaction", cfg.GasLimit))
+	}
+
+	if cfg.GasPriceGwei <= 0 || math.IsInf(cfg.GasPriceGwei, 0) || math.IsNaN(cfg.GasPriceGwei) {
+		errs = append(errs, fmt.Errorf("gas-price: %v must be a positive number of gwei", cfg.GasPriceGwei))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateEndpoint checks the endpoint is a named network, an HTTP or
+// WebSocket URL, or the path of an IPC socket.
+func validateEndpoint(endpoint string) error {
+	switch endpoint {
+	case "":
+		return errors.New("empty endpoint")
+	case NetworkDev, NetworkDevIPC, NetworkSimulated:
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" {
+		if strings.HasSuffix(endpoint, ".ipc") {
+			return nil
+		}
+		return fmt.Errorf("endpoint %q is not a URL, an .ipc path or a named network", endpoint)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+		if u.Host == "" {
+			return fmt.Errorf("endpoint %q has no host", endpoint)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("endpoint %q has unsupported scheme %q", endpoint, u.Scheme)
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// Simulated reports whether the config targets the in-memory simulated chain.
+func (cfg Config) Simulated() bool {
+	return len(cfg.Network) == 1 && cfg.Network[0] == NetworkSimulated
+}
+
+// Endpoints returns the network with the named dev networks resolved.
+func (cfg Config) Endpoints() []string {
+	endpoints := make([]string, len(cfg.Network))
+	for i, endpoint := range cfg.Network {
+		switch endpoint {
+		case NetworkDev:
+			endpoints[i] = ethereum.NetworkHTTPLocalhost
+		case NetworkDevIPC:
+			endpoints[i] = ethereum.NetworkLocalhost
+		default:
+			endpoints[i] = endpoint
+		}
+	}
+
+	return endpoints
+}
+
+// Accounts opens the keystore accounts.
+func (cfg Config) Accounts() (*ethereum.Accounts, error) {
+	return ethereum.NewAccounts(ethereum.AccountsConfig{
+		KeyStoreDir: cfg.KeyStoreDir,
+		AliasFile:   cfg.AliasFile,
+	})
+}
+
+// Backend connects to the network, returning the backend and a function to
+// close it. The simulated network is created with every account funded, the
+// accounts may be nil for commands that don't send transactions, and mines a
+// block for every transaction.
+func (cfg Config) Backend(ctx context.Context, accounts *ethereum.Accounts) (ethereum.Backend, func(), error) {
+	var chainID *big.Int
+	if cfg.ChainID != 0 {
+		chainID = new(big.Int).SetUint64(cfg.ChainID)
+	}
+
+	if cfg.Simulated() {
+		balances := make(map[common.Address]*big.Int)
+		if accounts != nil {
+			for _, account := range accounts.List() {
+				balances[account.Address] = nil
+			}
+		}
+
+		backend, err := ethereum.CreateSimulatedBackendWithConfig(ethereum.SimulatedConfig{
+			Balances:   balances,
+			AutoCommit: true,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if chainID != nil && backend.ChainID().Cmp(chainID) != 0 {
+			backend.Close()
+			return nil, nil, fmt.Errorf("%w: simulated chain is %v, expected %v", ethereum.ErrChainIDMismatch, backend.ChainID(), chainID)
+		}
+
+		return backend, func() { backend.Close() }, nil
+	}
+
+	backend, err := ethereum.CreateDialedBackendWithConfig(ctx, ethereum.DialedConfig{
+		Endpoints: cfg.Endpoints(),
+		ChainID:   chainID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return backend, backend.Close, nil
+}
+
+// Gas returns the configured gas limit, or the default when none is set.
+func (cfg Config) Gas(def uint64) uint64 {
+	if cfg.GasLimit == 0 {
+		return def
+	}
+
+	return cfg.GasLimit
+}
+
+// GasPrice returns the gas price in wei.
+func (cfg Config) GasPrice() *big.Int {
+	return currency.GWei2Wei(big.NewFloat(cfg.GasPriceGwei))
+}
+
+// Registry returns the registry of the deployed contracts, kept in the
+// contracts directory.
+func (cfg Config) Registry() *ethereum.Registry {
+	return ethereum.NewRegistry(filepath.Join(cfg.ContractsDir, RegistryFile))
+}
+
+// Source returns where the setting with the flag name came from.
+func (cfg Config) Source(name string) string {
+	return cfg.sources[name]
+}
+
+// String returns the effective config with the source of every setting.
+// Secrets are masked.
+func (cfg Config) String() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "\nConfig")
+	fmt.Fprintln(&b, "----------------------------------------------------")
+
+	w := tabwriter.NewWriter(&b, 0, 4, 1, ' ', 0)
+	if cfg.file != "" {
+		fmt.Fprintf(w, "config\t: %s\n", cfg.file)
+	}
+	for _, f := range fields {
+		v := f.get(cfg)
+		if f.secret && v != "" {
+			v = "********"
+		}
+		fmt.Fprintf(w, "%s\t: %s\t(%s)\n", f.flag, v, cfg.sources[f.flag])
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// field represents a setting, named by its flag, environment variable and
+// config file key.
+type field struct {
+	flag   string
+	env    string
+	key    string
+	usage  string
+	secret bool
+	set    func(cfg *Config, v string) error
+	get    func(cfg Config) string
+}
+
+// fields are the settings of a config, in the order they're printed.
+var fields = []field{
+	{
+		flag:  "network",
+		env:   "SMART_NETWORK",
+		key:   "network",
+		usage: "comma separated endpoints to fail over between, or dev, dev-ipc or simulated",
+		set: func(cfg *Config, v string) error {
+			cfg.Network = nil
+			for _, endpoint := range strings.Split(v, ",") {
+				if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+					cfg.Network = append(cfg.Network, endpoint)
+				}
+			}
+			return nil
+		},
+		get: func(cfg Config) string { return strings.Join(cfg.Network, ",") },
+	},
+	{
+		flag:  "chain-id",
+		env:   "SMART_CHAIN_ID",
+		key:   "chainID",
+		usage: "chain id the network must report, 0 for any",
+		set: func(cfg *Config, v string) (err error) {
+			cfg.ChainID, err = strconv.ParseUint(v, 10, 64)
+			return err
+		},
+		get: func(cfg Config) string { return strconv.FormatUint(cfg.ChainID, 10) },
+	},
+	{
+		flag:  "keystore",
+		env:   "SMART_KEYSTORE",
+		key:   "keyStoreDir",
+		usage: "keystore directory",
+		set:   func(cfg *Config, v string) error { cfg.KeyStoreDir = v; return nil },
+		get:   func(cfg Config) string { return cfg.KeyStoreDir },
+	},
+	{
+		flag:  "aliases",
+		env:   "SMART_ALIASES",
+		key:   "aliasFile",
+		usage: "JSON file naming the keystore accounts",
+		set:   func(cfg *Config, v string) error { cfg.AliasFile = v; return nil },
+		get:   func(cfg Config) string { return cfg.AliasFile },
+	},
+	{
+		flag:  "contracts",
+		env:   "SMART_CONTRACTS",
+		key:   "contractsDir",
+		usage: "directory holding the registry of deployed contracts",
+		set:   func(cfg *Config, v string) error { cfg.ContractsDir = v; return nil },
+		get:   func(cfg Config) string { return cfg.ContractsDir },
+	},
+	{
+		flag:  "gas-limit",
+		env:   "SMART_GAS_LIMIT",
+		key:   "gasLimit",
+		usage: "gas limit of transactions, 0 for the command's default",
+		set: func(cfg *Config, v string) (err error) {
+			cfg.GasLimit, err = strconv.ParseUint(v, 10, 64)
+			return err
+		},
+		get: func(cfg Config) string { return strconv.FormatUint(cfg.GasLimit, 10) },
+	},
+	{
+		flag:  "gas-price",
+		env:   "SMART_GAS_PRICE",
+		key:   "gasPriceGwei",
+		usage: "gas price of transactions in gwei",
+		set: func(cfg *Config, v string) (err error) {
+			cfg.GasPriceGwei, err = strconv.ParseFloat(v, 64)
+			return err
+		},
+		get: func(cfg Config) string { return strconv.FormatFloat(cfg.GasPriceGwei, 'f', -1, 64) },
+	},
+	{
+		flag:   "cmc-key",
+		env:    "CMC_API_KEY",
+		key:    "coinMarketCapKey",
+		usage:  "CoinMarketCap API key for currency conversion",
+		secret: true,
+		set:    func(cfg *Config, v string) error { cfg.CoinMarketCapKey = v; return nil },
+		get:    func(cfg Config) string { return cfg.CoinMarketCapKey },
+	},
+}
+
+// fieldByKey returns the field with the config file key.
+func fieldByKey(key string) (field, bool) {
+	for _, f := range fields {
+		if f.key == key {
+			return f, true
+		}
+	}
+
+	return field{}, false
+}
+
+// contains reports whether the list has the value.
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+
+	return false
+}
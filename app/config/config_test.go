@@ -0,0 +1,326 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/app/config"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestLoad(t *testing.T) {
+	for _, key := range []string{config.EnvConfig, "SMART_NETWORK", "SMART_CONTRACTS", "SMART_GAS_LIMIT", "SMART_GAS_PRICE", "CMC_API_KEY"} {
+		t.Setenv(key, "")
+	}
+
+	dir := t.TempDir()
+
+	// writeFile writes the config file and returns its path.
+	writeFile := func(t *testing.T, content string) string {
+		t.Helper()
+
+		f, err := os.CreateTemp(dir, "*.json")
+		if err != nil {
+			t.Fatalf("unable to create config file: %s", err)
+		}
+		defer f.Close()
+
+		if _, err := f.WriteString(content); err != nil {
+			t.Fatalf("unable to write config file: %s", err)
+		}
+
+		return f.Name()
+	}
+
+	// load loads the config from the arguments with a fresh flag set.
+	load := func(args ...string) (config.Config, *flag.FlagSet, error) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		cfg, err := config.Load(fs, args)
+		return cfg, fs, err
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, _, err := load()
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		if got := cfg.Endpoints(); len(got) != 1 || got[0] != "http://localhost:8545" {
+			t.Fatalf("wrong endpoints, got %v  exp [http://localhost:8545]", got)
+		}
+
+		if cfg.Gas(1_600_000) != 1_600_000 {
+			t.Fatalf("wrong gas limit, got %d  exp the command default", cfg.Gas(1_600_000))
+		}
+
+		if cfg.GasPrice().Cmp(big.NewInt(39_576_000_000)) != 0 {
+			t.Fatalf("wrong gas price, got %v  exp 39576000000", cfg.GasPrice())
+		}
+
+		if cfg.Source("network") != config.SourceDefault {
+			t.Fatalf("wrong source, got %s  exp %s", cfg.Source("network"), config.SourceDefault)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("precedence", func(t *testing.T) {
+		file := writeFile(t, `{
+			"network": ["http://one:8545", "ws://two:8546"],
+			"gasLimit": 2000000,
+			"gasPriceGwei": 10,
+			"contractsDir": "deployments"
+		}`)
+
+		t.Setenv(config.EnvConfig, file)
+		t.Setenv("SMART_GAS_LIMIT", "3000000")
+		t.Setenv("SMART_GAS_PRICE", "20")
+
+		cfg, _, err := load("-gas-price", "30.5")
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		exp := []struct {
+			name   string
+			got    string
+			exp    string
+			source string
+		}{
+			{"network", strings.Join(cfg.Network, ","), "http://one:8545,ws://two:8546", config.SourceFile},
+			{"contracts", cfg.ContractsDir, "deployments", config.SourceFile},
+			{"gas-limit", big.NewInt(int64(cfg.GasLimit)).String(), "3000000", config.SourceEnv},
+			{"gas-price", big.NewFloat(cfg.GasPriceGwei).String(), "30.5", config.SourceFlag},
+			{"keystore", cfg.KeyStoreDir, "zarf/ethereum/keystore", config.SourceDefault},
+		}
+
+		for _, tt := range exp {
+			if tt.got != tt.exp {
+				t.Fatalf("wrong %s, got %s  exp %s", tt.name, tt.got, tt.exp)
+			}
+
+			if cfg.Source(tt.name) != tt.source {
+				t.Fatalf("wrong source for %s, got %s  exp %s", tt.name, cfg.Source(tt.name), tt.source)
+			}
+		}
+
+		// The config file named by the flag wins over the environment.
+		other := writeFile(t, `{"gasPriceGwei": 1}`)
+		t.Setenv("SMART_GAS_PRICE", "")
+
+		cfg, _, err = load("-config", other)
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		if cfg.GasPriceGwei != 1 || cfg.ContractsDir != "zarf/ethereum" {
+			t.Fatalf("should only use the flag's config file, got price %v contracts %s", cfg.GasPriceGwei, cfg.ContractsDir)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("command flags", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		target := fs.String("target", "", "")
+
+		cfg, err := config.Load(fs, []string{"-target", "account2", "-network", "simulated", "-print-config", "extra"})
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		if *target != "account2" || !cfg.PrintConfig || fs.Arg(0) != "extra" {
+			t.Fatalf("wrong parse, got target %q print %v args %v", *target, cfg.PrintConfig, fs.Args())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("print", func(t *testing.T) {
+		t.Setenv("CMC_API_KEY", "secret")
+
+		cfg, _, err := load("-network", "dev-ipc")
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		out := cfg.String()
+
+		if strings.Contains(out, "secret") {
+			t.Fatalf("should mask secrets:\n%s", out)
+		}
+
+		for _, exp := range []string{"dev-ipc", "(flag)", "(env)", "(default)", "39.576"} {
+			if !strings.Contains(out, exp) {
+				t.Fatalf("should contain %q:\n%s", exp, out)
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("validation", func(t *testing.T) {
+		bad := [][]string{
+			{"-network", "ftp://host"},
+			{"-network", "localhost:8545"},
+			{"-network", "simulated,dev"},
+			{"-network", ","},
+			{"-gas-price", "0"},
+			{"-gas-limit", "100"},
+			{"-gas-limit", "lots"},
+			{"-chain-id", "-1"},
+			{"-config", writeFile(t, `{"gasPrice": 1}`)},
+			{"-config", writeFile(t, `{"network": true}`)},
+		}
+
+		for _, args := range bad {
+			if _, _, err := load(args...); err == nil {
+				t.Fatalf("should fail for %v", args)
+			}
+		}
+
+		_, _, err := load("-network", "ftp://host", "-gas-price", "-1")
+		if err == nil || !strings.Contains(err.Error(), "network") || !strings.Contains(err.Error(), "gas-price") {
+			t.Fatalf("should report every problem, got %v", err)
+		}
+
+		if _, _, err := load("-network", "https://node.example.com,ws://localhost:8546,zarf/ethereum/geth.ipc"); err != nil {
+			t.Fatalf("should accept HTTP, WebSocket and IPC endpoints: %s", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("contracts", func(t *testing.T) {
+		cfg, _, err := load("-contracts", dir)
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		registry := cfg.Registry()
+
+		if exp := filepath.Join(dir, config.RegistryFile); registry.Path() != exp {
+			t.Fatalf("wrong registry, got %s  exp %s", registry.Path(), exp)
+		}
+
+		if _, err := registry.Deployment(1337, "book"); !errors.Is(err, ethereum.ErrNotDeployed) {
+			t.Fatalf("should fail for a contract that isn't deployed, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("simulated contracts", func(t *testing.T) {
+		cfg, _, err := load("-network", "simulated")
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		// The simulated chain shares the dev geth's chain id, so its
+		// deployments must not go in the dev geth's registry.
+		if cfg.ContractsDir != os.TempDir() {
+			t.Fatalf("simulated deployments should be kept in the temp directory, got %s", cfg.ContractsDir)
+		}
+
+		cfg, _, err = load("-network", "simulated", "-contracts", dir)
+		if err != nil {
+			t.Fatalf("unable to load config: %s", err)
+		}
+
+		if cfg.ContractsDir != dir {
+			t.Fatalf("contracts flag should be kept, got %s  exp %s", cfg.ContractsDir, dir)
+		}
+	})
+}
+
+func TestSimulatedBackend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("SMART_NETWORK", "")
+	t.Setenv("KEYSTORE_PASSPHRASE_FILE", "../../zarf/ethereum/password")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg, err := config.Load(fs, []string{
+		"-network", "simulated",
+		"-keystore", "../../zarf/ethereum/keystore",
+		"-aliases", "../../zarf/ethereum/accounts.json",
+	})
+	if err != nil {
+		t.Fatalf("unable to load config: %s", err)
+	}
+
+	accounts, err := cfg.Accounts()
+	if err != nil {
+		t.Fatalf("unable to open accounts: %s", err)
+	}
+
+	backend, closeBackend, err := cfg.Backend(ctx, accounts)
+	if err != nil {
+		t.Fatalf("unable to create backend: %s", err)
+	}
+	defer closeBackend()
+
+	if backend.Network() != "simulated" {
+		t.Fatalf("wrong network, got %s  exp simulated", backend.Network())
+	}
+
+	for _, account := range accounts.List() {
+		balance, err := backend.BalanceAt(ctx, account.Address, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		if balance.Sign() <= 0 {
+			t.Fatalf("account %s should be funded", account.Address)
+		}
+	}
+
+	client, err := accounts.Client(backend, "owner")
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	txOpts, err := client.NewTransactOpts(ctx, cfg.Gas(21_000), cfg.GasPrice(), big.NewFloat(1))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts: %s", err)
+	}
+
+	to := accounts.List()[1].Address
+	tx, err := txOpts.Signer(client.Address(), types.NewTx(&types.LegacyTx{
+		GasPrice: txOpts.GasPrice,
+		Gas:      txOpts.GasLimit,
+		To:       &to,
+		Value:    txOpts.Value,
+	}))
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %s", err)
+	}
+
+	if err := backend.SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("unable to send transaction: %s", err)
+	}
+
+	if _, err := client.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for transaction: %s", err)
+	}
+
+	// A simulated chain can't satisfy another chain id.
+	cfg.ChainID = 5
+	if _, _, err := cfg.Backend(ctx, accounts); err == nil {
+		t.Fatal("should fail for the wrong chain id")
+	}
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/adamwoolhether/smartcontract/app/config"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+const usage = `Usage: keystore [flags] <command> [account]
+
+Commands:
+  list              List the accounts in the keystore.
+  create            Create a new account.
+  import-key        Import a hex private key.
+  import-mnemonic   Import the key derived from a BIP-39 mnemonic.
+  export <account>  Print the private key of the account.
+  passwd <account>  Change the passphrase of the account.
+
+Accounts are named by alias, address or index. The passphrase of an existing
+account is read from KEYSTORE_PASSPHRASE_FILE or KEYSTORE_PASSPHRASE, or
+prompted for. The passphrase of a new key file is read from
+-new-passphrase-file, or prompted for twice. The mnemonic passphrase is read
+from MNEMONIC_PASSPHRASE.
+
+The keystore and alias file are configured like the other commands, with
+-keystore and -aliases, SMART_KEYSTORE and SMART_ALIASES, or the config file.
+
+Flags:
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("keystore", flag.ContinueOnError)
+	scryptName := fs.String("scrypt", "standard", "scrypt parameters of new key files: standard or light")
+	scryptN := fs.Int("scrypt-n", 0, "scrypt N of new key files, a power of two no lower than light, overrides -scrypt")
+	scryptP := fs.Int("scrypt-p", 0, "scrypt P of new key files, at least 1, overrides -scrypt")
+	newPassphraseFile := fs.String("new-passphrase-file", "", "file with the passphrase of new key files")
+	keyFile := fs.String("key-file", "", "file with the hex private key to import")
+	mnemonicFile := fs.String("mnemonic-file", "", "file with the mnemonic to import")
+	path := fs.String("path", "m/44'/60'/0'/0/0", "derivation path of the mnemonic key")
+	yes := fs.Bool("yes", false, "export without confirmation")
+
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	cfg, err := config.Load(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if cfg.PrintConfig {
+		fmt.Print(cfg)
+		return nil
+	}
+
+	// =========================================================================
+
+	scrypt, err := ethereum.ParseScrypt(*scryptName)
+	if err != nil {
+		return err
+	}
+	if *scryptN != 0 {
+		scrypt.N = *scryptN
+	}
+	if *scryptP != 0 {
+		scrypt.P = *scryptP
+	}
+
+	ks, err := ethereum.NewKeyStore(ethereum.KeyStoreConfig{
+		Dir:    cfg.KeyStoreDir,
+		Scrypt: scrypt,
+	})
+	if err != nil {
+		return err
+	}
+
+	// newPassphrase returns the passphrase to encrypt a new key file with.
+	newPassphrase := func() (string, error) {
+		if *newPassphraseFile != "" {
+			return ethereum.PassphraseFile(*newPassphraseFile)(common.Address{})
+		}
+
+		passphrase, err := ethereum.PromptSecret(os.Stdin, os.Stderr, "New passphrase")
+		if err != nil {
+			return "", err
+		}
+
+		repeat, err := ethereum.PromptSecret(os.Stdin, os.Stderr, "Repeat passphrase")
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case passphrase == "":
+			return "", errors.New("passphrase is empty")
+		case passphrase != repeat:
+			return "", errors.New("passphrases don't match")
+		}
+
+		return passphrase, nil
+	}
+
+	// secret reads the secret from the file, or prompts for it.
+	secret := func(file string, prompt string) (string, error) {
+		if file == "" {
+			return ethereum.PromptSecret(os.Stdin, os.Stderr, prompt)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	// resolve returns the account named by the command's argument.
+	resolve := func() (ethereum.Account, error) {
+		if fs.NArg() != 2 {
+			return ethereum.Account{}, fmt.Errorf("%s requires an account", fs.Arg(0))
+		}
+
+		registry, err := cfg.Accounts()
+		if err != nil {
+			return ethereum.Account{}, err
+		}
+
+		return registry.Resolve(fs.Arg(1))
+	}
+
+	// =========================================================================
+
+	switch fs.Arg(0) {
+	case "list":
+		registry, err := cfg.Accounts()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "INDEX\tADDRESS\tALIASES\tFILE")
+		for _, account := range registry.List() {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", account.Index, account.Address, strings.Join(account.Aliases, ","), account.File)
+		}
+		return w.Flush()
+
+	case "create":
+		passphrase, err := newPassphrase()
+		if err != nil {
+			return err
+		}
+
+		account, err := ks.Create(passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Println(account.Address, account.File)
+
+	case "import-key":
+		hexKey, err := secret(*keyFile, "Private key (hex)")
+		if err != nil {
+			return err
+		}
+
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+
+		passphrase, err := newPassphrase()
+		if err != nil {
+			return err
+		}
+
+		account, err := ks.ImportKey(key, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Println(account.Address, account.File)
+
+	case "import-mnemonic":
+		derivationPath, err := accounts.ParseDerivationPath(*path)
+		if err != nil {
+			return fmt.Errorf("invalid derivation path: %w", err)
+		}
+
+		mnemonic, err := secret(*mnemonicFile, "Mnemonic")
+		if err != nil {
+			return err
+		}
+
+		passphrase, err := newPassphrase()
+		if err != nil {
+			return err
+		}
+
+		account, err := ks.ImportMnemonic(mnemonic, os.Getenv("MNEMONIC_PASSPHRASE"), derivationPath, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Println(account.Address, account.File)
+
+	case "export":
+		account, err := resolve()
+		if err != nil {
+			return err
+		}
+
+		passphrase, err := ethereum.DefaultPassphrase()(account.Address)
+		if err != nil {
+			return err
+		}
+
+		key, err := ks.Export(account.Address, passphrase)
+		if err != nil {
+			return err
+		}
+
+		// The key is only printed once the owner has confirmed the address,
+		// anyone with the key controls the account.
+		if !*yes {
+			fmt.Fprintf(os.Stderr, "Anyone with the private key controls %s.\nType the address to print the key: ", account.Address)
+
+			confirm, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("reading confirmation: %w", err)
+			}
+
+			if !strings.EqualFold(strings.TrimSpace(confirm), account.Address.Hex()) {
+				return errors.New("export not confirmed")
+			}
+		}
+
+		fmt.Println(hexutil.Encode(crypto.FromECDSA(key)))
+
+	case "passwd":
+		account, err := resolve()
+		if err != nil {
+			return err
+		}
+
+		passphrase, err := ethereum.DefaultPassphrase()(account.Address)
+		if err != nil {
+			return err
+		}
+
+		updated, err := newPassphrase()
+		if err != nil {
+			return err
+		}
+
+		if err := ks.ChangePassphrase(account.Address, passphrase, updated); err != nil {
+			return err
+		}
+		fmt.Println(account.Address, account.File)
+
+	case "":
+		fs.Usage()
+		return errors.New("command is required")
+
+	default:
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	return nil
+}
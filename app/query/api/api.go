@@ -0,0 +1,293 @@
+// Package api provides a read only HTTP API over the state of the deployed
+// contracts, so scripts don't need to talk to the node directly.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/indexer"
+	"github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// Config represents the settings needed to construct the API.
+type Config struct {
+	Client *ethereum.Client // Must be the owner to read account balances
+	Bank   common.Address   // Proxy or single bank contract
+	Store  *indexer.Store   // Indexed bank entries used for account history
+	Book   common.Address   // Book contract, the book routes are disabled when zero
+}
+
+// API serves the contract state over HTTP.
+type API struct {
+	client *ethereum.Client
+	bank   *bank.Bank
+	addr   common.Address
+	store  *indexer.Store
+	book   *book.Book
+	bookID common.Address
+	mux    *http.ServeMux
+}
+
+// New constructs the API for the contracts in the config.
+func New(cfg Config) (*API, error) {
+	if cfg.Client == nil || cfg.Store == nil {
+		return nil, errors.New("client and store are required")
+	}
+
+	// The proxy bank binding is used for both banks since they share the
+	// AccountBalance, Owner and Version calls.
+	bankContract, err := bank.NewBank(cfg.Bank, cfg.Client.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("binding bank %s: %w", cfg.Bank, err)
+	}
+
+	api := API{
+		client: cfg.Client,
+		bank:   bankContract,
+		addr:   cfg.Bank,
+		store:  cfg.Store,
+		mux:    http.NewServeMux(),
+	}
+
+	api.handle("/v1/bank", api.bankInfo)
+	api.handle("/v1/bank/balance/", api.balance)
+	api.handle("/v1/bank/history/", api.history)
+
+	if cfg.Book != (common.Address{}) {
+		bookContract, err := book.NewBook(cfg.Book, cfg.Client.Backend)
+		if err != nil {
+			return nil, fmt.Errorf("binding book %s: %w", cfg.Book, err)
+		}
+
+		api.book = bookContract
+		api.bookID = cfg.Book
+
+		api.handle("/v1/book", api.bookInfo)
+		api.handle("/v1/book/bets/", api.bet)
+		api.handle("/v1/book/balance/", api.bookBalance)
+	}
+
+	return &api, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (api *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mux.ServeHTTP(w, r)
+}
+
+// handle registers a handler for GET requests to the pattern.
+func (api *API) handle(pattern string, handler http.HandlerFunc) {
+	api.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			respondError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
+// =============================================================================
+
+// ContractInfo represents the details of a deployed contract.
+type ContractInfo struct {
+	Address common.Address `json:"address"`
+	Owner   common.Address `json:"owner"`
+	Version string         `json:"version,omitempty"`
+}
+
+// bankInfo returns the address, owner and version of the bank.
+func (api *API) bankInfo(w http.ResponseWriter, r *http.Request) {
+	callOpts := &bind.CallOpts{Context: r.Context(), From: api.client.Address()}
+
+	owner, err := api.bank.Owner(callOpts)
+	if err != nil {
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("retrieving owner: %s", err))
+		return
+	}
+
+	version, err := api.bank.Version(callOpts)
+	if err != nil {
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("retrieving version: %s", err))
+		return
+	}
+
+	respond(w, http.StatusOK, ContractInfo{
+		Address: api.addr,
+		Owner:   owner,
+		Version: version,
+	})
+}
+
+// Balance represents an account's balance in a contract.
+type Balance struct {
+	Contract common.Address `json:"contract"`
+	Account  common.Address `json:"account"`
+	Balance  *big.Int       `json:"balance"` // Wei
+}
+
+// balance returns the account's balance read from the bank.
+func (api *API) balance(w http.ResponseWriter, r *http.Request) {
+	account, err := accountParam(r, "/v1/bank/balance/")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	callOpts := &bind.CallOpts{Context: r.Context(), From: api.client.Address()}
+
+	balance, err := api.bank.AccountBalance(callOpts, account)
+	if err != nil {
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("retrieving balance: %s", err))
+		return
+	}
+
+	respond(w, http.StatusOK, Balance{
+		Contract: api.addr,
+		Account:  account,
+		Balance:  balance,
+	})
+}
+
+// history returns the indexed balance changes of the account in the bank,
+// optionally filtered with the kind query parameter.
+func (api *API) history(w http.ResponseWriter, r *http.Request) {
+	account, err := accountParam(r, "/v1/bank/history/")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	kind := indexer.Kind(r.URL.Query().Get("kind"))
+	switch kind {
+	case "", indexer.KindDeposit, indexer.KindWithdraw, indexer.KindReconcile:
+	default:
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("invalid kind %q", kind))
+		return
+	}
+
+	entries := []indexer.Entry{}
+	for _, entry := range api.store.Entries(account) {
+		if entry.Contract != api.addr || (kind != "" && entry.Kind != kind) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	respond(w, http.StatusOK, entries)
+}
+
+// bookInfo returns the address and owner of the book.
+func (api *API) bookInfo(w http.ResponseWriter, r *http.Request) {
+	owner, err := api.book.Owner(&bind.CallOpts{Context: r.Context(), From: api.client.Address()})
+	if err != nil {
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("retrieving owner: %s", err))
+		return
+	}
+
+	respond(w, http.StatusOK, ContractInfo{
+		Address: api.bookID,
+		Owner:   owner,
+	})
+}
+
+// bookBalance returns the account's balance read from the book.
+func (api *API) bookBalance(w http.ResponseWriter, r *http.Request) {
+	account, err := accountParam(r, "/v1/book/balance/")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	balance, err := api.book.AccountBalance(&bind.CallOpts{Context: r.Context(), From: api.client.Address()}, account)
+	if err != nil {
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("retrieving balance: %s", err))
+		return
+	}
+
+	respond(w, http.StatusOK, Balance{
+		Contract: api.bookID,
+		Account:  account,
+		Balance:  balance,
+	})
+}
+
+// Bet represents the details of a bet in the book.
+type Bet struct {
+	ID           string           `json:"id"`
+	State        string           `json:"state"`
+	Participants []common.Address `json:"participants"`
+	Moderator    common.Address   `json:"moderator"`
+	AmountBet    *big.Int         `json:"amount_bet"` // Wei
+	Expiration   uint64           `json:"expiration"` // Unix time
+}
+
+// bet returns the details of the bet read from the book.
+func (api *API) bet(w http.ResponseWriter, r *http.Request) {
+	betID := strings.TrimPrefix(r.URL.Path, "/v1/book/bets/")
+	if betID == "" {
+		respondError(w, http.StatusBadRequest, "missing bet id")
+		return
+	}
+
+	details, err := api.book.BetDetails(&bind.CallOpts{Context: r.Context(), From: api.client.Address()}, betID)
+	if err != nil {
+		if revertErr, ok := ethereum.CallRevert(err); ok && revertErr.Reason == book.ReasonBetNotFound {
+			respondError(w, http.StatusNotFound, fmt.Sprintf("bet %q does not exist", betID))
+			return
+		}
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("retrieving bet: %s", err))
+		return
+	}
+
+	respond(w, http.StatusOK, Bet{
+		ID:           betID,
+		State:        book.StateName(details.State),
+		Participants: details.Participants,
+		Moderator:    details.Moderator,
+		AmountBet:    details.AmountBetWei,
+		Expiration:   details.Expiration.Uint64(),
+	})
+}
+
+// =============================================================================
+
+// accountParam extracts the account address that follows the prefix in the
+// request path.
+func accountParam(r *http.Request, prefix string) (common.Address, error) {
+	param := strings.TrimPrefix(r.URL.Path, prefix)
+	if !common.IsHexAddress(param) {
+		return common.Address{}, fmt.Errorf("invalid account address %q", param)
+	}
+
+	return common.HexToAddress(param), nil
+}
+
+// respond writes the value as the JSON response body.
+func respond(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(v)
+}
+
+// ErrorResponse is the body returned when a request fails.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// respondError writes the message as a JSON error response.
+func respondError(w http.ResponseWriter, status int, msg string) {
+	respond(w, status, ErrorResponse{Error: msg})
+}
@@ -0,0 +1,369 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/indexer"
+	"github.com/adamwoolhether/smartcontract/app/bank/single/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/app/query/api"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+const (
+	deployerAcct = iota
+	account1Acc
+	account2Acc
+	numAccounts
+)
+
+const gasLimit = 5_000_000
+
+// get sends a GET request to the server and decodes the JSON response into v.
+func get(t *testing.T, srv *httptest.Server, path string, v any) int {
+	t.Helper()
+
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("unable to get %s: %s", path, err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("wrong content type for %s, got %q  exp %q", path, ct, "application/json")
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("unable to decode response for %s: %s", path, err)
+	}
+
+	return resp.StatusCode
+}
+
+func TestAPI(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(numAccounts, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	clients := make([]*ethereum.Client, numAccounts)
+	for i := range clients {
+		if clients[i], err = ethereum.NewClient(backend, backend.PrivateKeys[i]); err != nil {
+			t.Fatalf("unable to create client %d: %s", i, err)
+		}
+	}
+	deployer := clients[deployerAcct]
+
+	txOpts := func(t *testing.T, client *ethereum.Client, valueGWei float64) *bind.TransactOpts {
+		t.Helper()
+
+		opts, err := client.NewTransactOpts(ctx, gasLimit, big.NewInt(0), big.NewFloat(valueGWei))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		return opts
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	bankAddr, tx, _, err := bank.DeployBank(txOpts(t, deployer, 0), deployer.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy bank: %s", err)
+	}
+
+	if _, err := deployer.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	const depositGWei = 1_000_000_000.0
+	depositWei := currency.GWei2Wei(big.NewFloat(depositGWei))
+
+	// Account 1 deposits twice and withdraws, account 2 deposits once.
+	for _, step := range []struct {
+		acc      int
+		withdraw bool
+	}{{account1Acc, false}, {account1Acc, false}, {account1Acc, true}, {account2Acc, false}} {
+		client := clients[step.acc]
+
+		bank, err := bank.NewBank(bankAddr, client.Backend)
+		if err != nil {
+			t.Fatalf("unable to bind bank: %s", err)
+		}
+
+		if step.withdraw {
+			tx, err = bank.Withdraw(txOpts(t, client, 0))
+		} else {
+			tx, err = bank.Deposit(txOpts(t, client, depositGWei))
+		}
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		if _, err := client.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+	}
+
+	store, err := indexer.Open(filepath.Join(t.TempDir(), "bank_indexer.json"))
+	if err != nil {
+		t.Fatalf("unable to open store: %s", err)
+	}
+
+	idx, err := indexer.New(indexer.Config{
+		Backend:   backend,
+		Store:     store,
+		Contracts: []common.Address{bankAddr},
+	})
+	if err != nil {
+		t.Fatalf("unable to create indexer: %s", err)
+	}
+
+	if _, err := idx.Sync(ctx); err != nil {
+		t.Fatalf("unable to sync: %s", err)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	bookAddr, tx, bookContract, err := book.DeployBook(txOpts(t, deployer, 0), deployer.Backend)
+	if err != nil {
+		t.Fatalf("unable to deploy book: %s", err)
+	}
+
+	if _, err := deployer.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	for _, acc := range []int{account1Acc, account2Acc} {
+		book, err := book.NewBook(bookAddr, clients[acc].Backend)
+		if err != nil {
+			t.Fatalf("unable to bind book: %s", err)
+		}
+
+		tx, err := book.Deposit(txOpts(t, clients[acc], depositGWei))
+		if err != nil {
+			t.Fatalf("unable to deposit: %s", err)
+		}
+
+		if _, err := clients[acc].WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for deposit: %s", err)
+		}
+	}
+
+	const betID = "bet1"
+	const expiration = 1_900_000_000
+	betWei := currency.GWei2Wei(big.NewFloat(100_000_000))
+
+	participants := []common.Address{clients[account1Acc].Address(), clients[account2Acc].Address()}
+	nonces := []*big.Int{big.NewInt(0), big.NewInt(0)}
+
+	var sigs [][]byte
+	for _, acc := range []int{account1Acc, account2Acc} {
+		sig, err := clients[acc].Sign(ctx, betID, clients[acc].Address(), big.NewInt(0))
+		if err != nil {
+			t.Fatalf("unable to sign bet: %s", err)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	tx, err = bookContract.PlaceBet(txOpts(t, deployer, 0), betID, betWei, big.NewInt(0), big.NewInt(expiration), deployer.Address(), participants, nonces, sigs)
+	if err != nil {
+		t.Fatalf("unable to place bet: %s", err)
+	}
+
+	if _, err := deployer.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for bet: %s", err)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	handler, err := api.New(api.Config{
+		Client: deployer,
+		Bank:   bankAddr,
+		Store:  store,
+		Book:   bookAddr,
+	})
+	if err != nil {
+		t.Fatalf("unable to create api: %s", err)
+	}
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	account1 := clients[account1Acc].Address()
+	account2 := clients[account2Acc].Address()
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("bank info", func(t *testing.T) {
+		var info api.ContractInfo
+		if status := get(t, srv, "/v1/bank", &info); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		if info.Address != bankAddr || info.Owner != deployer.Address() || info.Version != "0.1.0" {
+			t.Fatalf("wrong bank info, got %+v", info)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("balance", func(t *testing.T) {
+		tests := []struct {
+			account common.Address
+			exp     *big.Int
+		}{
+			{account1, big.NewInt(0)},
+			{account2, depositWei},
+			{deployer.Address(), big.NewInt(0)},
+		}
+
+		for _, tt := range tests {
+			var balance api.Balance
+			if status := get(t, srv, "/v1/bank/balance/"+tt.account.Hex(), &balance); status != http.StatusOK {
+				t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+			}
+
+			if balance.Account != tt.account || balance.Contract != bankAddr || balance.Balance.Cmp(tt.exp) != 0 {
+				t.Fatalf("wrong balance for %s, got %+v  exp %v", tt.account, balance, tt.exp)
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("history", func(t *testing.T) {
+		var entries []indexer.Entry
+		if status := get(t, srv, "/v1/bank/history/"+account1.Hex(), &entries); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		expKinds := []indexer.Kind{indexer.KindDeposit, indexer.KindDeposit, indexer.KindWithdraw}
+		if len(entries) != len(expKinds) {
+			t.Fatalf("wrong number of entries, got %d  exp %d", len(entries), len(expKinds))
+		}
+
+		for i, entry := range entries {
+			if entry.Kind != expKinds[i] || entry.Account != account1 {
+				t.Fatalf("wrong entry %d, got %+v", i, entry)
+			}
+		}
+
+		if exp := new(big.Int).Mul(depositWei, big.NewInt(-2)); entries[2].Amount.Cmp(exp) != 0 {
+			t.Fatalf("wrong withdraw amount, got %v  exp %v", entries[2].Amount, exp)
+		}
+
+		if status := get(t, srv, "/v1/bank/history/"+account1.Hex()+"?kind=withdraw", &entries); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		if len(entries) != 1 || entries[0].Kind != indexer.KindWithdraw {
+			t.Fatalf("should only get the withdraw, got %+v", entries)
+		}
+
+		// An account without history gets an empty list rather than null.
+		var raw json.RawMessage
+		if status := get(t, srv, "/v1/bank/history/"+deployer.Address().Hex(), &raw); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		if got := strings.TrimSpace(string(raw)); got != "[]" {
+			t.Fatalf("wrong empty history, got %s  exp []", got)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("book", func(t *testing.T) {
+		var info api.ContractInfo
+		if status := get(t, srv, "/v1/book", &info); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		if info.Address != bookAddr || info.Owner != deployer.Address() {
+			t.Fatalf("wrong book info, got %+v", info)
+		}
+
+		var bet api.Bet
+		if status := get(t, srv, "/v1/book/bets/"+betID, &bet); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		if bet.ID != betID || bet.State != "live" || bet.Moderator != deployer.Address() || bet.Expiration != expiration || bet.AmountBet.Cmp(betWei) != 0 {
+			t.Fatalf("wrong bet, got %+v", bet)
+		}
+
+		if len(bet.Participants) != 2 || bet.Participants[0] != participants[0] || bet.Participants[1] != participants[1] {
+			t.Fatalf("wrong participants, got %v  exp %v", bet.Participants, participants)
+		}
+
+		var balance api.Balance
+		if status := get(t, srv, "/v1/book/balance/"+account1.Hex(), &balance); status != http.StatusOK {
+			t.Fatalf("wrong status, got %d  exp %d", status, http.StatusOK)
+		}
+
+		if exp := new(big.Int).Sub(depositWei, betWei); balance.Contract != bookAddr || balance.Balance.Cmp(exp) != 0 {
+			t.Fatalf("wrong book balance, got %+v  exp %v", balance, exp)
+		}
+
+		var errResp api.ErrorResponse
+		if status := get(t, srv, "/v1/book/bets/unknown", &errResp); status != http.StatusNotFound {
+			t.Fatalf("wrong status for an unknown bet, got %d  exp %d", status, http.StatusNotFound)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			method string
+			path   string
+			status int
+		}{
+			{"bad address", http.MethodGet, "/v1/bank/balance/0x1234", http.StatusBadRequest},
+			{"missing address", http.MethodGet, "/v1/bank/history/", http.StatusBadRequest},
+			{"bad kind", http.MethodGet, "/v1/bank/history/" + account1.Hex() + "?kind=bet", http.StatusBadRequest},
+			{"wrong method", http.MethodPost, "/v1/bank", http.StatusMethodNotAllowed},
+			{"missing bet", http.MethodGet, "/v1/book/bets/", http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+				if err != nil {
+					t.Fatalf("unable to create request: %s", err)
+				}
+
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatalf("unable to send request: %s", err)
+				}
+				defer resp.Body.Close()
+
+				if resp.StatusCode != tt.status {
+					t.Fatalf("wrong status, got %d  exp %d", resp.StatusCode, tt.status)
+				}
+
+				var errResp api.ErrorResponse
+				if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+					t.Fatalf("should get an error message, got %+v: %v", errResp, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/app/bank/indexer"
+	proxy "github.com/adamwoolhether/smartcontract/app/bank/proxy/contract/go/bank"
+	"github.com/adamwoolhether/smartcontract/app/book/contract/go/book"
+	"github.com/adamwoolhether/smartcontract/app/config"
+	"github.com/adamwoolhether/smartcontract/app/query/api"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+const (
+	defaultAddr      = "localhost:3000"
+	defaultStoreFile = "zarf/ethereum/bank_indexer.json"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		return err
+	}
+
+	if cfg.PrintConfig {
+		fmt.Print(cfg)
+		return nil
+	}
+
+	addr := os.Getenv("QUERY_ADDR")
+	if addr == "" {
+		addr = defaultAddr
+	}
+
+	storeFile := os.Getenv("INDEXER_STORE")
+	if storeFile == "" {
+		storeFile = defaultStoreFile
+	}
+
+	// =========================================================================
+
+	accounts, err := cfg.Accounts()
+	if err != nil {
+		return err
+	}
+
+	backend, closeBackend, err := cfg.Backend(ctx, accounts)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	registry := cfg.Registry()
+
+	bankDeployment, err := registry.Lookup(ctx, backend, "bank", proxy.BankMetaData)
+	if err != nil {
+		return err
+	}
+	bankAddr := bankDeployment.Address
+
+	// The book is optional, its routes are only served once it's deployed.
+	var bookAddr common.Address
+	bookDeployment, err := registry.Lookup(ctx, backend, "book", book.BookMetaData)
+	switch {
+	case err == nil:
+		bookAddr = bookDeployment.Address
+	case !errors.Is(err, ethereum.ErrNotDeployed):
+		return err
+	}
+
+	// The owner's key is used since only the owner can read account balances.
+	client, err := accounts.Client(backend, "owner")
+	if err != nil {
+		return err
+	}
+
+	// The indexer runs in process so the history served stays current.
+	store, err := indexer.Open(storeFile)
+	if err != nil {
+		return err
+	}
+
+	idx, err := indexer.New(indexer.Config{
+		Backend:   backend,
+		Store:     store,
+		Contracts: []common.Address{bankAddr},
+	})
+	if err != nil {
+		return err
+	}
+
+	handler, err := api.New(api.Config{
+		Client: client,
+		Bank:   bankAddr,
+		Store:  store,
+		Book:   bookAddr,
+	})
+	if err != nil {
+		return err
+	}
+
+	// =========================================================================
+
+	srv := http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errs := make(chan error, 2)
+
+	go func() {
+		errs <- idx.Run(ctx)
+	}()
+
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	fmt.Println("\nServing")
+	fmt.Println("----------------------------------------------------")
+	fmt.Println("address:", addr)
+	fmt.Println("contractID:", bankAddr)
+	fmt.Println("bookID:", bookAddr)
+
+	select {
+	case err := <-errs:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,346 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/term"
+)
+
+// Set of environment variables read by DefaultPassphrase.
+const (
+	EnvPassphraseFile = "KEYSTORE_PASSPHRASE_FILE"
+	EnvPassphrase     = "KEYSTORE_PASSPHRASE"
+)
+
+// Passphrase returns the passphrase that unlocks the key file of the account.
+type Passphrase func(account common.Address) (string, error)
+
+// PassphraseFile reads the passphrase from the file, ignoring a trailing
+// newline. Every account uses the same passphrase.
+func PassphraseFile(path string) Passphrase {
+	return func(common.Address) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading passphrase file: %w", err)
+		}
+
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+}
+
+// PassphraseEnv reads the passphrase from the environment variable. Every
+// account uses the same passphrase.
+func PassphraseEnv(key string) Passphrase {
+	return func(common.Address) (string, error) {
+		passphrase, exists := os.LookupEnv(key)
+		if !exists {
+			return "", fmt.Errorf("environment variable %s is not set", key)
+		}
+
+		return passphrase, nil
+	}
+}
+
+// PassphrasePrompt asks for the passphrase of each account on out and reads
+// it from in. Echo is turned off while reading when in is a terminal.
+func PassphrasePrompt(in *os.File, out io.Writer) Passphrase {
+	return func(account common.Address) (string, error) {
+		passphrase, err := PromptSecret(in, out, fmt.Sprintf("Passphrase for %s", account))
+		if err != nil {
+			return "", fmt.Errorf("reading passphrase: %w", err)
+		}
+
+		return passphrase, nil
+	}
+}
+
+// PromptSecret writes the prompt to out and reads a line from in with echo
+// turned off when in is a terminal.
+func PromptSecret(in *os.File, out io.Writer, prompt string) (string, error) {
+	fmt.Fprintf(out, "%s: ", prompt)
+	defer fmt.Fprintln(out)
+
+	return readPassword(in)
+}
+
+// DefaultPassphrase reads the passphrase from the file named by
+// KEYSTORE_PASSPHRASE_FILE, or KEYSTORE_PASSPHRASE, and prompts on the
+// terminal when neither is set.
+func DefaultPassphrase() Passphrase {
+	if path := os.Getenv(EnvPassphraseFile); path != "" {
+		return PassphraseFile(path)
+	}
+
+	if _, exists := os.LookupEnv(EnvPassphrase); exists {
+		return PassphraseEnv(EnvPassphrase)
+	}
+
+	return PassphrasePrompt(os.Stdin, os.Stderr)
+}
+
+// readPassword reads a line from the file with echo turned off when the file
+// is a terminal. Reading fails on a terminal echo can't be turned off for,
+// rather than showing the line.
+func readPassword(in *os.File) (string, error) {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return readLine(in)
+	}
+
+	line, err := term.ReadPassword(fd)
+	if err != nil {
+		return "", err
+	}
+
+	return string(line), nil
+}
+
+// readLine reads a line from the file a byte at a time, so nothing past the
+// line is consumed.
+func readLine(in *os.File) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+
+	for {
+		_, err := in.Read(b)
+		switch {
+		case err == io.EOF && len(line) > 0:
+			return string(line), nil
+		case err != nil:
+			return "", err
+		case b[0] == '\n':
+			return strings.TrimRight(string(line), "\r"), nil
+		}
+		line = append(line, b[0])
+	}
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// AccountsConfig represents the settings of an account registry.
+type AccountsConfig struct {
+	KeyStoreDir string
+	AliasFile   string                    // JSON object of alias to address, optional
+	Aliases     map[string]common.Address // Added to the aliases in the file
+	Passphrase  Passphrase                // nil = DefaultPassphrase()
+}
+
+// Account represents an account in the registry.
+type Account struct {
+	Index   int
+	Address common.Address
+	Aliases []string
+	File    string
+}
+
+// Accounts is a registry of the accounts in a keystore directory. Accounts
+// are resolved by alias, address or index, where the index is the position of
+// the key file sorted by name. Geth names key files by their creation time,
+// so the index is the order the accounts were created in.
+type Accounts struct {
+	accounts   []Account
+	aliases    map[string]common.Address
+	passphrase Passphrase
+	ks         *KeyStore
+
+	mu      sync.Mutex
+	signers map[common.Address]Signer
+}
+
+// NewAccounts scans the keystore directory and constructs a registry of its
+// accounts. Files that aren't key files are ignored.
+func NewAccounts(cfg AccountsConfig) (*Accounts, error) {
+	entries, err := os.ReadDir(cfg.KeyStoreDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading keystore: %w", err)
+	}
+
+	var accounts []Account
+	indexes := make(map[common.Address]int)
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		file := filepath.Join(cfg.KeyStoreDir, entry.Name())
+
+		address, err := keyFileAddress(file)
+		if err != nil {
+			continue
+		}
+
+		if _, exists := indexes[address]; exists {
+			return nil, fmt.Errorf("account %s has more than one key file", address)
+		}
+
+		indexes[address] = len(accounts)
+		accounts = append(accounts, Account{Address: address, File: file})
+	}
+
+	// ReadDir returns the entries sorted by name.
+	for i := range accounts {
+		accounts[i].Index = i
+	}
+
+	aliases := make(map[string]common.Address)
+
+	if cfg.AliasFile != "" {
+		data, err := os.ReadFile(cfg.AliasFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading alias file: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &aliases); err != nil {
+			return nil, fmt.Errorf("decoding alias file: %w", err)
+		}
+	}
+
+	for alias, address := range cfg.Aliases {
+		aliases[alias] = address
+	}
+
+	for alias, address := range aliases {
+		i, exists := indexes[address]
+		if !exists {
+			return nil, fmt.Errorf("alias %q: account %s is not in the keystore", alias, address)
+		}
+		accounts[i].Aliases = append(accounts[i].Aliases, alias)
+	}
+
+	for i := range accounts {
+		sort.Strings(accounts[i].Aliases)
+	}
+
+	passphrase := cfg.Passphrase
+	if passphrase == nil {
+		passphrase = DefaultPassphrase()
+	}
+
+	// The registry never writes key files, so the scrypt parameters are
+	// unused.
+	ks, err := NewKeyStore(KeyStoreConfig{Dir: cfg.KeyStoreDir})
+	if err != nil {
+		return nil, err
+	}
+
+	a := Accounts{
+		accounts:   accounts,
+		aliases:    aliases,
+		passphrase: passphrase,
+		ks:         ks,
+		signers:    make(map[common.Address]Signer),
+	}
+
+	return &a, nil
+}
+
+// List returns the accounts in the registry in index order.
+func (a *Accounts) List() []Account {
+	accounts := make([]Account, len(a.accounts))
+	copy(accounts, a.accounts)
+
+	return accounts
+}
+
+// Resolve returns the account with the alias, address or index.
+func (a *Accounts) Resolve(name string) (Account, error) {
+	if address, exists := a.aliases[name]; exists {
+		return a.byAddress(address)
+	}
+
+	if common.IsHexAddress(name) {
+		return a.byAddress(common.HexToAddress(name))
+	}
+
+	if i, err := strconv.Atoi(name); err == nil {
+		if i < 0 || i >= len(a.accounts) {
+			return Account{}, fmt.Errorf("account index %d is out of range [0, %d)", i, len(a.accounts))
+		}
+		return a.accounts[i], nil
+	}
+
+	return Account{}, fmt.Errorf("account %q does not exist", name)
+}
+
+// Signer returns a signer for the account with the alias, address or index,
+// unlocking the account in the keystore with the passphrase on first use.
+func (a *Accounts) Signer(name string) (Signer, error) {
+	account, err := a.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if signer, exists := a.signers[account.Address]; exists {
+		return signer, nil
+	}
+
+	passphrase, err := a.passphrase(account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := a.ks.Unlock(account.Address, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	a.signers[account.Address] = signer
+
+	return signer, nil
+}
+
+// Client returns a client for the account with the alias, address or index.
+func (a *Accounts) Client(backend Backend, name string) (*Client, error) {
+	signer, err := a.Signer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignerClient(backend, signer)
+}
+
+// byAddress returns the account with the address.
+func (a *Accounts) byAddress(address common.Address) (Account, error) {
+	for _, account := range a.accounts {
+		if account.Address == address {
+			return account, nil
+		}
+	}
+
+	return Account{}, fmt.Errorf("account %s is not in the keystore", address)
+}
+
+// keyFileAddress returns the address of the encrypted key file.
+func keyFileAddress(file string) (common.Address, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var key struct {
+		Address string `json:"address"`
+		Crypto  any    `json:"crypto"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return common.Address{}, err
+	}
+
+	if key.Crypto == nil || !common.IsHexAddress(key.Address) {
+		return common.Address{}, errors.New("not a key file")
+	}
+
+	return common.HexToAddress(key.Address), nil
+}
@@ -0,0 +1,240 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestAccounts(t *testing.T) {
+	const passphrase = "123"
+
+	dir := t.TempDir()
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+
+	// Key files are named by their creation time, so the index is the order
+	// the accounts are created in.
+	var addresses []common.Address
+	for i := 0; i < 3; i++ {
+		account, err := ks.NewAccount(passphrase)
+		if err != nil {
+			t.Fatalf("unable to create account: %s", err)
+		}
+		addresses = append(addresses, account.Address)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a key file"), 0644); err != nil {
+		t.Fatalf("unable to write junk file: %s", err)
+	}
+
+	aliasFile := filepath.Join(t.TempDir(), "accounts.json")
+	data, err := json.Marshal(map[string]common.Address{
+		"owner":  addresses[0],
+		"player": addresses[1],
+	})
+	if err != nil {
+		t.Fatalf("unable to encode aliases: %s", err)
+	}
+
+	if err := os.WriteFile(aliasFile, data, 0644); err != nil {
+		t.Fatalf("unable to write alias file: %s", err)
+	}
+
+	passphraseFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passphraseFile, []byte(passphrase+"\n"), 0600); err != nil {
+		t.Fatalf("unable to write passphrase file: %s", err)
+	}
+
+	// newAccounts constructs a registry of the keystore using the source.
+	newAccounts := func(t *testing.T, source ethereum.Passphrase) *ethereum.Accounts {
+		t.Helper()
+
+		accounts, err := ethereum.NewAccounts(ethereum.AccountsConfig{
+			KeyStoreDir: dir,
+			AliasFile:   aliasFile,
+			Aliases:     map[string]common.Address{"moderator": addresses[2]},
+			Passphrase:  source,
+		})
+		if err != nil {
+			t.Fatalf("unable to create accounts: %s", err)
+		}
+
+		return accounts
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("list", func(t *testing.T) {
+		accounts := newAccounts(t, ethereum.PassphraseFile(passphraseFile))
+
+		list := accounts.List()
+		if len(list) != len(addresses) {
+			t.Fatalf("wrong number of accounts, got %d  exp %d", len(list), len(addresses))
+		}
+
+		aliases := []string{"owner", "player", "moderator"}
+		for i, account := range list {
+			if account.Index != i || account.Address != addresses[i] {
+				t.Fatalf("wrong account %d, got %d %s  exp %s", i, account.Index, account.Address, addresses[i])
+			}
+
+			if len(account.Aliases) != 1 || account.Aliases[0] != aliases[i] {
+				t.Fatalf("wrong aliases for account %d, got %v  exp %s", i, account.Aliases, aliases[i])
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("resolve", func(t *testing.T) {
+		accounts := newAccounts(t, ethereum.PassphraseFile(passphraseFile))
+
+		names := map[string]common.Address{
+			"owner":                             addresses[0],
+			"moderator":                         addresses[2],
+			"1":                                 addresses[1],
+			strings.ToLower(addresses[2].Hex()): addresses[2],
+		}
+
+		for name, exp := range names {
+			account, err := accounts.Resolve(name)
+			if err != nil {
+				t.Fatalf("unable to resolve %q: %s", name, err)
+			}
+
+			if account.Address != exp {
+				t.Fatalf("wrong account for %q, got %s  exp %s", name, account.Address, exp)
+			}
+		}
+
+		for _, name := range []string{"unknown", "3", "-1", common.Address{}.Hex()} {
+			if _, err := accounts.Resolve(name); err == nil {
+				t.Fatalf("should fail to resolve %q", name)
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("unknown alias", func(t *testing.T) {
+		_, err := ethereum.NewAccounts(ethereum.AccountsConfig{
+			KeyStoreDir: dir,
+			Aliases:     map[string]common.Address{"ghost": {}},
+			Passphrase:  ethereum.PassphraseFile(passphraseFile),
+		})
+		if err == nil {
+			t.Fatal("should reject an alias for an account outside the keystore")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("signer", func(t *testing.T) {
+		t.Setenv("TEST_KEYSTORE_PASSPHRASE", passphrase)
+
+		var calls int
+		source := func(account common.Address) (string, error) {
+			calls++
+			return ethereum.PassphraseEnv("TEST_KEYSTORE_PASSPHRASE")(account)
+		}
+
+		accounts := newAccounts(t, source)
+
+		signer, err := accounts.Signer("player")
+		if err != nil {
+			t.Fatalf("unable to unlock player: %s", err)
+		}
+
+		sig, err := signer.SignHash(context.Background(), common.Hash{1})
+		if err != nil {
+			t.Fatalf("unable to sign: %s", err)
+		}
+
+		if address, err := ethereum.FromHashSignature(sig, common.Hash{1}); err != nil || address != addresses[1] {
+			t.Fatalf("wrong signer, got %s %v  exp %s", address, err, addresses[1])
+		}
+
+		// The account stays unlocked after the first use.
+		if _, err := accounts.Signer(addresses[1].Hex()); err != nil {
+			t.Fatalf("unable to unlock player: %s", err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("passphrase should be read once, got %d", calls)
+		}
+
+		wrong := newAccounts(t, func(common.Address) (string, error) { return "wrong", nil })
+		if _, err := wrong.Signer("player"); err == nil {
+			t.Fatal("should fail to unlock with the wrong passphrase")
+		}
+
+		missing := newAccounts(t, ethereum.PassphraseEnv("TEST_KEYSTORE_MISSING"))
+		if _, err := missing.Signer("player"); err == nil {
+			t.Fatal("should fail when the environment variable isn't set")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("prompt", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("unable to create pipe: %s", err)
+		}
+		defer r.Close()
+
+		if _, err := w.WriteString(passphrase + "\r\nnext line\n"); err != nil {
+			t.Fatalf("unable to write passphrase: %s", err)
+		}
+		w.Close()
+
+		var out strings.Builder
+		accounts := newAccounts(t, ethereum.PassphrasePrompt(r, &out))
+
+		if _, err := accounts.Signer("owner"); err != nil {
+			t.Fatalf("unable to unlock owner: %s", err)
+		}
+
+		if !strings.Contains(out.String(), addresses[0].Hex()) {
+			t.Fatalf("prompt should name the account, got %q", out.String())
+		}
+
+		// Only the first line is consumed by the prompt.
+		rest := make([]byte, 64)
+		n, _ := r.Read(rest)
+		if string(rest[:n]) != "next line\n" {
+			t.Fatalf("prompt read past the line, left %q", rest[:n])
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("client", func(t *testing.T) {
+		accounts := newAccounts(t, ethereum.PassphraseFile(passphraseFile))
+
+		backend, err := ethereum.CreateSimulatedBackend(1, true, big.NewInt(100))
+		if err != nil {
+			t.Fatalf("unable to create simulated backend: %s", err)
+		}
+		defer backend.Close()
+
+		clt, err := accounts.Client(backend, "moderator")
+		if err != nil {
+			t.Fatalf("unable to create client: %s", err)
+		}
+
+		if clt.Address() != addresses[2] {
+			t.Fatalf("wrong client address, got %s  exp %s", clt.Address(), addresses[2])
+		}
+	})
+}
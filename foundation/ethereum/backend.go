@@ -4,57 +4,33 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// DialedBackend represents a dialed connect to an Ethereum node.
-type DialedBackend struct {
-	*ethclient.Client
-	network string
-	chainID *big.Int
-}
-
-// CreateDialedBackend constructs and ethereum client value
-// for the given network and establishes a connection.
-func CreateDialedBackend(ctx context.Context, network string) (*DialedBackend, error) {
-	client, err := ethclient.Dial(network)
-	if err != nil {
-		return nil, err
-	}
+// SimulatedBlockTime is the time between the blocks mined by the simulated
+// backend, unless the clock is moved.
+const SimulatedBlockTime = 10 * time.Second
 
-	chaindID, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	db := DialedBackend{
-		Client:  client,
-		network: network,
-		chainID: chaindID,
-	}
-
-	return &db, nil
-}
-
-// Network returns the network that the backend is connected to.
-func (db *DialedBackend) Network() string {
-	return db.network
-}
-
-// ChainID returns the chain id that the backend is connected to.
-func (db *DialedBackend) ChainID() *big.Int {
-	return db.chainID
-}
-
-// /////////////////////////////////////////////////////////////////
+// simulatedHeadroom is how far in the past the simulated chain starts. The
+// chain's consensus engine rejects blocks from more than a few seconds in the
+// future, so the headroom is the total the clock can ever be moved forward
+// over the life of a backend. Ten years lets a test jump past expirations
+// measured in years, and costs nothing since only the block times change.
+const simulatedHeadroom = 10 * 365 * 24 * time.Hour
 
 // SimulatedBackend represents a simulated connection to an ethereum node.
 type SimulatedBackend struct {
@@ -63,49 +39,218 @@ type SimulatedBackend struct {
 	PrivateKeys []*ecdsa.PrivateKey
 	network     string
 	chainID     *big.Int
+	labels      map[string]int
+	contracts   map[string]common.Address
+
+	mu          sync.Mutex
+	signer      types.Signer
+	queued      map[common.Address]map[uint64]*types.Transaction
+	uncommitted []*types.Transaction
+	snapshots   map[string]snapshot
+	reverts     uint64
+}
+
+// snapshot represents the state of the simulated chain saved under a name.
+type snapshot struct {
+	block       *types.Block
+	uncommitted []*types.Transaction
+	queued      map[common.Address]map[uint64]*types.Transaction
 }
 
 // CreateSimulatedBackend constructs a simulated backend and set of private keys
 // registered to the backend with a balance on 100 ETH. These private keys are
 // used with the NewSimulation call to get an Ethereum API value.
 func CreateSimulatedBackend(numAccounts int, autoCommit bool, accountBalance *big.Int) (*SimulatedBackend, error) {
-	keys := make([]*ecdsa.PrivateKey, numAccounts)
+	cfg := SimulatedConfig{
+		Accounts:   make([]SimulatedAccount, numAccounts),
+		AutoCommit: autoCommit,
+	}
+
+	for i := range cfg.Accounts {
+		cfg.Accounts[i].BalanceWei = big.NewInt(0).Mul(accountBalance, big.NewInt(1e18))
+	}
+
+	return CreateSimulatedBackendWithConfig(cfg)
+}
+
+// SimulatedAccount represents an account funded in the genesis block of a
+// simulated backend.
+type SimulatedAccount struct {
+	Label      string   // Name to look the account up by, optional
+	BalanceWei *big.Int // Balance in the genesis block (nil = 100 ETH)
+}
+
+// SimulatedContract represents a contract deployed in the genesis block of a
+// simulated backend.
+type SimulatedContract struct {
+	Address    common.Address
+	Code       []byte // Runtime bytecode, not the deployment bytecode
+	Storage    map[common.Hash]common.Hash
+	BalanceWei *big.Int
+}
+
+// SimulatedConfig represents the settings of a simulated backend. Keys are
+// derived from the Seed, or the Mnemonic and Passphrase, so the accounts are
+// the same on every run. Random keys are generated when neither is set.
+// Balances funds accounts whose keys the backend doesn't hold, such as the
+// accounts of a keystore, a nil balance is 100 ETH.
+type SimulatedConfig struct {
+	Seed       []byte
+	Mnemonic   string
+	Passphrase string
+	Accounts   []SimulatedAccount
+	Balances   map[common.Address]*big.Int
+	Contracts  map[string]SimulatedContract
+	AutoCommit bool
+}
+
+// CreateSimulatedBackendWithConfig constructs a simulated backend with the
+// accounts and contracts in the config in its genesis block. The private keys
+// are in the order of the accounts in the config.
+func CreateSimulatedBackendWithConfig(cfg SimulatedConfig) (*SimulatedBackend, error) {
+	var keys []*ecdsa.PrivateKey
+	var err error
+
+	switch {
+	case cfg.Seed != nil && cfg.Mnemonic != "":
+		return nil, errors.New("seed and mnemonic are mutually exclusive")
+	case cfg.Seed != nil:
+		keys, err = SeedKeys(cfg.Seed, len(cfg.Accounts))
+	case cfg.Mnemonic != "":
+		keys, err = MnemonicKeys(cfg.Mnemonic, cfg.Passphrase, len(cfg.Accounts))
+	default:
+		keys, err = randomKeys(len(cfg.Accounts))
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	alloc := make(core.GenesisAlloc)
+	labels := make(map[string]int)
 
-	for i := 0; i < numAccounts; i++ {
-		privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
-		if err != nil {
-			return nil, fmt.Errorf("unable to generate private key: %w", err)
+	for i, account := range cfg.Accounts {
+		if account.Label != "" {
+			if _, exists := labels[account.Label]; exists {
+				return nil, fmt.Errorf("duplicate account label %q", account.Label)
+			}
+			labels[account.Label] = i
 		}
 
-		keys[i] = privateKey
+		balance := account.BalanceWei
+		if balance == nil {
+			balance = big.NewInt(0).Mul(big.NewInt(100), big.NewInt(1e18))
+		}
+
+		address := crypto.PubkeyToAddress(keys[i].PublicKey)
+		if _, exists := alloc[address]; exists {
+			return nil, fmt.Errorf("duplicate account %s", address)
+		}
+
+		alloc[address] = core.GenesisAccount{
+			Balance: balance,
+		}
+	}
 
-		alloc[crypto.PubkeyToAddress(privateKey.PublicKey)] = core.GenesisAccount{
-			Balance: big.NewInt(0).Mul(accountBalance, big.NewInt(1e18)),
+	for address, balance := range cfg.Balances {
+		if _, exists := alloc[address]; exists {
+			return nil, fmt.Errorf("duplicate account %s", address)
+		}
+
+		if balance == nil {
+			balance = big.NewInt(0).Mul(big.NewInt(100), big.NewInt(1e18))
+		}
+
+		alloc[address] = core.GenesisAccount{
+			Balance: balance,
 		}
 	}
 
+	contracts := make(map[string]common.Address)
+
+	for name, contract := range cfg.Contracts {
+		if contract.Address == (common.Address{}) {
+			return nil, fmt.Errorf("contract %q needs an address", name)
+		}
+
+		if _, exists := alloc[contract.Address]; exists {
+			return nil, fmt.Errorf("contract %q address %s is already allocated", name, contract.Address)
+		}
+
+		balance := contract.BalanceWei
+		if balance == nil {
+			balance = big.NewInt(0)
+		}
+
+		alloc[contract.Address] = core.GenesisAccount{
+			Code:    contract.Code,
+			Storage: contract.Storage,
+			Balance: balance,
+		}
+		contracts[name] = contract.Address
+	}
+
 	maxLimit := uint64(9223372036854775807)
 	client := backends.NewSimulatedBackend(alloc, maxLimit)
 
-	// Set the clock to 5.15 minutes into the past to deal with bugs
-	// the simulated clock. Transactions won't be committed otherwise.
-	now := time.Since(time.Date(1970, time.January, 1, 0, 5, 15, 0, time.UTC))
-	client.AdjustTime(now)
+	// Blocks can't be mined ahead of the wall clock, so the chain starts in
+	// the past to leave room for moving the simulated clock forward. The
+	// genesis block is at time 0.
+	start := time.Now().Add(-simulatedHeadroom).Unix()
+	client.AdjustTime(time.Duration(start)*time.Second - SimulatedBlockTime)
 
 	client.Commit()
 
 	b := SimulatedBackend{
 		SimulatedBackend: client,
-		AutoCommit:       autoCommit,
+		AutoCommit:       cfg.AutoCommit,
 		PrivateKeys:      keys,
 		network:          "simulated",
 		chainID:          big.NewInt(1337),
+		labels:           labels,
+		contracts:        contracts,
+		signer:           types.LatestSignerForChainID(big.NewInt(1337)),
+		queued:           make(map[common.Address]map[uint64]*types.Transaction),
+		snapshots:        make(map[string]snapshot),
 	}
 
 	return &b, nil
 }
 
+// randomKeys generates the number of random private keys.
+func randomKeys(n int) ([]*ecdsa.PrivateKey, error) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate private key: %w", err)
+		}
+		keys[i] = privateKey
+	}
+
+	return keys, nil
+}
+
+// PrivateKey returns the private key of the account with the label.
+func (sb *SimulatedBackend) PrivateKey(label string) (*ecdsa.PrivateKey, error) {
+	i, exists := sb.labels[label]
+	if !exists {
+		return nil, fmt.Errorf("account %q does not exist", label)
+	}
+
+	return sb.PrivateKeys[i], nil
+}
+
+// Contract returns the address of the contract deployed in the genesis block
+// with the name.
+func (sb *SimulatedBackend) Contract(name string) (common.Address, error) {
+	address, exists := sb.contracts[name]
+	if !exists {
+		return common.Address{}, fmt.Errorf("contract %q does not exist", name)
+	}
+
+	return address, nil
+}
+
 // Network returns the network that the backend is connected to.
 func (sb *SimulatedBackend) Network() string {
 	return sb.network
@@ -117,22 +262,399 @@ func (sb *SimulatedBackend) ChainID() *big.Int {
 }
 
 // SendTransaction pipes parameters to the embedded backend and
-// also calls Commit() if sb.AutoCommit==true.
+// also calls Commit() if sb.AutoCommit==true. Like a node's transaction pool,
+// a transaction with a nonce ahead of the account's pending nonce is queued
+// until the transactions filling the gap have been sent, and a transaction
+// with the nonce of an uncommitted transaction replaces it if the fees were
+// bumped enough.
 func (sb *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	from, err := types.Sender(sb.signer, tx)
+	if err != nil {
+		return fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	pending, err := sb.PendingNonceAt(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	// The embedded backend panics on transactions that can't be applied,
+	// so reject the ones a node's transaction pool would reject.
+	balance, err := sb.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return err
+	}
+
+	if balance.Cmp(tx.Cost()) < 0 {
+		return fmt.Errorf("%w: address %v have %v want %v", core.ErrInsufficientFunds, from, balance, tx.Cost())
+	}
+
+	switch {
+	case tx.Nonce() < pending:
+		replaced, err := sb.replacePending(ctx, from, tx)
+		if replaced || err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: address %v, tx: %d state: %d", core.ErrNonceTooLow, from, tx.Nonce(), pending)
+
+	case tx.Nonce() > pending:
+		if queuedTx, exists := sb.queued[from][tx.Nonce()]; exists {
+			if err := checkReplacement(queuedTx, tx); err != nil {
+				return err
+			}
+		}
+		if sb.queued[from] == nil {
+			sb.queued[from] = make(map[uint64]*types.Transaction)
+		}
+		sb.queued[from][tx.Nonce()] = tx
+		return nil
+	}
+
+	if err := sb.sendTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	// Send any queued transactions that are now executable.
+	for nonce := tx.Nonce() + 1; ; nonce++ {
+		queuedTx, exists := sb.queued[from][nonce]
+		if !exists {
+			break
+		}
+		delete(sb.queued[from], nonce)
+
+		if err := sb.sendTransaction(ctx, queuedTx); err != nil {
+			return fmt.Errorf("sending queued transaction %d: %w", nonce, err)
+		}
+	}
+
+	return nil
+}
+
+// replacePending replaces the uncommitted transaction with the same sender
+// and nonce, if there is one, by rebuilding the pending block.
+func (sb *SimulatedBackend) replacePending(ctx context.Context, from common.Address, tx *types.Transaction) (bool, error) {
+	replaced := -1
+	for i, pendingTx := range sb.uncommitted {
+		sender, err := types.Sender(sb.signer, pendingTx)
+		if err != nil || sender != from || pendingTx.Nonce() != tx.Nonce() {
+			continue
+		}
+
+		if err := checkReplacement(pendingTx, tx); err != nil {
+			return false, err
+		}
+
+		replaced = i
+		break
+	}
+
+	if replaced == -1 {
+		return false, nil
+	}
+
+	txs := sb.uncommitted
+	txs[replaced] = tx
+
+	sb.SimulatedBackend.Rollback()
+	sb.uncommitted = nil
+
+	return true, sb.resend(ctx, txs)
+}
+
+// resend sends the transactions to the embedded backend's fresh pending
+// block, rebuilding the block that was rolled back.
+func (sb *SimulatedBackend) resend(ctx context.Context, txs []*types.Transaction) error {
+	for _, tx := range txs {
+		if err := sb.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("rebuilding pending block: %w", err)
+		}
+		sb.uncommitted = append(sb.uncommitted, tx)
+	}
+
+	return nil
+}
+
+// checkReplacement validates the transaction can replace the existing
+// transaction with the same nonce.
+func checkReplacement(existing *types.Transaction, tx *types.Transaction) error {
+	if existing.Hash() == tx.Hash() {
+		return fmt.Errorf("already known: hash %v", tx.Hash())
+	}
+
+	return checkPriceBump(existing, tx)
+}
+
+// sendTransaction sends the transaction to the embedded backend and
+// commits it if auto commit is enabled.
+func (sb *SimulatedBackend) sendTransaction(ctx context.Context, tx *types.Transaction) error {
 	if err := sb.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
 		return err
 	}
+	sb.uncommitted = append(sb.uncommitted, tx)
 
 	if sb.AutoCommit {
-		sb.Commit()
+		sb.commit()
 	}
 
 	return nil
 }
 
-// SetTime shifts the time of the simulated clock.
-// It can only be called on empty blocks.
+// Commit imports all the pending transactions as a single block and starts
+// a fresh new state.
+func (sb *SimulatedBackend) Commit() common.Hash {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	return sb.commit()
+}
+
+// commit performs the work of Commit and expects the lock to be held.
+func (sb *SimulatedBackend) commit() common.Hash {
+	sb.uncommitted = nil
+	return sb.SimulatedBackend.Commit()
+}
+
+// Rollback aborts all pending transactions, reverting to the last committed
+// state.
+func (sb *SimulatedBackend) Rollback() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.uncommitted = nil
+	sb.SimulatedBackend.Rollback()
+}
+
+// CallContract executes a contract call. Unlike the embedded backend, calls
+// against a historical block are supported so a failed transaction can be
+// replayed against the block it was mined in.
+func (sb *SimulatedBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	chain := sb.Blockchain()
+
+	if blockNumber == nil || blockNumber.Cmp(chain.CurrentBlock().Number()) == 0 {
+		return sb.SimulatedBackend.CallContract(ctx, call, blockNumber)
+	}
+
+	block := chain.GetBlockByNumber(blockNumber.Uint64())
+	if block == nil {
+		return nil, ethereum.NotFound
+	}
+
+	stateDB, err := chain.StateAt(block.Root())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving state at block %v: %w", blockNumber, err)
+	}
+
+	if call.Gas == 0 {
+		call.Gas = block.GasLimit()
+	}
+
+	if call.Value == nil {
+		call.Value = big.NewInt(0)
+	}
+
+	// Gas is free for the call and the caller can afford any value, the
+	// same as a call against the latest block.
+	stateDB.SetBalance(call.From, math.MaxBig256)
+	zero := big.NewInt(0)
+	msg := types.NewMessage(call.From, call.To, 0, call.Value, call.Gas, zero, zero, zero, call.Data, call.AccessList, true)
+
+	blockContext := core.NewEVMBlockContext(block.Header(), chain, nil)
+	evm := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), stateDB, chain.Config(), vm.Config{NoBaseFee: true})
+
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Revert()) > 0 {
+		return nil, &callError{err: result.Err, data: result.Revert()}
+	}
+
+	return result.Return(), result.Err
+}
+
+// Now returns the time of the simulated clock, which is the time of the
+// latest block. The next block is mined SimulatedBlockTime later.
+func (sb *SimulatedBackend) Now() time.Time {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	return time.Unix(int64(sb.Blockchain().CurrentBlock().Time()), 0)
+}
+
+// AdvanceTime moves the simulated clock forward by the duration, truncated
+// to seconds. See JumpTime.
+func (sb *SimulatedBackend) AdvanceTime(d time.Duration) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	latest := sb.Blockchain().CurrentBlock().Time()
+
+	return sb.mineEmpty(latest+uint64(d/time.Second), 1)
+}
+
+// SetTime moves the simulated clock to the specified time like JumpTime,
+// ignoring a time that can't be set.
+//
+// Deprecated: Use JumpTime, which reports when the time can't be set.
 func (sb *SimulatedBackend) SetTime(t time.Time) {
-	sb.AdjustTime(time.Since(t))
-	sb.Commit()
-}
\ No newline at end of file
+	sb.JumpTime(t)
+}
+
+// JumpTime moves the simulated clock to the specified time by mining an
+// empty block at that time. Pending transactions stay pending and are mined
+// in the next block, SimulatedBlockTime later. The time must be after the
+// latest block and can't be ahead of the wall clock.
+func (sb *SimulatedBackend) JumpTime(t time.Time) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	return sb.mineEmpty(uint64(t.Unix()), 1)
+}
+
+// MineBlocks mines the number of empty blocks, SimulatedBlockTime apart.
+// Pending transactions stay pending and are mined in the block after.
+func (sb *SimulatedBackend) MineBlocks(n int) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if n < 1 {
+		return fmt.Errorf("invalid number of blocks %d", n)
+	}
+
+	latest := sb.Blockchain().CurrentBlock().Time()
+
+	return sb.mineEmpty(latest+uint64(SimulatedBlockTime/time.Second), n)
+}
+
+// mineEmpty mines the number of empty blocks, the first at the specified
+// unix time. The embedded backend can only shift the time of an empty
+// pending block, so the pending transactions are set aside and sent again
+// once the empty blocks are mined.
+func (sb *SimulatedBackend) mineEmpty(first uint64, n int) error {
+	latest := sb.Blockchain().CurrentBlock().Time()
+	if first <= latest {
+		return fmt.Errorf("time %d must be after the latest block at %d", first, latest)
+	}
+
+	interval := uint64(SimulatedBlockTime / time.Second)
+	last := first + uint64(n-1)*interval
+	if now := uint64(time.Now().Unix()); last > now {
+		return fmt.Errorf("time %d is ahead of the wall clock at %d", last, now)
+	}
+
+	txs := sb.uncommitted
+	sb.SimulatedBackend.Rollback()
+	sb.uncommitted = nil
+
+	offset := time.Duration(first-latest)*time.Second - SimulatedBlockTime
+	if err := sb.SimulatedBackend.AdjustTime(offset); err != nil {
+		return err
+	}
+	sb.SimulatedBackend.Commit()
+
+	for i := 1; i < n; i++ {
+		sb.SimulatedBackend.Commit()
+	}
+
+	return sb.resend(context.Background(), txs)
+}
+
+// Snapshot saves the state of the chain under the name, including the
+// pending and queued transactions, replacing any snapshot with the same name.
+// The state of a snapshot is kept in memory for the life of the backend.
+func (sb *SimulatedBackend) Snapshot(name string) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	block := sb.Blockchain().CurrentBlock()
+
+	// Keep the block's state from being garbage collected as the chain
+	// grows, so it's still there to revert to.
+	sb.Blockchain().StateCache().TrieDB().Reference(block.Root(), common.Hash{})
+
+	sb.snapshots[name] = snapshot{
+		block:       block,
+		uncommitted: append([]*types.Transaction(nil), sb.uncommitted...),
+		queued:      copyQueued(sb.queued),
+	}
+}
+
+// Revert rewinds the chain to the snapshot with the name, dropping every
+// block mined since and restoring the pending and queued transactions. The
+// snapshot is kept so it can be reverted to again. The nonce managers of
+// clients bound to the backend resync before handing out their next nonce.
+func (sb *SimulatedBackend) Revert(name string) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	snap, exists := sb.snapshots[name]
+	if !exists {
+		return fmt.Errorf("snapshot %q does not exist", name)
+	}
+
+	chain := sb.Blockchain()
+	number := snap.block.NumberU64()
+
+	if hash := chain.GetCanonicalHash(number); hash != snap.block.Hash() {
+		return fmt.Errorf("snapshot %q block %d is no longer canonical", name, number)
+	}
+
+	if err := chain.SetHead(number); err != nil {
+		return fmt.Errorf("rewinding to block %d: %w", number, err)
+	}
+
+	if hash := chain.CurrentBlock().Hash(); hash != snap.block.Hash() {
+		return fmt.Errorf("rewound to block %s, expected %s", hash, snap.block.Hash())
+	}
+
+	sb.SimulatedBackend.Rollback()
+	sb.uncommitted = nil
+	sb.queued = copyQueued(snap.queued)
+	sb.reverts++
+
+	return sb.resend(context.Background(), snap.uncommitted)
+}
+
+// revertCount returns the number of times the chain has been reverted.
+func (sb *SimulatedBackend) revertCount() uint64 {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	return sb.reverts
+}
+
+// copyQueued returns a copy of the queued transactions.
+func copyQueued(queued map[common.Address]map[uint64]*types.Transaction) map[common.Address]map[uint64]*types.Transaction {
+	cp := make(map[common.Address]map[uint64]*types.Transaction, len(queued))
+	for from, txs := range queued {
+		cp[from] = make(map[uint64]*types.Transaction, len(txs))
+		for nonce, tx := range txs {
+			cp[from][nonce] = tx
+		}
+	}
+
+	return cp
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// callError is a reverted call carrying the revert data, the same as the
+// error a node returns over RPC.
+type callError struct {
+	err  error
+	data []byte
+}
+
+// Error implements the error interface.
+func (ce *callError) Error() string {
+	return ce.err.Error()
+}
+
+// ErrorData returns the hex encoded revert data.
+func (ce *callError) ErrorData() interface{} {
+	return hexutil.Encode(ce.data)
+}
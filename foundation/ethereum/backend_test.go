@@ -0,0 +1,438 @@
+package ethereum_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestSimulatedClock(t *testing.T) {
+	ctx := context.Background()
+
+	// Auto commit is disabled so a transaction can be left pending while
+	// the clock moves.
+	backend, err := ethereum.CreateSimulatedBackend(2, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	to := crypto.PubkeyToAddress(backend.PrivateKeys[1].PublicKey)
+
+	// latest returns the number and time of the latest block.
+	latest := func(t *testing.T) (uint64, time.Time) {
+		t.Helper()
+
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+
+		return header.Number.Uint64(), time.Unix(int64(header.Time), 0)
+	}
+
+	// expectPending sends a transfer that's left pending while the clock
+	// moves, then mines it and checks it's in the block after the empty
+	// blocks.
+	expectPending := func(t *testing.T, move func() error, blocks uint64) {
+		t.Helper()
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transfer: %s", err)
+		}
+
+		number, _ := latest(t)
+
+		if err := move(); err != nil {
+			t.Fatalf("unable to move the clock: %s", err)
+		}
+
+		if got, _ := latest(t); got != number+blocks {
+			t.Fatalf("wrong latest block, got %d  exp %d", got, number+blocks)
+		}
+
+		if _, isPending, err := client.TransactionByHash(ctx, tx.Hash()); err != nil || !isPending {
+			t.Fatalf("transfer should still be pending, pending %t err %v", isPending, err)
+		}
+
+		backend.Commit()
+
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			t.Fatalf("unable to retrieve receipt: %s", err)
+		}
+
+		if exp := number + blocks + 1; receipt.BlockNumber.Uint64() != exp {
+			t.Fatalf("wrong block for transfer, got %d  exp %d", receipt.BlockNumber, exp)
+		}
+	}
+
+	_, start := latest(t)
+	if behind := time.Since(start); behind < 24*time.Hour {
+		t.Fatalf("chain should start well before the wall clock, starts %s", start)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("advance time", func(t *testing.T) {
+		_, before := latest(t)
+
+		expectPending(t, func() error { return backend.AdvanceTime(time.Hour) }, 1)
+
+		_, after := latest(t)
+		after = after.Add(-ethereum.SimulatedBlockTime)
+
+		if !after.Equal(before.Add(time.Hour)) {
+			t.Fatalf("wrong time after advancing, got %s  exp %s", after, before.Add(time.Hour))
+		}
+
+		if err := backend.AdvanceTime(time.Millisecond); err == nil {
+			t.Fatal("should fail to advance by less than a second")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("set time", func(t *testing.T) {
+		_, before := latest(t)
+		target := before.Add(24 * time.Hour)
+
+		if err := backend.JumpTime(target); err != nil {
+			t.Fatalf("unable to set time: %s", err)
+		}
+
+		if now := backend.Now(); !now.Equal(target) {
+			t.Fatalf("wrong time, got %s  exp %s", now, target)
+		}
+
+		if err := backend.JumpTime(before); err == nil {
+			t.Fatal("should fail to set the time into the past")
+		}
+
+		if err := backend.JumpTime(time.Now().Add(time.Hour)); err == nil {
+			t.Fatal("should fail to set the time ahead of the wall clock")
+		}
+
+		expectPending(t, func() error { return backend.JumpTime(target.Add(time.Minute)) }, 1)
+
+		backend.SetTime(target.Add(time.Hour))
+		if now := backend.Now(); !now.Equal(target.Add(time.Hour)) {
+			t.Fatalf("wrong time, got %s  exp %s", now, target.Add(time.Hour))
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("mine blocks", func(t *testing.T) {
+		_, before := latest(t)
+
+		expectPending(t, func() error { return backend.MineBlocks(5) }, 5)
+
+		_, after := latest(t)
+		if exp := before.Add(6 * ethereum.SimulatedBlockTime); !after.Equal(exp) {
+			t.Fatalf("wrong time after mining, got %s  exp %s", after, exp)
+		}
+
+		if err := backend.MineBlocks(0); err == nil {
+			t.Fatal("should fail to mine no blocks")
+		}
+	})
+}
+
+func TestSimulatedSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	// Auto commit is disabled so a snapshot can be taken with a pending
+	// transaction.
+	backend, err := ethereum.CreateSimulatedBackend(2, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	to := crypto.PubkeyToAddress(backend.PrivateKeys[1].PublicKey)
+
+	// send sends a transfer of 1 GWei.
+	send := func(t *testing.T) *types.Transaction {
+		t.Helper()
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transfer: %s", err)
+		}
+
+		return tx
+	}
+
+	// expectBalance checks the receiver's balance in GWei above the 100
+	// ether it started with.
+	expectBalance := func(t *testing.T, gwei int64) {
+		t.Helper()
+
+		balance, err := client.BalanceAt(ctx, to, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		exp := new(big.Int).Add(new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)), big.NewInt(gwei*1e9))
+		if balance.Cmp(exp) != 0 {
+			t.Fatalf("wrong balance, got %v  exp %v", balance, exp)
+		}
+	}
+
+	// revert reverts to the snapshot, the client's nonces resync on their
+	// own.
+	revert := func(t *testing.T, name string) {
+		t.Helper()
+
+		if err := backend.Revert(name); err != nil {
+			t.Fatalf("unable to revert: %s", err)
+		}
+	}
+
+	send(t)
+	backend.Commit()
+	expectBalance(t, 1)
+
+	pending := send(t)
+	backend.Snapshot("funded")
+	snapshotTime := backend.Now()
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("revert", func(t *testing.T) {
+		backend.Commit()
+		send(t)
+		backend.Commit()
+		expectBalance(t, 3)
+
+		// A nonce still held when the chain is reverted isn't left as a gap.
+		if _, err := client.Nonces().Next(ctx); err != nil {
+			t.Fatalf("unable to take nonce: %s", err)
+		}
+
+		revert(t, "funded")
+		expectBalance(t, 1)
+
+		if now := backend.Now(); !now.Equal(snapshotTime) {
+			t.Fatalf("wrong time after revert, got %s  exp %s", now, snapshotTime)
+		}
+
+		if _, isPending, err := client.TransactionByHash(ctx, pending.Hash()); err != nil || !isPending {
+			t.Fatalf("transfer should be pending again, pending %t err %v", isPending, err)
+		}
+
+		send(t)
+		backend.Commit()
+		expectBalance(t, 3)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("revert again", func(t *testing.T) {
+		// More blocks than the chain keeps the state of in memory.
+		if err := backend.MineBlocks(200); err != nil {
+			t.Fatalf("unable to mine blocks: %s", err)
+		}
+
+		revert(t, "funded")
+		expectBalance(t, 1)
+
+		backend.Commit()
+		expectBalance(t, 2)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("unknown", func(t *testing.T) {
+		if err := backend.Revert("unknown"); err == nil {
+			t.Fatal("should fail to revert to an unknown snapshot")
+		}
+	})
+}
+
+func TestSimulatedAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	// The accounts of the well known development mnemonic used by Hardhat
+	// and Foundry.
+	const mnemonic = "test test test test test test test test test test test junk"
+	expAddresses := []common.Address{
+		common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+	}
+
+	contract := ethereum.SimulatedContract{
+		Address:    common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		Code:       common.FromHex("0x600160005260206000f3"),
+		Storage:    map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(42))},
+		BalanceWei: big.NewInt(7),
+	}
+
+	cfg := ethereum.SimulatedConfig{
+		Mnemonic: mnemonic,
+		Accounts: []ethereum.SimulatedAccount{
+			{Label: "deployer"},
+			{Label: "winner", BalanceWei: big.NewInt(1_000)},
+		},
+		Balances: map[common.Address]*big.Int{
+			common.HexToAddress("0x00000000000000000000000000000000000000bb"): big.NewInt(9),
+		},
+		Contracts: map[string]ethereum.SimulatedContract{
+			"one": contract,
+		},
+		AutoCommit: true,
+	}
+
+	backend, err := ethereum.CreateSimulatedBackendWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("mnemonic", func(t *testing.T) {
+		for i, label := range []string{"deployer", "winner"} {
+			key, err := backend.PrivateKey(label)
+			if err != nil {
+				t.Fatalf("unable to retrieve %s: %s", label, err)
+			}
+
+			if got := crypto.PubkeyToAddress(key.PublicKey); got != expAddresses[i] {
+				t.Fatalf("wrong address for %s, got %s  exp %s", label, got, expAddresses[i])
+			}
+		}
+
+		if _, err := backend.PrivateKey("unknown"); err == nil {
+			t.Fatal("should fail for an unknown label")
+		}
+
+		if _, err := ethereum.MnemonicKeys("test test test", "", 1); err == nil {
+			t.Fatal("should fail for an invalid mnemonic")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("balances", func(t *testing.T) {
+		exp := map[common.Address]*big.Int{
+			expAddresses[0]:  new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)),
+			expAddresses[1]:  big.NewInt(1_000),
+			contract.Address: big.NewInt(7),
+			common.HexToAddress("0x00000000000000000000000000000000000000bb"): big.NewInt(9),
+		}
+
+		for address, balance := range exp {
+			got, err := backend.BalanceAt(ctx, address, nil)
+			if err != nil {
+				t.Fatalf("unable to retrieve balance: %s", err)
+			}
+
+			if got.Cmp(balance) != 0 {
+				t.Fatalf("wrong balance for %s, got %v  exp %v", address, got, balance)
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("contracts", func(t *testing.T) {
+		address, err := backend.Contract("one")
+		if err != nil {
+			t.Fatalf("unable to retrieve contract: %s", err)
+		}
+
+		code, err := backend.CodeAt(ctx, address, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve code: %s", err)
+		}
+
+		if !bytes.Equal(code, contract.Code) {
+			t.Fatalf("wrong code, got %x  exp %x", code, contract.Code)
+		}
+
+		slot, err := backend.StorageAt(ctx, address, common.Hash{}, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve storage: %s", err)
+		}
+
+		if got := new(big.Int).SetBytes(slot); got.Int64() != 42 {
+			t.Fatalf("wrong storage, got %v  exp 42", got)
+		}
+
+		bad := cfg
+		bad.Contracts = map[string]ethereum.SimulatedContract{"clash": {Address: expAddresses[0]}}
+		if _, err := ethereum.CreateSimulatedBackendWithConfig(bad); err == nil {
+			t.Fatal("should fail for a contract at an account's address")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("seed", func(t *testing.T) {
+		cfg := ethereum.SimulatedConfig{
+			Seed:     []byte("smartcontract"),
+			Accounts: make([]ethereum.SimulatedAccount, 3),
+		}
+
+		// addresses creates a backend from the config and returns its
+		// addresses.
+		addresses := func(t *testing.T) []common.Address {
+			t.Helper()
+
+			backend, err := ethereum.CreateSimulatedBackendWithConfig(cfg)
+			if err != nil {
+				t.Fatalf("unable to create simulated backend: %s", err)
+			}
+			defer backend.Close()
+
+			var addrs []common.Address
+			for _, key := range backend.PrivateKeys {
+				addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+			}
+
+			return addrs
+		}
+
+		first := addresses(t)
+		second := addresses(t)
+
+		for i := range first {
+			if first[i] != second[i] {
+				t.Fatalf("account %d differs between runs, got %s  exp %s", i, second[i], first[i])
+			}
+		}
+
+		if first[0] == first[1] {
+			t.Fatal("accounts should differ from each other")
+		}
+	})
+}
@@ -111,24 +111,36 @@ func (c *Converter) USD2GWei(amountUSD *big.Float) *big.Float {
 func (c *Converter) CalculateTransactionDetails(tx *types.Transaction) TransactionDetails {
 	return TransactionDetails{
 		Hash:              tx.Hash().Hex(),
+		Type:              tx.Type(),
 		Nonce:             tx.Nonce(),
 		GasLimit:          tx.Gas(),
 		GasOfferPriceGWei: Wei2GWei(tx.GasPrice()).String(),
+		GasFeeCapGWei:     Wei2GWei(tx.GasFeeCap()).String(),
+		GasTipCapGWei:     Wei2GWei(tx.GasTipCap()).String(),
 		Value:             Wei2GWei(tx.Cost()).String(),
 		MaxGasPriceGWei:   Wei2GWei(tx.Cost()).String(),
 		MaxGasPriceUSD:    c.Wei2USD(tx.Cost()),
 	}
 }
 
-// CalculateReceiptDetails performs calculations on the receipt.
-func (c *Converter) CalculateReceiptDetails(receipt *types.Receipt, gasPrice *big.Int) ReceiptDetails {
+// CalculateReceiptDetails performs calculations on the receipt. The base fee
+// is the base fee of the block the transaction was mined in, and is nil for
+// blocks mined before EIP-1559 was activated.
+func (c *Converter) CalculateReceiptDetails(receipt *types.Receipt, tx *types.Transaction, baseFee *big.Int) ReceiptDetails {
+	gasPrice, tip := EffectiveGasPrice(tx, baseFee)
 	cost := big.NewInt(0).Mul(big.NewInt(int64(receipt.GasUsed)), gasPrice)
 
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
 	return ReceiptDetails{
 		Status:        receipt.Status,
 		GasUsed:       receipt.GasUsed,
 		GasPriceGWei:  Wei2GWei(gasPrice).String(),
 		GasPriceUSD:   c.Wei2USD(gasPrice),
+		BaseFeeGWei:   Wei2GWei(baseFee).String(),
+		TipGWei:       Wei2GWei(tip).String(),
 		FinalCostGWei: Wei2GWei(cost).String(),
 		FinalCostUSD:  c.Wei2USD(cost),
 	}
@@ -197,6 +209,12 @@ func ExtractLogData(abiMetaData string, receipt *types.Receipt) ([]LogData, erro
 	return logData, nil
 }
 
+// ReceiptLogs pulls extra information from the receipt's logs using the
+// converter's ABI.
+func (c *Converter) ReceiptLogs(receipt *types.Receipt) ([]LogData, error) {
+	return ExtractLogData(c.abiMetaData, receipt)
+}
+
 // FmtBalanceSheet produces a easy to read format of the starting and ending
 // balance for the connected account.
 func (c *Converter) FmtBalanceSheet(startingBalance *big.Int, endingBalance *big.Int) string {
@@ -216,8 +234,8 @@ func (c *Converter) FmtTransaction(tx *types.Transaction) string {
 }
 
 // FmtTransactionReceipt produces a easy to read format of the specified receipt.
-func (c *Converter) FmtTransactionReceipt(receipt *types.Receipt, gasPrice *big.Int) string {
-	rcd := c.CalculateReceiptDetails(receipt, gasPrice)
+func (c *Converter) FmtTransactionReceipt(receipt *types.Receipt, tx *types.Transaction, baseFee *big.Int) string {
+	rcd := c.CalculateReceiptDetails(receipt, tx, baseFee)
 
 	var b bytes.Buffer
 
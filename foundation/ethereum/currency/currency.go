@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	ethUnit "github.com/DeOne4eg/eth-unit-converter"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 const (
@@ -27,6 +28,21 @@ func GWei2Wei(amountGWei *big.Float) *big.Int {
 	return unit.Wei()
 }
 
+// EffectiveGasPrice calculates the price per unit of gas that was paid for the
+// transaction and the portion of that price which was paid to the miner as a
+// tip. The base fee is the base fee of the block the transaction was mined in,
+// and is nil for blocks mined before EIP-1559 was activated.
+func EffectiveGasPrice(tx *types.Transaction, baseFee *big.Int) (gasPrice *big.Int, tip *big.Int) {
+	if baseFee == nil {
+		return tx.GasPrice(), tx.GasPrice()
+	}
+
+	tip = tx.EffectiveGasTipValue(baseFee)
+	gasPrice = big.NewInt(0).Add(baseFee, tip)
+
+	return gasPrice, tip
+}
+
 // /////////////////////////////////////////////////////////////////
 
 // captureETH2USD retrieves the current USD price of 1 ETH from CoinMarketCap.
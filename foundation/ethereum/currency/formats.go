@@ -12,9 +12,12 @@ func formatTxCostDetails(tcd TransactionDetails) string {
 	fmt.Fprintf(&b, "\nTransaction Details\n")
 	fmt.Fprintf(&b, "----------------------------------------------------\n")
 	fmt.Fprintf(&b, "hash            : %v\n", tcd.Hash)
+	fmt.Fprintf(&b, "type            : %v\n", tcd.Type)
 	fmt.Fprintf(&b, "nonce           : %v\n", tcd.Nonce)
 	fmt.Fprintf(&b, "gas limit       : %v\n", tcd.GasLimit)
 	fmt.Fprintf(&b, "gas offer price : %v GWei\n", tcd.GasOfferPriceGWei)
+	fmt.Fprintf(&b, "gas fee cap     : %v GWei\n", tcd.GasFeeCapGWei)
+	fmt.Fprintf(&b, "gas tip cap     : %v GWei\n", tcd.GasTipCapGWei)
 	fmt.Fprintf(&b, "value           : %v GWei\n", tcd.Value)
 	fmt.Fprintf(&b, "max gas price   : %v GWei\n", tcd.MaxGasPriceGWei)
 	fmt.Fprintf(&b, "max gas price   : %v USD\n", tcd.MaxGasPriceUSD)
@@ -32,6 +35,8 @@ func formatReceiptCostDetails(rcd ReceiptDetails) string {
 	fmt.Fprintf(&b, "gas used        : %v\n", rcd.GasUsed)
 	fmt.Fprintf(&b, "gas price       : %v GWei\n", rcd.GasPriceGWei)
 	fmt.Fprintf(&b, "gas price       : %v USD\n", rcd.GasPriceUSD)
+	fmt.Fprintf(&b, "base fee        : %v GWei\n", rcd.BaseFeeGWei)
+	fmt.Fprintf(&b, "tip             : %v GWei\n", rcd.TipGWei)
 	fmt.Fprintf(&b, "final gas cost  : %v GWei\n", rcd.FinalCostGWei)
 	fmt.Fprintf(&b, "final gas cost  : %v USD\n", rcd.FinalCostUSD)
 
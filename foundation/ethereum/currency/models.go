@@ -49,35 +49,40 @@ type ResponseUSD2ETH struct {
 
 // TransactionDetails holds details about a transaction and its cost.
 type TransactionDetails struct {
-	Hash              string
-	Nonce             uint64
-	GasLimit          uint64
-	GasOfferPriceGWei string
-	Value             string
-	MaxGasPriceGWei   string
-	MaxGasPriceUSD    string
+	Hash              string `json:"hash"`
+	Type              uint8  `json:"type"`
+	Nonce             uint64 `json:"nonce"`
+	GasLimit          uint64 `json:"gasLimit"`
+	GasOfferPriceGWei string `json:"gasOfferPriceGWei"`
+	GasFeeCapGWei     string `json:"gasFeeCapGWei"`
+	GasTipCapGWei     string `json:"gasTipCapGWei"`
+	Value             string `json:"value"`
+	MaxGasPriceGWei   string `json:"maxGasPriceGWei"`
+	MaxGasPriceUSD    string `json:"maxGasPriceUSD"`
 }
 
 // ReceiptDetails holds details about a receipt and its cost.
 type ReceiptDetails struct {
-	Status        uint64
-	GasUsed       uint64
-	GasPriceGWei  string
-	GasPriceUSD   string
-	FinalCostGWei string
-	FinalCostUSD  string
+	Status        uint64 `json:"status"`
+	GasUsed       uint64 `json:"gasUsed"`
+	GasPriceGWei  string `json:"gasPriceGWei"`
+	GasPriceUSD   string `json:"gasPriceUSD"`
+	BaseFeeGWei   string `json:"baseFeeGWei"`
+	TipGWei       string `json:"tipGWei"`
+	FinalCostGWei string `json:"finalCostGWei"`
+	FinalCostUSD  string `json:"finalCostUSD"`
 }
 
 // BalanceDiff performs calculations on the starting and ending balance.
 type BalanceDiff struct {
-	BeforeGWei string
-	AfterGWei  string
-	DiffGWei   string
-	DiffUSD    string
+	BeforeGWei string `json:"beforeGWei"`
+	AfterGWei  string `json:"afterGWei"`
+	DiffGWei   string `json:"diffGWei"`
+	DiffUSD    string `json:"diffUSD"`
 }
 
 // LogData represents data we can pull from events in the receipt logs.
 type LogData struct {
-	EventName string
-	Data      map[string]any
+	EventName string         `json:"eventName"`
+	Data      map[string]any `json:"data"`
 }
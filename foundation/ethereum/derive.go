@@ -0,0 +1,119 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// SeedKeys derives the number of private keys from the seed. Key i is
+// keccak256(seed || uint32(i)), so the same seed always produces the same
+// accounts.
+func SeedKeys(seed []byte, n int) ([]*ecdsa.PrivateKey, error) {
+	if len(seed) == 0 {
+		return nil, errors.New("seed is required")
+	}
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		index := make([]byte, 4)
+		binary.BigEndian.PutUint32(index, uint32(i))
+
+		key, err := crypto.ToECDSA(crypto.Keccak256(seed, index))
+		if err != nil {
+			return nil, fmt.Errorf("deriving key %d: %w", i, err)
+		}
+		keys[i] = key
+	}
+
+	return keys, nil
+}
+
+// MnemonicKeys derives the number of private keys from the BIP-39 mnemonic
+// and passphrase at the BIP-44 paths m/44'/60'/0'/0/i, the same accounts a
+// wallet restored from the mnemonic would have.
+func MnemonicKeys(mnemonic string, passphrase string, n int) ([]*ecdsa.PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	next := accounts.DefaultIterator(accounts.DefaultBaseDerivationPath)
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		path := next()
+
+		key, err := DeriveKey(seed, path)
+		if err != nil {
+			return nil, fmt.Errorf("deriving key at %s: %w", path, err)
+		}
+		keys[i] = key
+	}
+
+	return keys, nil
+}
+
+// DeriveKey derives the private key at the BIP-32 path from the seed.
+func DeriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, chainCode, err := bip32Key([]byte("Bitcoin seed"), seed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range path {
+		var data []byte
+		switch {
+		case index >= 0x80000000:
+			data = append([]byte{0}, math.PaddedBigBytes(key, 32)...)
+		default:
+			privateKey, err := crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&privateKey.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		if key, chainCode, err = bip32Key(chainCode, data, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+}
+
+// bip32Key computes HMAC-SHA512(chainCode, data) and returns the left half
+// added to the parent key as the child key, and the right half as the child
+// chain code. A nil parent returns the master key.
+func bip32Key(chainCode []byte, data []byte, parent *big.Int) (*big.Int, []byte, error) {
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Cmp(n) >= 0 {
+		return nil, nil, errors.New("derived key is out of range")
+	}
+
+	if parent != nil {
+		key.Add(key, parent).Mod(key, n)
+	}
+
+	if key.Sign() == 0 {
+		return nil, nil, errors.New("derived key is zero")
+	}
+
+	return key, sum[32:], nil
+}
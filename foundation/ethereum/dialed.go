@@ -0,0 +1,747 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrNoHealthyEndpoint is returned when none of the endpoints of a dialed
+// backend can be reached.
+var ErrNoHealthyEndpoint = errors.New("no healthy endpoint")
+
+// ErrChainIDMismatch is returned when an endpoint reports a different chain
+// id than the rest of the endpoints.
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// Default retry settings of a dialed backend.
+const (
+	defaultRetries    = 3
+	defaultBackoff    = 250 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+)
+
+// DialedConfig represents the settings of a dialed backend.
+type DialedConfig struct {
+	Endpoints      []string      // HTTP, WS or IPC, in order of preference
+	ChainID        *big.Int      // nil = the chain id of the first endpoint reached
+	Retries        int           // 0 = 3, attempts after the first, negative = none
+	Backoff        time.Duration // 0 = 250ms, doubled after every attempt
+	MaxBackoff     time.Duration // 0 = 5s
+	HealthInterval time.Duration // 0 = endpoints are only checked on failure
+}
+
+// DialedBackend represents a dialed connection to one of a list of Ethereum
+// nodes. Calls go to the first healthy endpoint in the list. When an
+// endpoint can't be reached the call fails over to the next one, and reads
+// are retried with backoff. Every endpoint must report the same chain id,
+// so transactions are never signed for the wrong chain.
+type DialedBackend struct {
+	cfg     DialedConfig
+	chainID *big.Int
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	active    int
+
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// endpoint represents one of the nodes of a dialed backend. The client is nil
+// until the endpoint is dialed and after it fails.
+type endpoint struct {
+	url    string
+	client *ethclient.Client
+}
+
+// CreateDialedBackend constructs and ethereum client value
+// for the given network and establishes a connection.
+func CreateDialedBackend(ctx context.Context, network string) (*DialedBackend, error) {
+	return CreateDialedBackendWithConfig(ctx, DialedConfig{
+		Endpoints: []string{network},
+	})
+}
+
+// CreateDialedBackendWithConfig constructs a backend for the endpoints. Every
+// endpoint is dialed and must report the same chain id, endpoints that
+// can't be reached yet are checked when they're first used. At least one
+// endpoint must be reachable.
+func CreateDialedBackendWithConfig(ctx context.Context, cfg DialedConfig) (*DialedBackend, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("at least one endpoint is required")
+	}
+
+	switch {
+	case cfg.Retries == 0:
+		cfg.Retries = defaultRetries
+	case cfg.Retries < 0:
+		cfg.Retries = 0
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+
+	db := DialedBackend{
+		cfg:      cfg,
+		chainID:  cfg.ChainID,
+		active:   -1,
+		shutdown: make(chan struct{}),
+	}
+
+	for _, url := range cfg.Endpoints {
+		db.endpoints = append(db.endpoints, &endpoint{url: url})
+	}
+
+	var errs []error
+	for i := range db.endpoints {
+		err := db.connect(ctx, i)
+		switch {
+		case errors.Is(err, ErrChainIDMismatch):
+			db.Close()
+			return nil, err
+		case err != nil:
+			errs = append(errs, err)
+		case db.active == -1:
+			db.active = i
+		}
+	}
+
+	if db.active == -1 {
+		db.Close()
+		return nil, fmt.Errorf("%w: %w", ErrNoHealthyEndpoint, errors.Join(errs...))
+	}
+
+	if cfg.HealthInterval > 0 {
+		db.wg.Add(1)
+		go func() {
+			defer db.wg.Done()
+			db.healthLoop()
+		}()
+	}
+
+	return &db, nil
+}
+
+// Close stops the health checks and closes the connection to every
+// endpoint.
+func (db *DialedBackend) Close() {
+	select {
+	case <-db.shutdown:
+		return
+	default:
+		close(db.shutdown)
+	}
+	db.wg.Wait()
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, ep := range db.endpoints {
+		if ep.client != nil {
+			ep.client.Close()
+			ep.client = nil
+		}
+	}
+}
+
+// Network returns the endpoint that the backend is currently using.
+func (db *DialedBackend) Network() string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.active == -1 {
+		return db.endpoints[0].url
+	}
+
+	return db.endpoints[db.active].url
+}
+
+// ChainID returns the chain id that the backend is connected to.
+func (db *DialedBackend) ChainID() *big.Int {
+	return db.chainID
+}
+
+// CheckHealth checks every endpoint with BlockNumber and switches to the
+// first healthy endpoint in the list, so the backend moves back to a
+// preferred endpoint once it recovers.
+func (db *DialedBackend) CheckHealth(ctx context.Context) error {
+	var errs []error
+
+	for i := range db.endpoints {
+		err := db.connect(ctx, i)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		db.mu.Lock()
+		db.active = i
+		db.mu.Unlock()
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrNoHealthyEndpoint, errors.Join(errs...))
+}
+
+// healthLoop checks the health of the endpoints until the backend is closed.
+func (db *DialedBackend) healthLoop() {
+	ticker := time.NewTicker(db.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.shutdown:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), db.cfg.HealthInterval)
+		if err := db.CheckHealth(ctx); err != nil {
+			log.Warn("Health check failed", "err", err)
+		}
+		cancel()
+	}
+}
+
+// connect dials the endpoint if needed, verifies its chain id and checks it
+// can answer BlockNumber. A failed endpoint is closed so it's redialed next
+// time.
+func (db *DialedBackend) connect(ctx context.Context, i int) error {
+	db.mu.Lock()
+	ep := db.endpoints[i]
+	client := ep.client
+	db.mu.Unlock()
+
+	if client == nil {
+		var err error
+		if client, err = db.dial(ctx, ep.url); err != nil {
+			return err
+		}
+
+		db.mu.Lock()
+		switch ep.client {
+		case nil:
+			ep.client = client
+		default:
+			// Another call dialed the endpoint first.
+			client.Close()
+			client = ep.client
+		}
+		db.mu.Unlock()
+	}
+
+	if _, err := client.BlockNumber(ctx); err != nil {
+		db.fail(i, client, err)
+		return fmt.Errorf("checking %s: %w", ep.url, err)
+	}
+
+	return nil
+}
+
+// dial connects to the endpoint and verifies its chain id. The chain id of
+// the first endpoint reached is used when none is configured.
+func (db *DialedBackend) dial(ctx context.Context, url string) (*ethclient.Client, error) {
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("dialing %s: %w", url, err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("retrieving chain id from %s: %w", url, err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	switch {
+	case db.chainID == nil:
+		db.chainID = chainID
+	case db.chainID.Cmp(chainID) != 0:
+		client.Close()
+		return nil, fmt.Errorf("%w: %s reports %v, expected %v", ErrChainIDMismatch, url, chainID, db.chainID)
+	}
+
+	return client, nil
+}
+
+// current returns the client of the active endpoint, failing over to the next
+// healthy endpoint when the active one has failed.
+func (db *DialedBackend) current(ctx context.Context) (*ethclient.Client, int, error) {
+	db.mu.Lock()
+	active := db.active
+	if active == -1 {
+		active = 0
+	}
+	client := db.endpoints[active].client
+	db.mu.Unlock()
+
+	if client != nil {
+		return client, active, nil
+	}
+
+	var errs []error
+	for k := range db.endpoints {
+		i := (active + k) % len(db.endpoints)
+
+		if err := db.connect(ctx, i); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		db.mu.Lock()
+		db.active = i
+		client := db.endpoints[i].client
+		db.mu.Unlock()
+
+		return client, i, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: %w", ErrNoHealthyEndpoint, errors.Join(errs...))
+}
+
+// fail closes the failed client of the endpoint and moves the backend on to
+// the next endpoint when it was the active one.
+func (db *DialedBackend) fail(i int, client *ethclient.Client, err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	ep := db.endpoints[i]
+
+	// Another call may have already replaced the failed client.
+	if ep.client != client {
+		return
+	}
+
+	log.Warn("Endpoint failed", "endpoint", ep.url, "err", err)
+
+	ep.client.Close()
+	ep.client = nil
+
+	if db.active == i {
+		db.active = (i + 1) % len(db.endpoints)
+	}
+}
+
+// retry calls the function with the client of the active endpoint. When the
+// endpoint fails, the call is retried with backoff on the next healthy
+// endpoint. Only calls that are safe to repeat may be retried.
+func (db *DialedBackend) retry(ctx context.Context, fn func(client *ethclient.Client) error) error {
+	backoff := db.cfg.Backoff
+
+	var err error
+	for attempt := 0; attempt <= db.cfg.Retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+
+			if backoff *= 2; backoff > db.cfg.MaxBackoff {
+				backoff = db.cfg.MaxBackoff
+			}
+		}
+
+		client, i, cErr := db.current(ctx)
+		if cErr != nil {
+			err = cErr
+			continue
+		}
+
+		err = fn(client)
+		if !endpointFailed(ctx, err) {
+			return err
+		}
+
+		db.fail(i, client, err)
+	}
+
+	return fmt.Errorf("after %d attempts: %w", db.cfg.Retries+1, err)
+}
+
+// endpointFailed reports whether the error was caused by the endpoint rather
+// than being the node's answer to the call.
+func endpointFailed(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+
+	// JSON-RPC errors are the node's answer, except for timeouts.
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == -32002
+	}
+
+	return true
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// CodeAt returns the code of the contract at the block.
+func (db *DialedBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		code, err = client.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// CallContract executes the call at the block.
+func (db *DialedBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// PendingCodeAt returns the code of the contract in the pending state.
+func (db *DialedBackend) PendingCodeAt(ctx context.Context, contract common.Address) (code []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		code, err = client.PendingCodeAt(ctx, contract)
+		return err
+	})
+	return code, err
+}
+
+// PendingCallContract executes the call in the pending state.
+func (db *DialedBackend) PendingCallContract(ctx context.Context, call ethereum.CallMsg) (result []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		result, err = client.PendingCallContract(ctx, call)
+		return err
+	})
+	return result, err
+}
+
+// HeaderByNumber returns the header of the block, the latest when nil.
+func (db *DialedBackend) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// BlockNumber returns the number of the latest block.
+func (db *DialedBackend) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+// PendingNonceAt returns the nonce of the account in the pending state.
+func (db *DialedBackend) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// NonceAt returns the nonce of the account at the block.
+func (db *DialedBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		nonce, err = client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// BalanceAt returns the balance of the account at the block.
+func (db *DialedBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		balance, err = client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// SuggestGasPrice returns the gas price the node suggests.
+func (db *DialedBackend) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// SuggestGasTipCap returns the gas tip cap the node suggests.
+func (db *DialedBackend) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		tip, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+// EstimateGas estimates the gas the call needs.
+func (db *DialedBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		gas, err = client.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// FilterLogs returns the logs matching the query.
+func (db *DialedBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		logs, err = client.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes to the logs matching the query. Only making
+// the subscription fails over to the next endpoint. A live subscription is
+// tied to the endpoint it was made on and isn't made again on another one,
+// since logs mined in between would be missed. When the endpoint fails the
+// subscription's error channel reports it, and the caller subscribes again
+// and backfills from the last block it processed, like WatchEvents with
+// Start set.
+func (db *DialedBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		sub, err = client.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
+
+// TransactionByHash returns the transaction with the hash.
+func (db *DialedBackend) TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		tx, isPending, err = client.TransactionByHash(ctx, txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+// TransactionReceipt returns the receipt of the mined transaction.
+func (db *DialedBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// /////////////////////////////////////////////////////////////////
+// The rest of the read calls of an ethclient.Client, so the backend keeps the
+// calls it had when it embedded one.
+
+// BlockByHash returns the block with the hash.
+func (db *DialedBackend) BlockByHash(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		block, err = client.BlockByHash(ctx, hash)
+		return err
+	})
+	return block, err
+}
+
+// BlockByNumber returns the block, the latest when nil.
+func (db *DialedBackend) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		block, err = client.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+// HeaderByHash returns the header of the block with the hash.
+func (db *DialedBackend) HeaderByHash(ctx context.Context, hash common.Hash) (header *types.Header, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		header, err = client.HeaderByHash(ctx, hash)
+		return err
+	})
+	return header, err
+}
+
+// TransactionCount returns the number of transactions in the block.
+func (db *DialedBackend) TransactionCount(ctx context.Context, blockHash common.Hash) (count uint, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		count, err = client.TransactionCount(ctx, blockHash)
+		return err
+	})
+	return count, err
+}
+
+// TransactionInBlock returns the transaction at the index in the block.
+func (db *DialedBackend) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (tx *types.Transaction, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		tx, err = client.TransactionInBlock(ctx, blockHash, index)
+		return err
+	})
+	return tx, err
+}
+
+// TransactionSender returns the sender of the transaction mined in the block.
+func (db *DialedBackend) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (sender common.Address, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		sender, err = client.TransactionSender(ctx, tx, block, index)
+		return err
+	})
+	return sender, err
+}
+
+// CallContractAtHash executes the call at the block with the hash.
+func (db *DialedBackend) CallContractAtHash(ctx context.Context, call ethereum.CallMsg, blockHash common.Hash) (result []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		result, err = client.CallContractAtHash(ctx, call, blockHash)
+		return err
+	})
+	return result, err
+}
+
+// StorageAt returns the value of the storage key of the account at the block.
+func (db *DialedBackend) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) (value []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		value, err = client.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return value, err
+}
+
+// PendingBalanceAt returns the balance of the account in the pending state.
+func (db *DialedBackend) PendingBalanceAt(ctx context.Context, account common.Address) (balance *big.Int, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		balance, err = client.PendingBalanceAt(ctx, account)
+		return err
+	})
+	return balance, err
+}
+
+// PendingStorageAt returns the value of the storage key of the account in the
+// pending state.
+func (db *DialedBackend) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) (value []byte, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		value, err = client.PendingStorageAt(ctx, account, key)
+		return err
+	})
+	return value, err
+}
+
+// PendingTransactionCount returns the number of transactions in the pending
+// state.
+func (db *DialedBackend) PendingTransactionCount(ctx context.Context) (count uint, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		count, err = client.PendingTransactionCount(ctx)
+		return err
+	})
+	return count, err
+}
+
+// FeeHistory returns the fee history of the blocks up to the last block.
+func (db *DialedBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (history *ethereum.FeeHistory, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		history, err = client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+		return err
+	})
+	return history, err
+}
+
+// SyncProgress returns the node's sync progress, nil when it isn't syncing.
+func (db *DialedBackend) SyncProgress(ctx context.Context) (progress *ethereum.SyncProgress, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		progress, err = client.SyncProgress(ctx)
+		return err
+	})
+	return progress, err
+}
+
+// NetworkID returns the network id of the node.
+func (db *DialedBackend) NetworkID(ctx context.Context) (id *big.Int, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		id, err = client.NetworkID(ctx)
+		return err
+	})
+	return id, err
+}
+
+// PeerCount returns the number of peers the node is connected to.
+func (db *DialedBackend) PeerCount(ctx context.Context) (count uint64, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		count, err = client.PeerCount(ctx)
+		return err
+	})
+	return count, err
+}
+
+// SubscribeNewHead subscribes to the headers of new blocks. Like
+// SubscribeFilterLogs, only making the subscription fails over to the next
+// endpoint.
+func (db *DialedBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (sub ethereum.Subscription, err error) {
+	err = db.retry(ctx, func(client *ethclient.Client) error {
+		sub, err = client.SubscribeNewHead(ctx, ch)
+		return err
+	})
+	return sub, err
+}
+
+// SendTransaction sends the signed transaction. A signed transaction is
+// identified by its hash, so sending it again can't spend twice. When the
+// endpoint fails the transaction is sent again on the next endpoint, and an
+// endpoint that already has it, pending or mined, counts as sent.
+func (db *DialedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	var attempt int
+
+	return db.retry(ctx, func(client *ethclient.Client) error {
+		attempt++
+
+		err := client.SendTransaction(ctx, tx)
+		if err != nil && attempt > 1 && sentBefore(ctx, client, tx, err) {
+			return nil
+		}
+
+		return err
+	})
+}
+
+// sentBefore reports whether the error from sending the transaction again
+// means an earlier attempt reached the node. The node either still has it
+// pending, or mined it and rejects the nonce as too low, which is told apart
+// from another transaction using the nonce by looking the transaction up.
+func sentBefore(ctx context.Context, client *ethclient.Client, tx *types.Transaction, err error) bool {
+	msg := err.Error()
+
+	switch {
+	case strings.Contains(msg, errMsgAlreadyKnown):
+		return true
+
+	case strings.Contains(msg, errMsgNonceTooLow):
+		found, _, err := client.TransactionByHash(ctx, tx.Hash())
+		return err == nil && found != nil
+	}
+
+	return false
+}
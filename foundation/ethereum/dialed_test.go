@@ -0,0 +1,479 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	smart "github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// chainService stands in for the eth API of the nodes of a chain. Nodes
+// sharing the service share its transaction pool.
+type chainService struct {
+	chainID int64
+
+	mu    sync.Mutex
+	txs   map[common.Hash]*types.Transaction
+	mined bool
+}
+
+// ChainId implements eth_chainId.
+func (s *chainService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(s.chainID))
+}
+
+// BlockNumber implements eth_blockNumber.
+func (s *chainService) BlockNumber() hexutil.Uint64 {
+	return 10
+}
+
+// GetBalance implements eth_getBalance, every account has 100 wei.
+func (s *chainService) GetBalance(address common.Address, block string) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(100))
+}
+
+// GetTransactionReceipt implements eth_getTransactionReceipt, no transaction
+// is ever mined.
+func (s *chainService) GetTransactionReceipt(hash common.Hash) (map[string]any, error) {
+	return nil, nil
+}
+
+// SendRawTransaction implements eth_sendRawTransaction.
+func (s *chainService) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.txs[tx.Hash()] != nil {
+		if s.mined {
+			return common.Hash{}, errors.New("nonce too low")
+		}
+		return common.Hash{}, errors.New("already known")
+	}
+	s.txs[tx.Hash()] = &tx
+
+	return tx.Hash(), nil
+}
+
+// GetTransactionByHash implements eth_getTransactionByHash, transactions are
+// in a block once the service mines them.
+func (s *chainService) GetTransactionByHash(hash common.Hash) (map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.txs[hash]
+	if tx == nil {
+		return nil, nil
+	}
+
+	data, err := tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if s.mined {
+		fields["blockHash"] = common.Hash{1}
+		fields["blockNumber"] = hexutil.Uint64(10)
+	}
+
+	return fields, nil
+}
+
+// mine has the service treat the transactions it holds as mined.
+func (s *chainService) mine(mined bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mined = mined
+}
+
+// testNode serves a chain service over HTTP. A down node answers every
+// request with a 503, a node with failures answers that many requests with
+// a 503, and a dropping node handles requests but answers with a 503.
+type testNode struct {
+	URL string
+
+	mu       sync.Mutex
+	down     bool
+	dropping bool
+	failures int
+	requests int
+}
+
+// startNode serves the chain service on a new node.
+func startNode(t *testing.T, service *chainService) *testNode {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatalf("unable to register service: %s", err)
+	}
+
+	var node testNode
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.mu.Lock()
+		node.requests++
+		fail := node.down || node.failures > 0
+		drop := node.dropping
+		if node.failures > 0 {
+			node.failures--
+		}
+		node.mu.Unlock()
+
+		switch {
+		case drop:
+			server.ServeHTTP(httptest.NewRecorder(), r)
+			http.Error(w, "dropped", http.StatusServiceUnavailable)
+		case fail:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			server.ServeHTTP(w, r)
+		}
+	})
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(func() {
+		srv.Close()
+		server.Stop()
+	})
+
+	node.URL = srv.URL
+
+	return &node
+}
+
+// set changes the behavior of the node and resets its request count.
+func (n *testNode) set(down bool, dropping bool, failures int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.down = down
+	n.dropping = dropping
+	n.failures = failures
+	n.requests = 0
+}
+
+// count returns the number of requests since the node was last set.
+func (n *testNode) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.requests
+}
+
+func TestDialedBackend(t *testing.T) {
+	ctx := context.Background()
+
+	chain := &chainService{chainID: 1337, txs: make(map[common.Hash]*types.Transaction)}
+	primary := startNode(t, chain)
+	secondary := startNode(t, chain)
+	other := startNode(t, &chainService{chainID: 1, txs: make(map[common.Hash]*types.Transaction)})
+
+	account := common.HexToAddress("0x6327A38415C53FFb36c11db55Ea74cc9cB4976Fd")
+
+	// dial constructs a backend for the nodes with a short backoff.
+	dial := func(t *testing.T, retries int, nodes ...*testNode) *smart.DialedBackend {
+		t.Helper()
+
+		var endpoints []string
+		for _, node := range nodes {
+			endpoints = append(endpoints, node.URL)
+		}
+
+		backend, err := smart.CreateDialedBackendWithConfig(ctx, smart.DialedConfig{
+			Endpoints: endpoints,
+			Retries:   retries,
+			Backoff:   time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("unable to create backend: %s", err)
+		}
+		t.Cleanup(backend.Close)
+
+		return backend
+	}
+
+	// balance checks the balance can be read through the backend.
+	balance := func(t *testing.T, backend *smart.DialedBackend) {
+		t.Helper()
+
+		wei, err := backend.BalanceAt(ctx, account, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		if wei.Cmp(big.NewInt(100)) != 0 {
+			t.Fatalf("wrong balance, got %v  exp 100", wei)
+		}
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("failover", func(t *testing.T) {
+		primary.set(false, false, 0)
+		secondary.set(false, false, 0)
+
+		backend := dial(t, 0, primary, secondary)
+
+		if backend.ChainID().Int64() != 1337 {
+			t.Fatalf("wrong chain id, got %v  exp 1337", backend.ChainID())
+		}
+
+		primary.set(true, false, 0)
+		balance(t, backend)
+
+		if backend.Network() != secondary.URL {
+			t.Fatalf("should fail over to the secondary, using %s", backend.Network())
+		}
+
+		// The backend stays on the secondary until the health check finds
+		// the primary has recovered.
+		primary.set(false, false, 0)
+		balance(t, backend)
+
+		if primary.count() != 0 {
+			t.Fatalf("primary should not be used before the health check, got %d requests", primary.count())
+		}
+
+		if err := backend.CheckHealth(ctx); err != nil {
+			t.Fatalf("unable to check health: %s", err)
+		}
+
+		if backend.Network() != primary.URL {
+			t.Fatalf("should fail back to the primary, using %s", backend.Network())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("retries", func(t *testing.T) {
+		primary.set(false, false, 0)
+
+		backend := dial(t, 3, primary)
+
+		primary.set(false, false, 2)
+		balance(t, backend)
+
+		primary.set(false, false, 100)
+		if _, err := backend.BalanceAt(ctx, account, nil); err == nil {
+			t.Fatal("should fail once the retries are exhausted")
+		}
+
+		primary.set(false, false, 0)
+		balance(t, backend)
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("read calls fail over", func(t *testing.T) {
+		primary.set(false, false, 0)
+		secondary.set(false, false, 0)
+
+		backend := dial(t, 0, primary, secondary)
+
+		// The read calls an ethclient.Client has go through the same
+		// failover as the ones the backend needs.
+		primary.set(true, false, 0)
+
+		wei, err := backend.PendingBalanceAt(ctx, account)
+		if err != nil {
+			t.Fatalf("unable to retrieve pending balance: %s", err)
+		}
+
+		if wei.Cmp(big.NewInt(100)) != 0 {
+			t.Fatalf("wrong pending balance, got %v  exp 100", wei)
+		}
+
+		if backend.Network() != secondary.URL {
+			t.Fatalf("should fail over to the secondary, using %s", backend.Network())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("answers are not retried", func(t *testing.T) {
+		primary.set(false, false, 0)
+
+		backend := dial(t, 3, primary)
+		primary.set(false, false, 0)
+
+		if _, err := backend.TransactionReceipt(ctx, common.Hash{1}); !errors.Is(err, ethereum.NotFound) {
+			t.Fatalf("should return not found, got %v", err)
+		}
+
+		if primary.count() != 1 {
+			t.Fatalf("not found should not be retried, got %d requests", primary.count())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("send again", func(t *testing.T) {
+		primary.set(false, false, 0)
+		secondary.set(false, false, 0)
+
+		backend := dial(t, 0, primary, secondary)
+
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("unable to generate key: %s", err)
+		}
+
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(backend.ChainID()), &types.LegacyTx{
+			GasPrice: big.NewInt(1),
+			Gas:      21_000,
+			To:       &account,
+		})
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %s", err)
+		}
+
+		// The primary accepts the transaction but the answer is lost, so
+		// the secondary already knows it.
+		primary.set(false, true, 0)
+
+		if err := backend.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		if chain.txs[tx.Hash()] == nil {
+			t.Fatal("transaction should be in the pool")
+		}
+
+		if err := backend.SendTransaction(ctx, tx); err == nil {
+			t.Fatal("sending a known transaction on the first attempt should fail")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("send again after mined", func(t *testing.T) {
+		primary.set(false, false, 0)
+		secondary.set(false, false, 0)
+		defer chain.mine(false)
+
+		backend := dial(t, 0, primary, secondary)
+
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("unable to generate key: %s", err)
+		}
+
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(backend.ChainID()), &types.LegacyTx{
+			GasPrice: big.NewInt(1),
+			Gas:      21_000,
+			To:       &account,
+		})
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %s", err)
+		}
+
+		// The primary accepts the transaction but the answer is lost, and
+		// it's mined before the secondary sees it again.
+		primary.set(false, true, 0)
+		chain.mine(true)
+
+		if err := backend.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		primary.set(false, false, 0)
+		if err := backend.SendTransaction(ctx, tx); err == nil {
+			t.Fatal("sending a mined transaction on the first attempt should fail")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("chain id", func(t *testing.T) {
+		primary.set(false, false, 0)
+
+		_, err := smart.CreateDialedBackendWithConfig(ctx, smart.DialedConfig{
+			Endpoints: []string{primary.URL, other.URL},
+		})
+		if !errors.Is(err, smart.ErrChainIDMismatch) {
+			t.Fatalf("should fail for endpoints on different chains, got %v", err)
+		}
+
+		_, err = smart.CreateDialedBackendWithConfig(ctx, smart.DialedConfig{
+			Endpoints: []string{primary.URL},
+			ChainID:   big.NewInt(1),
+		})
+		if !errors.Is(err, smart.ErrChainIDMismatch) {
+			t.Fatalf("should fail for an endpoint on the wrong chain, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("unreachable", func(t *testing.T) {
+		primary.set(true, false, 0)
+		secondary.set(false, false, 0)
+
+		backend := dial(t, 0, primary, secondary)
+
+		if backend.Network() != secondary.URL {
+			t.Fatalf("should start on the secondary, using %s", backend.Network())
+		}
+		balance(t, backend)
+
+		secondary.set(true, false, 0)
+
+		_, err := smart.CreateDialedBackendWithConfig(ctx, smart.DialedConfig{
+			Endpoints: []string{primary.URL, secondary.URL},
+		})
+		if !errors.Is(err, smart.ErrNoHealthyEndpoint) {
+			t.Fatalf("should fail when no endpoint is reachable, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("health checks", func(t *testing.T) {
+		primary.set(true, false, 0)
+		secondary.set(false, false, 0)
+
+		backend, err := smart.CreateDialedBackendWithConfig(ctx, smart.DialedConfig{
+			Endpoints:      []string{primary.URL, secondary.URL},
+			HealthInterval: 10 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("unable to create backend: %s", err)
+		}
+		defer backend.Close()
+
+		primary.set(false, false, 0)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for backend.Network() != primary.URL {
+			if time.Now().After(deadline) {
+				t.Fatalf("health checks should move back to the primary, using %s", backend.Network())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// eip712Prefix is prepended to the domain separator and struct hash
+// when calculating the digest of typed structured data.
+const eip712Prefix = "\x19\x01"
+
+// Domain represents the EIP-712 domain of a contract. Binding signatures to
+// the chain id and contract address prevents them from being replayed
+// against another deployment.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// NewDomain constructs the EIP-712 domain for the specified contract on the
+// network the client is connected to.
+func (c *Client) NewDomain(name string, version string, contract common.Address) Domain {
+	return Domain{
+		Name:              name,
+		Version:           version,
+		ChainID:           new(big.Int).Set(c.Backend.ChainID()),
+		VerifyingContract: contract,
+	}
+}
+
+// Separator returns the domain separator, matching
+// keccak256(abi.encode(TYPE_HASH, keccak256(name), keccak256(version), chainid, contract)).
+func (d Domain) Separator() (common.Hash, error) {
+	td := apitypes.TypedData{
+		Types:  apitypes.Types{"EIP712Domain": domainType},
+		Domain: d.typedDataDomain(),
+	}
+
+	hash, err := td.HashStruct("EIP712Domain", td.Domain.Map())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("hashing domain: %w", err)
+	}
+
+	return common.BytesToHash(hash), nil
+}
+
+// typedDataDomain converts the domain to the geth representation.
+func (d Domain) typedDataDomain() apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              d.Name,
+		Version:           d.Version,
+		ChainId:           (*math.HexOrDecimal256)(d.ChainID),
+		VerifyingContract: d.VerifyingContract.Hex(),
+	}
+}
+
+// domainType is the EIP712Domain type for the fields supported by Domain.
+var domainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// Field represents a single named member of a typed struct.
+type Field struct {
+	Name string
+	Type string
+}
+
+// Types represents the set of struct types, excluding EIP712Domain,
+// referenced by typed structured data.
+type Types map[string][]Field
+
+// TypedData represents an EIP-712 typed structured data message. Message
+// values can be Go strings, booleans, byte slices, addresses, hashes, big
+// integers, the fixed size integer kinds, or nested maps and slices of them.
+type TypedData struct {
+	Domain      Domain
+	Types       Types
+	PrimaryType string
+	Message     map[string]any
+}
+
+// StructHash returns hashStruct(message) for the primary type.
+func (td TypedData) StructHash() (common.Hash, error) {
+	gtd, err := td.typedData()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	hash, err := gtd.HashStruct(td.PrimaryType, gtd.Message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("hashing %s: %w", td.PrimaryType, err)
+	}
+
+	return common.BytesToHash(hash), nil
+}
+
+// Hash returns the digest that is signed, matching OpenZeppelin's
+// _hashTypedDataV4: keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).
+func (td TypedData) Hash() (common.Hash, error) {
+	separator, err := td.Domain.Separator()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	structHash, err := td.StructHash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash([]byte(eip712Prefix), separator.Bytes(), structHash.Bytes()), nil
+}
+
+// typedData converts the typed data to the geth representation.
+func (td TypedData) typedData() (apitypes.TypedData, error) {
+	types := apitypes.Types{"EIP712Domain": domainType}
+	for name, fields := range td.Types {
+		gFields := make([]apitypes.Type, len(fields))
+		for i, field := range fields {
+			gFields[i] = apitypes.Type{Name: field.Name, Type: field.Type}
+		}
+		types[name] = gFields
+	}
+
+	if _, exists := types[td.PrimaryType]; !exists {
+		return apitypes.TypedData{}, fmt.Errorf("primary type %q is not defined", td.PrimaryType)
+	}
+
+	message, err := typedValue(td.Message)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	gtd := apitypes.TypedData{
+		Types:       types,
+		PrimaryType: td.PrimaryType,
+		Domain:      td.Domain.typedDataDomain(),
+		Message:     message.(map[string]any),
+	}
+
+	return gtd, nil
+}
+
+// typedValue converts Go values into the representation geth's typed data
+// encoder expects.
+func typedValue(value any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			tv, err := typedValue(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			m[key] = tv
+		}
+		return m, nil
+	case []any:
+		s := make([]any, len(v))
+		for i, val := range v {
+			tv, err := typedValue(val)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			s[i] = tv
+		}
+		return s, nil
+	case common.Address:
+		return v.Hex(), nil
+	case common.Hash:
+		return v.Bytes(), nil
+	case string, bool, []byte, [32]byte, *big.Int:
+		return v, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// SignTypedData signs the EIP-712 digest of the typed data with the private
+// key. Unlike SignHash, no Ethereum salt is applied since the "\x19\x01"
+// prefix already separates typed data from transactions. The returned
+// signature has V normalized to 27 or 28.
+func SignTypedData(privateKey *ecdsa.PrivateKey, td TypedData) ([]byte, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	return signDigest(privateKey, hash)
+}
+
+// FromTypedDataSignature recovers the address that signed the typed data.
+// V may be either 0/1 or 27/28.
+func FromTypedDataSignature(sig []byte, td TypedData) (common.Address, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return recoverDigest(sig, hash)
+}
+
+// VerifyTypedDataSignature checks the specified address signed the typed data.
+func VerifyTypedDataSignature(address common.Address, sig []byte, td TypedData) error {
+	signer, err := FromTypedDataSignature(sig, td)
+	if err != nil {
+		return err
+	}
+
+	if signer != address {
+		return fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidSignature, signer, address)
+	}
+
+	return nil
+}
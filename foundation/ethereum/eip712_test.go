@@ -0,0 +1,183 @@
+package ethereum_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// deployFixture deploys the compiled contract found in testdata and returns
+// a bound contract for calling it.
+func deployFixture(t *testing.T, client *ethereum.Client, name string, params ...any) (common.Address, *bind.BoundContract) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	abiData, err := os.ReadFile("testdata/" + strings.ToLower(name) + "/" + name + ".abi")
+	if err != nil {
+		t.Fatalf("unable to read abi: %s", err)
+	}
+
+	binData, err := os.ReadFile("testdata/" + strings.ToLower(name) + "/" + name + ".bin")
+	if err != nil {
+		t.Fatalf("unable to read bin: %s", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(abiData)))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %s", err)
+	}
+
+	txOpts, err := client.NewTransactOpts(ctx, 3_000_000, big.NewInt(0), big.NewFloat(0))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts for deploy: %s", err)
+	}
+
+	address, tx, contract, err := bind.DeployContract(txOpts, parsed, common.FromHex(strings.TrimSpace(string(binData))), client.Backend, params...)
+	if err != nil {
+		t.Fatalf("unable to deploy %s: %s", name, err)
+	}
+
+	if _, err := client.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for deploy: %s", err)
+	}
+
+	return address, contract
+}
+
+func TestEIP712(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(2, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	const name = "Book"
+	const version = "1"
+
+	address, fixture := deployFixture(t, client, "EIP712", name, version)
+
+	callOpts, err := client.NewCallOpts(ctx)
+	if err != nil {
+		t.Fatalf("unable to create call opts: %s", err)
+	}
+
+	participant, err := ethereum.NewClient(backend, backend.PrivateKeys[1])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	const betID = "bet-1"
+	nonce := big.NewInt(7)
+
+	td := ethereum.TypedData{
+		Domain: client.NewDomain(name, version, address),
+		Types: ethereum.Types{
+			"Bet": {
+				{Name: "betID", Type: "string"},
+				{Name: "participant", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Bet",
+		Message: map[string]any{
+			"betID":       betID,
+			"participant": participant.Address(),
+			"nonce":       nonce,
+		},
+	}
+
+	// call executes a view function on the fixture and returns the result.
+	call := func(method string, params ...any) any {
+		var out []any
+		if err := fixture.Call(callOpts, &out, method, params...); err != nil {
+			t.Fatalf("unable to call %s: %s", method, err)
+		}
+		return out[0]
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("domain separator", func(t *testing.T) {
+		got, err := td.Domain.Separator()
+		if err != nil {
+			t.Fatalf("unable to calculate domain separator: %s", err)
+		}
+
+		exp := common.Hash(call("DomainSeparator").([32]byte))
+		if got != exp {
+			t.Fatalf("wrong domain separator, got %s  exp %s", got, exp)
+		}
+	})
+
+	t.Run("struct hash", func(t *testing.T) {
+		got, err := td.StructHash()
+		if err != nil {
+			t.Fatalf("unable to calculate struct hash: %s", err)
+		}
+
+		exp := common.Hash(call("HashBet", betID, participant.Address(), nonce).([32]byte))
+		if got != exp {
+			t.Fatalf("wrong struct hash, got %s  exp %s", got, exp)
+		}
+	})
+
+	t.Run("typed data hash", func(t *testing.T) {
+		got, err := td.Hash()
+		if err != nil {
+			t.Fatalf("unable to calculate typed data hash: %s", err)
+		}
+
+		exp := common.Hash(call("HashTypedDataV4", betID, participant.Address(), nonce).([32]byte))
+		if got != exp {
+			t.Fatalf("wrong typed data hash, got %s  exp %s", got, exp)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("sign and recover", func(t *testing.T) {
+		sig, err := ethereum.SignTypedData(backend.PrivateKeys[1], td)
+		if err != nil {
+			t.Fatalf("unable to sign typed data: %s", err)
+		}
+
+		if err := ethereum.VerifyTypedDataSignature(participant.Address(), sig, td); err != nil {
+			t.Fatalf("signature should verify: %s", err)
+		}
+
+		signer := call("Recover", betID, participant.Address(), nonce, sig).(common.Address)
+		if signer != participant.Address() {
+			t.Fatalf("wrong signer recovered by contract, got %s  exp %s", signer, participant.Address())
+		}
+	})
+
+	t.Run("replay on another deployment", func(t *testing.T) {
+		sig, err := ethereum.SignTypedData(backend.PrivateKeys[1], td)
+		if err != nil {
+			t.Fatalf("unable to sign typed data: %s", err)
+		}
+
+		other := td
+		other.Domain = client.NewDomain(name, version, common.HexToAddress("0x01"))
+
+		if err := ethereum.VerifyTypedDataSignature(participant.Address(), sig, other); err == nil {
+			t.Fatal("signature should not verify against another contract")
+		}
+	})
+}
@@ -5,14 +5,16 @@ package ethereum
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 // Set of networks supported by the package.
@@ -23,10 +25,12 @@ const (
 
 type Backend interface {
 	bind.ContractBackend
+	bind.PendingContractCaller
 	bind.DeployBackend
 	TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 	BalanceAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
 	Network() string
 	ChainID() *big.Int
 }
@@ -34,24 +38,47 @@ type Backend interface {
 // Client enables API interaction with smart contracts.
 type Client struct {
 	Backend
-	address    common.Address
-	privateKey *ecdsa.PrivateKey
+	address common.Address
+	signer  Signer
+	nonces  *NonceManager
+
+	replacements replacements
+	errorABIs    errorABIs
 }
 
 // NewClient provides an API for accessing an ethereum node for performing
 // blockchain operations. The private key will determine "who" will be
-// interacting with the node vai the returned *Client.
+// interacting with the node vai the returned *Client. Transactions for this
+// account sent through the client's Backend have their nonces managed, so
+// the client can be shared by multiple goroutines.
 func NewClient(backend Backend, privateKey *ecdsa.PrivateKey) (*Client, error) {
+	return NewSignerClient(backend, NewKeySigner(privateKey))
+}
+
+// NewSignerClient provides an API for accessing an ethereum node like
+// NewClient, with the transactions and signatures of the client's account
+// made by the signer. The client never sees the account's key.
+func NewSignerClient(backend Backend, signer Signer) (*Client, error) {
+	address := signer.Address()
+	nonces := NewNonceManager(backend, address)
+
+	mb := managedBackend{
+		Backend: backend,
+		nonces:  nonces,
+		signer:  types.LatestSignerForChainID(backend.ChainID()),
+	}
+
 	client := Client{
-		Backend:    backend,
-		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
-		privateKey: privateKey,
+		Backend: &mb,
+		address: address,
+		signer:  signer,
+		nonces:  nonces,
 	}
 
 	return &client, nil
 }
 
-// Address returns the current address calculated from the private key.
+// Address returns the address of the client's account.
 func (c *Client) Address() common.Address {
 	return c.address
 }
@@ -66,9 +93,28 @@ func (c *Client) ChainID() int {
 	return int(c.Backend.ChainID().Int64())
 }
 
-// PrivateKey returns network info for the connected network.
-func (c *Client) PrivateKey() *ecdsa.PrivateKey {
-	return c.privateKey
+// Signer returns the signer of the client's account.
+func (c *Client) Signer() Signer {
+	return c.signer
+}
+
+// Sign ABI-encodes and hashes the values like Sign, and signs the hash with
+// the client's signer.
+func (c *Client) Sign(ctx context.Context, values ...any) ([]byte, error) {
+	return SignWith(ctx, c.signer, values...)
+}
+
+// SignHash signs the salted hash with the client's signer, like SignHash.
+func (c *Client) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return c.signer.SignHash(ctx, hash)
+}
+
+// Nonces returns the nonce manager for the client's account. TransactOpts
+// take their nonce when the transaction is signed and the client's Backend
+// releases it if the send fails, so only a transaction signed with NoSend
+// set and never sent through the client's Backend must be released.
+func (c *Client) Nonces() *NonceManager {
+	return c.nonces
 }
 
 // Balance retrieves the current balances for the client's account.
@@ -95,11 +141,7 @@ func (c *Client) NewCallOpts(ctx context.Context) (*bind.CallOpts, error) {
 // the amount of gas needed will be estimated. If gasPrice is set to 0, then the
 // connected geth service is consulted for the suggested gas price.
 func (c *Client) NewTransactOpts(ctx context.Context, gasLimit uint64, gasPrice *big.Int, valueGWei *big.Float) (*bind.TransactOpts, error) {
-	nonce, err := c.PendingNonceAt(ctx, c.address)
-	if err != nil {
-		return nil, err
-	}
-
+	var err error
 	if gasPrice == nil || gasPrice.Cmp(big.NewInt(0)) == 0 {
 		gasPrice, err = c.SuggestGasPrice(ctx)
 		if err != nil {
@@ -107,32 +149,145 @@ func (c *Client) NewTransactOpts(ctx context.Context, gasLimit uint64, gasPrice
 		}
 	}
 
-	txOpts, err := bind.NewKeyedTransactorWithChainID(c.privateKey, c.Backend.ChainID())
+	txOpts, err := c.newTransactOpts(ctx, gasLimit, valueGWei)
+	if err != nil {
+		return nil, err
+	}
+
+	txOpts.GasPrice = gasPrice // Amount agree on to pay per unit of gas.
+
+	return txOpts, nil
+}
+
+// FeeMultipliers represents the multipliers used to calculate the fee caps of
+// an EIP-1559 dynamic-fee transaction. The tip cap is the node's suggested tip
+// scaled by Tip, and the fee cap is the latest base fee scaled by BaseFee plus
+// the tip cap. A zero value uses the default multipliers.
+type FeeMultipliers struct {
+	BaseFee float64
+	Tip     float64
+}
+
+// Default multipliers for dynamic-fee transactions. Doubling the base fee
+// keeps the transaction marketable for six consecutive full blocks.
+const (
+	defaultBaseFeeMultiplier = 2.0
+	defaultTipMultiplier     = 1.0
+)
+
+// NewDynamicFeeTransactOpts constructs a new TransactOpts for an EIP-1559
+// dynamic-fee transaction. The GasTipCap and GasFeeCap are calculated from the
+// connected geth service's suggested tip and the latest header's base fee
+// using the specified multipliers. If gasLimit is set to 0, then the amount
+// of gas needed will be estimated.
+func (c *Client) NewDynamicFeeTransactOpts(ctx context.Context, gasLimit uint64, multipliers FeeMultipliers, valueGWei *big.Float) (*bind.TransactOpts, error) {
+	if multipliers.BaseFee == 0 {
+		multipliers.BaseFee = defaultBaseFeeMultiplier
+	}
+	if multipliers.Tip == 0 {
+		multipliers.Tip = defaultTipMultiplier
+	}
+
+	head, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving latest header: %w", err)
+	}
+
+	if head.BaseFee == nil {
+		return nil, errors.New("network does not support dynamic-fee transactions")
+	}
+
+	tip, err := c.SuggestGasTipCap(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("keying transaction: %w", err)
+		return nil, fmt.Errorf("retrieving suggested gas tip cap: %w", err)
+	}
+
+	txOpts, err := c.newTransactOpts(ctx, gasLimit, valueGWei)
+	if err != nil {
+		return nil, err
+	}
+
+	gasTipCap := mulFloat(tip, multipliers.Tip)
+	gasFeeCap := mulFloat(head.BaseFee, multipliers.BaseFee)
+	gasFeeCap.Add(gasFeeCap, gasTipCap)
+
+	txOpts.GasTipCap = gasTipCap // Amount agree on to pay the miner per unit of gas.
+	txOpts.GasFeeCap = gasFeeCap // Maximum amount agree on to pay per unit of gas.
+
+	return txOpts, nil
+}
+
+// newTransactOpts constructs the TransactOpts fields shared by legacy and
+// dynamic-fee transactions, leaving the fee fields unset. The nonce is left
+// unset so it's only taken from the nonce manager when the transaction is
+// signed, right before it's sent. A call that fails before then, such as a
+// gas estimate that reverts, never holds a nonce. Setting the Nonce field
+// signs the transaction at that nonce instead.
+func (c *Client) newTransactOpts(ctx context.Context, gasLimit uint64, valueGWei *big.Float) (*bind.TransactOpts, error) {
+	chainID := c.Backend.ChainID()
+
+	txOpts := bind.TransactOpts{
+		From: c.address,
+	}
+
+	txOpts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != c.address {
+			return nil, bind.ErrNotAuthorized
+		}
+
+		if txOpts.Nonce != nil {
+			return c.signer.SignTx(ctx, tx, chainID)
+		}
+
+		nonce, err := c.nonces.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		signed, err := c.signer.SignTx(ctx, withNonce(tx, nonce), chainID)
+		if err != nil {
+			c.nonces.Release(nonce)
+			return nil, err
+		}
+
+		return signed, nil
 	}
 
 	// Convert the GWei value to Wei.
 	gWei2Wei := big.NewInt(0)
 	big.NewFloat(0).SetPrec(1024).Mul(valueGWei, big.NewFloat(1e9)).Int(gWei2Wei)
 
-	txOpts.Nonce = big.NewInt(0).SetUint64(nonce)
 	txOpts.Value = gWei2Wei
 	txOpts.GasLimit = gasLimit // Maximum amount of gas we're willing to pay for.
-	txOpts.GasPrice = gasPrice // Amount agree on to pay per unit of gas.
 
-	return txOpts, nil
+	return &txOpts, nil
+}
+
+// BaseFee returns the base fee of the specified block. A nil block number
+// returns the base fee of the latest block. The returned value is nil for
+// blocks mined before EIP-1559 was activated.
+func (c *Client) BaseFee(ctx context.Context, blockNumber *big.Int) (*big.Int, error) {
+	head, err := c.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving header: %w", err)
+	}
+
+	return head.BaseFee, nil
 }
 
 // WaitMined waits for the transaction to be mined before returning a receipt.
+// If the transaction was replaced using SpeedUp or Cancel, the receipt of
+// whichever transaction in the replacement set is mined is returned. If the
+// transaction failed, the returned error wraps a *RevertError when the
+// contract reverted.
 func (c *Client) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
-	receipt, err := bind.WaitMined(ctx, c.Backend, tx)
+	receipt, mined, err := c.waitMined(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("waiting for tx to be mined: %w", err)
 	}
 
 	if receipt.Status == 0 {
-		if err := c.extractError(ctx, tx); err != nil {
+		if err := c.extractError(ctx, mined, receipt.BlockNumber); err != nil {
 			return nil, fmt.Errorf("extracting tx error: %w", err)
 		}
 	}
@@ -140,17 +295,54 @@ func (c *Client) WaitMined(ctx context.Context, tx *types.Transaction) (*types.R
 	return receipt, nil
 }
 
-// extractError checks retrieves the error message from a failed transaction.
-func (c *Client) extractError(ctx context.Context, tx *types.Transaction) error {
-	msg := ethereum.CallMsg{
-		From:     c.address,
-		To:       tx.To(),
-		Gas:      tx.Gas(),
-		GasPrice: tx.GasPrice(),
-		Value:    tx.Value(),
-		Data:     tx.Data(),
+// waitMined polls for a receipt for any transaction in the replacement set of
+// the specified transaction, returning the receipt and the transaction that
+// was mined.
+func (c *Client) waitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, *types.Transaction, error) {
+	c.replacements.wait(tx.Nonce())
+	defer c.replacements.release(tx.Nonce())
+
+	queryTicker := time.NewTicker(time.Second)
+	defer queryTicker.Stop()
+
+	for {
+		if receipt, mined := c.findReceipt(ctx, tx); receipt != nil {
+			c.replacements.finish(tx.Nonce())
+			return receipt, mined, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// findReceipt looks for a receipt for any transaction in the replacement set
+// of the specified transaction, returning the receipt and the transaction
+// that was mined. A nil receipt is returned if none of them have been mined.
+func (c *Client) findReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, *types.Transaction) {
+	for _, candidate := range c.replacements.candidates(tx) {
+		receipt, err := c.TransactionReceipt(ctx, candidate.Hash())
+		if err == nil {
+			return receipt, candidate
+		}
+
+		if !errors.Is(err, ethereum.NotFound) {
+			log.Trace("Receipt retrieval failed", "hash", candidate.Hash(), "err", err)
+		}
 	}
 
-	_, err := c.CallContract(ctx, msg, nil)
-	return err
+	return nil, nil
+}
+
+// mulFloat multiplies the wei value by the specified multiplier,
+// truncating any fractional wei.
+func mulFloat(wei *big.Int, multiplier float64) *big.Int {
+	v := big.NewFloat(0).SetPrec(1024).SetInt(wei)
+	v.Mul(v, big.NewFloat(multiplier))
+
+	result, _ := v.Int(nil)
+	return result
 }
@@ -0,0 +1,125 @@
+package ethereum_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum/currency"
+)
+
+func TestDynamicFeeTransactOpts(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(2, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	baseFee, err := client.BaseFee(ctx, nil)
+	if err != nil {
+		t.Fatalf("unable to retrieve base fee: %s", err)
+	}
+
+	tip, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		t.Fatalf("unable to retrieve suggested tip: %s", err)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	multipliers := ethereum.FeeMultipliers{BaseFee: 3, Tip: 2}
+	txOpts, err := client.NewDynamicFeeTransactOpts(ctx, 21_000, multipliers, big.NewFloat(1))
+	if err != nil {
+		t.Fatalf("unable to create dynamic fee transaction opts: %s", err)
+	}
+
+	if txOpts.GasPrice != nil {
+		t.Fatalf("gas price should not be set, got %v", txOpts.GasPrice)
+	}
+
+	expTip := big.NewInt(0).Mul(tip, big.NewInt(2))
+	if txOpts.GasTipCap.Cmp(expTip) != 0 {
+		t.Fatalf("wrong gas tip cap, got %v  exp %v", txOpts.GasTipCap, expTip)
+	}
+
+	expFeeCap := big.NewInt(0).Mul(baseFee, big.NewInt(3))
+	expFeeCap.Add(expFeeCap, expTip)
+	if txOpts.GasFeeCap.Cmp(expFeeCap) != 0 {
+		t.Fatalf("wrong gas fee cap, got %v  exp %v", txOpts.GasFeeCap, expFeeCap)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	to, err := ethereum.NewClient(backend, backend.PrivateKeys[1])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	toAddress := to.Address()
+
+	startingBalance, err := client.Balance(ctx)
+	if err != nil {
+		t.Fatalf("unable to retrieve starting balance: %s", err)
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   backend.ChainID(),
+		GasTipCap: txOpts.GasTipCap,
+		GasFeeCap: txOpts.GasFeeCap,
+		Gas:       txOpts.GasLimit,
+		To:        &toAddress,
+		Value:     txOpts.Value,
+	})
+
+	tx, err = txOpts.Signer(client.Address(), tx)
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %s", err)
+	}
+
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("unable to send transaction: %s", err)
+	}
+
+	receipt, err := client.WaitMined(ctx, tx)
+	if err != nil {
+		t.Fatalf("waiting for transaction: %s", err)
+	}
+
+	if tx.Type() != types.DynamicFeeTxType {
+		t.Fatalf("wrong transaction type, got %d  exp %d", tx.Type(), types.DynamicFeeTxType)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	blockBaseFee, err := client.BaseFee(ctx, receipt.BlockNumber)
+	if err != nil {
+		t.Fatalf("unable to retrieve block base fee: %s", err)
+	}
+
+	gasPrice, paidTip := currency.EffectiveGasPrice(tx, blockBaseFee)
+	if paidTip.Cmp(expTip) != 0 {
+		t.Fatalf("wrong tip paid, got %v  exp %v", paidTip, expTip)
+	}
+
+	endingBalance, err := client.Balance(ctx)
+	if err != nil {
+		t.Fatalf("unable to retrieve ending balance: %s", err)
+	}
+
+	cost := big.NewInt(0).Mul(gasPrice, big.NewInt(int64(receipt.GasUsed)))
+	cost.Add(cost, tx.Value())
+
+	diff := big.NewInt(0).Sub(startingBalance, endingBalance)
+	if diff.Cmp(cost) != 0 {
+		t.Fatalf("wrong cost, got %v  exp %v", diff, cost)
+	}
+}
@@ -0,0 +1,513 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Set of defaults used when the EventFilter fields are left at zero.
+const (
+	defaultChunkSize   = 1_000
+	defaultReorgWindow = 64
+)
+
+// EventFilter queries and streams the events of a single contract. Events are
+// streamed using a log subscription when the node supports it, otherwise the
+// node is polled for logs, which is the case for HTTP connections.
+type EventFilter struct {
+	backend  Backend
+	address  common.Address
+	abi      abi.ABI
+	contract *bind.BoundContract
+
+	PollInterval time.Duration // How often logs are polled without a subscription (0 = 1s)
+	ChunkSize    uint64        // Number of blocks requested per FilterLogs call (0 = 1000)
+	ReorgWindow  uint64        // Number of blocks checked for reorgs when polling (0 = 64)
+}
+
+// NewEventFilter constructs an event filter for the contract deployed at the
+// specified address.
+func NewEventFilter(backend Backend, address common.Address, contractABI abi.ABI) *EventFilter {
+	return &EventFilter{
+		backend:  backend,
+		address:  address,
+		abi:      contractABI,
+		contract: bind.NewBoundContract(address, contractABI, backend, backend, backend),
+	}
+}
+
+// Event represents a decoded contract event and where it was emitted.
+type Event[T any] struct {
+	Name        string
+	Data        T
+	Address     common.Address
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	TxIndex     uint
+	LogIndex    uint
+
+	// Removed is true if the event was reverted due to a chain reorganisation.
+	// The event was delivered before and should be undone.
+	Removed bool
+}
+
+// DecodeEvent decodes the log into the fields of T, matched by the argument
+// names of the named event in the ABI.
+func DecodeEvent[T any](ef *EventFilter, name string, log types.Log) (Event[T], error) {
+	evt := Event[T]{
+		Name:        name,
+		Address:     log.Address,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		LogIndex:    log.Index,
+		Removed:     log.Removed,
+	}
+
+	if len(log.Topics) == 0 {
+		return Event[T]{}, fmt.Errorf("log %s:%d has no topics", log.TxHash, log.Index)
+	}
+
+	if err := ef.contract.UnpackLog(&evt.Data, name, log); err != nil {
+		return Event[T]{}, fmt.Errorf("unpacking %s log %s:%d: %w", name, log.TxHash, log.Index, err)
+	}
+
+	return evt, nil
+}
+
+// QueryEvents retrieves the named events emitted in the block range of the
+// filter opts. The range is requested in chunks of ChunkSize blocks so large
+// ranges aren't rejected by the node. A nil End queries up to the latest
+// block.
+func QueryEvents[T any](opts *bind.FilterOpts, ef *EventFilter, name string) ([]Event[T], error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query, err := ef.filterQuery(name)
+	if err != nil {
+		return nil, err
+	}
+
+	end := opts.End
+	if end == nil {
+		header, err := ef.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving latest header: %w", err)
+		}
+		latest := header.Number.Uint64()
+		end = &latest
+	}
+
+	logs, err := ef.filterLogs(ctx, query, opts.Start, *end)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event[T], len(logs))
+	for i, log := range logs {
+		if events[i], err = DecodeEvent[T](ef, name, log); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// WatchEvents streams the named events into the sink as they are emitted. If
+// the watch opts have a Start block, the events emitted since then are
+// delivered first. Events reverted by a reorg are delivered again with
+// Removed set.
+func WatchEvents[T any](opts *bind.WatchOpts, ef *EventFilter, name string, sink chan<- Event[T]) (event.Subscription, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query, err := ef.filterQuery(name)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(chan types.Log)
+
+	logSub, err := ef.watchLogs(ctx, query, opts.Start, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		defer logSub.Unsubscribe()
+
+		for {
+			select {
+			case log := <-logs:
+				evt, err := DecodeEvent[T](ef, name, log)
+				if err != nil {
+					return err
+				}
+
+				select {
+				case sink <- evt:
+				case <-quit:
+					return nil
+				}
+
+			case err := <-logSub.Err():
+				return err
+
+			case <-quit:
+				return nil
+			}
+		}
+	})
+
+	return sub, nil
+}
+
+// filterQuery constructs the query for the named event of the contract.
+func (ef *EventFilter) filterQuery(name string) (ethereum.FilterQuery, error) {
+	abiEvent, exists := ef.abi.Events[name]
+	if !exists {
+		return ethereum.FilterQuery{}, fmt.Errorf("event %q not found in abi", name)
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{ef.address},
+		Topics:    [][]common.Hash{{abiEvent.ID}},
+	}
+
+	return query, nil
+}
+
+// filterLogs retrieves the logs for the query in the block range, one chunk
+// of blocks at a time.
+func (ef *EventFilter) filterLogs(ctx context.Context, query ethereum.FilterQuery, from uint64, to uint64) ([]types.Log, error) {
+	chunkSize := ef.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	var logs []types.Log
+
+	for start := from; start <= to; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > to {
+			end = to
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+
+		chunk, err := ef.backend.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("filtering logs in blocks %d-%d: %w", start, end, err)
+		}
+
+		logs = append(logs, chunk...)
+	}
+
+	return logs, nil
+}
+
+// watchLogs streams the logs for the query into the channel, subscribing if
+// the node supports it and polling otherwise.
+func (ef *EventFilter) watchLogs(ctx context.Context, query ethereum.FilterQuery, start *uint64, logs chan<- types.Log) (event.Subscription, error) {
+	subLogs := make(chan types.Log)
+
+	logSub, err := ef.backend.SubscribeFilterLogs(ctx, query, subLogs)
+	switch {
+	case errors.Is(err, rpc.ErrNotificationsUnsupported):
+		return ef.pollLogs(ctx, query, start, logs)
+	case err != nil:
+		return nil, fmt.Errorf("subscribing to logs: %w", err)
+	}
+
+	// The head is retrieved after subscribing, so every block after it is
+	// covered by the subscription.
+	header, err := ef.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		logSub.Unsubscribe()
+		return nil, fmt.Errorf("retrieving latest header: %w", err)
+	}
+	head := header.Number.Uint64()
+
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		defer logSub.Unsubscribe()
+
+		if start != nil {
+			backfill, err := ef.filterLogs(ctx, query, *start, head)
+			if err != nil {
+				return err
+			}
+
+			for _, log := range backfill {
+				select {
+				case logs <- log:
+				case <-quit:
+					return nil
+				}
+			}
+		}
+
+		for {
+			select {
+			case log := <-subLogs:
+				// Logs up to the head were already delivered by the backfill.
+				if start != nil && !log.Removed && log.BlockNumber <= head {
+					continue
+				}
+
+				select {
+				case logs <- log:
+				case <-quit:
+					return nil
+				}
+
+			case err := <-logSub.Err():
+				return err
+
+			case <-quit:
+				return nil
+			}
+		}
+	})
+
+	return sub, nil
+}
+
+// pollLogs streams the logs for the query into the channel by polling the
+// node every PollInterval.
+func (ef *EventFilter) pollLogs(ctx context.Context, query ethereum.FilterQuery, start *uint64, logs chan<- types.Log) (event.Subscription, error) {
+	p := logPoller{
+		ef:    ef,
+		query: query,
+		known: make(map[uint64]common.Hash),
+	}
+
+	switch start {
+	case nil:
+		header, err := ef.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving latest header: %w", err)
+		}
+		p.next = header.Number.Uint64() + 1
+
+	default:
+		p.next = *start
+	}
+
+	interval := ef.PollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		// Polling stops when the caller's context is done, which is reported
+		// as the subscription's error, or when the subscription is closed.
+		pollCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-pollCtx.Done():
+			}
+		}()
+
+		deliver := func(log types.Log) bool {
+			select {
+			case logs <- log:
+				return true
+			case <-pollCtx.Done():
+				return false
+			}
+		}
+
+		queryTicker := time.NewTicker(interval)
+		defer queryTicker.Stop()
+
+		for {
+			if err := p.poll(pollCtx, deliver); err != nil {
+				if pollCtx.Err() != nil {
+					return ctx.Err()
+				}
+				return err
+			}
+
+			select {
+			case <-pollCtx.Done():
+				return ctx.Err()
+			case <-queryTicker.C:
+			}
+		}
+	})
+
+	return sub, nil
+}
+
+// FindFork compares the hashes of processed blocks, keyed by block number,
+// with the canonical chain. If any of them were reorged, the first block
+// number after the most recent block that is still canonical is returned, or
+// the oldest block number if none of them are.
+func FindFork(ctx context.Context, backend Backend, known map[uint64]common.Hash) (uint64, bool, error) {
+	numbers := make([]uint64, 0, len(known))
+	for number := range known {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+
+	for i, number := range numbers {
+		header, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return 0, false, fmt.Errorf("retrieving header %d: %w", number, err)
+		}
+
+		// Every ancestor of a canonical block is canonical, so only the
+		// blocks after this one could have been reorged.
+		if header != nil && header.Hash() == known[number] {
+			return number + 1, i > 0, nil
+		}
+	}
+
+	if len(numbers) == 0 {
+		return 0, false, nil
+	}
+
+	return numbers[len(numbers)-1], true, nil
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// logPoller polls the node for new logs and detects reorgs by comparing the
+// hashes of the blocks it has processed with the canonical chain.
+type logPoller struct {
+	ef        *EventFilter
+	query     ethereum.FilterQuery
+	next      uint64
+	known     map[uint64]common.Hash
+	delivered []types.Log
+}
+
+// poll delivers the removed logs of any processed blocks that were reorged,
+// followed by the logs of the blocks mined since the last poll. The deliver
+// func returns false if the logs are no longer wanted.
+func (p *logPoller) poll(ctx context.Context, deliver func(types.Log) bool) error {
+	header, err := p.ef.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("retrieving latest header: %w", err)
+	}
+	head := header.Number.Uint64()
+
+	fork, reorged, err := p.findFork(ctx)
+	if err != nil {
+		return err
+	}
+
+	if reorged {
+		if !p.rewind(fork, deliver) {
+			return nil
+		}
+	}
+
+	if p.next > head {
+		return nil
+	}
+
+	logs, err := p.ef.filterLogs(ctx, p.query, p.next, head)
+	if err != nil {
+		return err
+	}
+
+	for _, log := range logs {
+		if !deliver(log) {
+			return nil
+		}
+		p.delivered = append(p.delivered, log)
+		p.known[log.BlockNumber] = log.BlockHash
+	}
+
+	p.known[head] = header.Hash()
+	p.next = head + 1
+	p.prune(head)
+
+	return nil
+}
+
+// findFork returns where to rewind the poller to if any processed block was
+// reorged.
+func (p *logPoller) findFork(ctx context.Context) (uint64, bool, error) {
+	return FindFork(ctx, p.ef.backend, p.known)
+}
+
+// rewind delivers the logs from the fork onwards as removed, newest first,
+// and moves the poller back to the fork.
+func (p *logPoller) rewind(fork uint64, deliver func(types.Log) bool) bool {
+	i := len(p.delivered)
+	for i > 0 && p.delivered[i-1].BlockNumber >= fork {
+		i--
+	}
+
+	for j := len(p.delivered) - 1; j >= i; j-- {
+		log := p.delivered[j]
+		log.Removed = true
+
+		if !deliver(log) {
+			return false
+		}
+		p.delivered = p.delivered[:j]
+	}
+
+	for number := range p.known {
+		if number >= fork {
+			delete(p.known, number)
+		}
+	}
+
+	if fork < p.next {
+		p.next = fork
+	}
+
+	return true
+}
+
+// prune forgets the blocks that are too old to be checked for reorgs.
+func (p *logPoller) prune(head uint64) {
+	window := p.ef.ReorgWindow
+	if window == 0 {
+		window = defaultReorgWindow
+	}
+
+	if head < window {
+		return
+	}
+	oldest := head - window
+
+	for number := range p.known {
+		if number < oldest {
+			delete(p.known, number)
+		}
+	}
+
+	i := 0
+	for i < len(p.delivered) && p.delivered[i].BlockNumber < oldest {
+		i++
+	}
+	p.delivered = p.delivered[i:]
+}
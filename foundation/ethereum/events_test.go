@@ -0,0 +1,262 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// valueSet is the decoded ValueSet event of the Events fixture.
+type valueSet struct {
+	Sender common.Address
+	Key    string
+	Value  *big.Int
+}
+
+// httpBackend behaves like a backend connected over HTTP, which can't
+// subscribe to logs.
+type httpBackend struct {
+	*ethereum.SimulatedBackend
+}
+
+// SubscribeFilterLogs implements the bind.ContractFilterer interface.
+func (httpBackend) SubscribeFilterLogs(context.Context, goethereum.FilterQuery, chan<- types.Log) (goethereum.Subscription, error) {
+	return nil, rpc.ErrNotificationsUnsupported
+}
+
+// receive waits for the next event delivered by the subscription.
+func receive(t *testing.T, sub event.Subscription, sink <-chan ethereum.Event[valueSet]) ethereum.Event[valueSet] {
+	t.Helper()
+
+	select {
+	case evt := <-sink:
+		return evt
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	return ethereum.Event[valueSet]{}
+}
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+
+	// Auto commit is disabled so the test controls when blocks are mined.
+	backend, err := ethereum.CreateSimulatedBackend(1, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	// The fixture is deployed with auto commit so deployFixture can wait
+	// for it to be mined.
+	backend.AutoCommit = true
+	address, contract := deployFixture(t, client, "Events")
+	backend.AutoCommit = false
+
+	abiData, err := os.ReadFile("testdata/events/Events.abi")
+	if err != nil {
+		t.Fatalf("unable to read abi: %s", err)
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(string(abiData)))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %s", err)
+	}
+
+	// set sends a Set transaction and mines it in its own block.
+	set := func(t *testing.T, key string, value int64) *types.Transaction {
+		txOpts, err := client.NewTransactOpts(ctx, 100_000, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := contract.Transact(txOpts, "Set", key, big.NewInt(value))
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+		backend.Commit()
+
+		return tx
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("query", func(t *testing.T) {
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+		start := header.Number.Uint64() + 1
+
+		var txs []*types.Transaction
+		for i := int64(0); i < 5; i++ {
+			txs = append(txs, set(t, "key", i))
+		}
+
+		ef := ethereum.NewEventFilter(backend, address, contractABI)
+		ef.ChunkSize = 2
+
+		events, err := ethereum.QueryEvents[valueSet](&bind.FilterOpts{Start: start, Context: ctx}, ef, "ValueSet")
+		if err != nil {
+			t.Fatalf("unable to query events: %s", err)
+		}
+
+		if len(events) != len(txs) {
+			t.Fatalf("wrong number of events, got %d  exp %d", len(events), len(txs))
+		}
+
+		for i, evt := range events {
+			if evt.TxHash != txs[i].Hash() {
+				t.Fatalf("wrong tx hash for event %d, got %s  exp %s", i, evt.TxHash, txs[i].Hash())
+			}
+
+			if evt.BlockNumber != start+uint64(i) {
+				t.Fatalf("wrong block number for event %d, got %d  exp %d", i, evt.BlockNumber, start+uint64(i))
+			}
+
+			if evt.Data.Sender != client.Address() || evt.Data.Key != "key" || evt.Data.Value.Int64() != int64(i) {
+				t.Fatalf("wrong data for event %d, got %+v", i, evt.Data)
+			}
+		}
+
+		end := start + 1
+		events, err = ethereum.QueryEvents[valueSet](&bind.FilterOpts{Start: start, End: &end, Context: ctx}, ef, "ValueSet")
+		if err != nil {
+			t.Fatalf("unable to query events: %s", err)
+		}
+
+		if len(events) != 2 {
+			t.Fatalf("wrong number of events in range, got %d  exp %d", len(events), 2)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("subscribe", func(t *testing.T) {
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+		start := header.Number.Uint64()
+
+		ef := ethereum.NewEventFilter(backend, address, contractABI)
+
+		sink := make(chan ethereum.Event[valueSet])
+		sub, err := ethereum.WatchEvents(&bind.WatchOpts{Start: &start, Context: ctx}, ef, "ValueSet", sink)
+		if err != nil {
+			t.Fatalf("unable to watch events: %s", err)
+		}
+		defer sub.Unsubscribe()
+
+		// The last event of the query subtest is backfilled.
+		if evt := receive(t, sub, sink); evt.BlockNumber != start || evt.Data.Value.Int64() != 4 {
+			t.Fatalf("backfilled event should be delivered first, got block %d value %v", evt.BlockNumber, evt.Data.Value)
+		}
+
+		tx := set(t, "live", 10)
+
+		evt := receive(t, sub, sink)
+		if evt.TxHash != tx.Hash() || evt.Data.Key != "live" || evt.Removed {
+			t.Fatalf("wrong live event, got %+v", evt)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("poll with reorg", func(t *testing.T) {
+		ef := ethereum.NewEventFilter(httpBackend{backend}, address, contractABI)
+		ef.PollInterval = 10 * time.Millisecond
+
+		sink := make(chan ethereum.Event[valueSet])
+		sub, err := ethereum.WatchEvents(&bind.WatchOpts{Context: ctx}, ef, "ValueSet", sink)
+		if err != nil {
+			t.Fatalf("unable to watch events: %s", err)
+		}
+		defer sub.Unsubscribe()
+
+		tx := set(t, "poll", 20)
+
+		evt := receive(t, sub, sink)
+		if evt.TxHash != tx.Hash() || evt.Data.Key != "poll" || evt.Removed {
+			t.Fatalf("wrong polled event, got %+v", evt)
+		}
+
+		// Replace the block the event was emitted in with a longer chain
+		// without it.
+		header, err := backend.HeaderByHash(ctx, evt.BlockHash)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+
+		if err := backend.Fork(ctx, header.ParentHash); err != nil {
+			t.Fatalf("unable to fork: %s", err)
+		}
+		backend.Commit()
+		backend.Commit()
+
+		removed := receive(t, sub, sink)
+		if removed.TxHash != tx.Hash() || !removed.Removed {
+			t.Fatalf("reorged event should be removed, got %+v", removed)
+		}
+
+		// The transaction is mined again on the new chain.
+		if err := backend.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("unable to resend transaction: %s", err)
+		}
+		backend.Commit()
+
+		evt = receive(t, sub, sink)
+		if evt.TxHash != tx.Hash() || evt.Removed || evt.BlockHash == removed.BlockHash {
+			t.Fatalf("event should be delivered from the new chain, got %+v", evt)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("poll stops with context", func(t *testing.T) {
+		ef := ethereum.NewEventFilter(httpBackend{backend}, address, contractABI)
+		ef.PollInterval = 10 * time.Millisecond
+
+		watchCtx, cancel := context.WithCancel(ctx)
+
+		sink := make(chan ethereum.Event[valueSet])
+		sub, err := ethereum.WatchEvents(&bind.WatchOpts{Context: watchCtx}, ef, "ValueSet", sink)
+		if err != nil {
+			t.Fatalf("unable to watch events: %s", err)
+		}
+		defer sub.Unsubscribe()
+
+		cancel()
+
+		select {
+		case err := <-sub.Err():
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("should stop with the context's error, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("polling should stop when the context is cancelled")
+		}
+	})
+}
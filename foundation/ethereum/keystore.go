@@ -0,0 +1,193 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// ScryptParams represents the cost of the scrypt key derivation used to
+// encrypt key files. Higher costs make the passphrase harder to brute force
+// and the key file slower to unlock.
+type ScryptParams struct {
+	N int
+	P int
+}
+
+// Set of scrypt parameters geth provides.
+var (
+	ScryptStandard = ScryptParams{N: keystore.StandardScryptN, P: keystore.StandardScryptP}
+	ScryptLight    = ScryptParams{N: keystore.LightScryptN, P: keystore.LightScryptP}
+)
+
+// ParseScrypt returns the scrypt parameters with the name, standard or light.
+func ParseScrypt(name string) (ScryptParams, error) {
+	switch name {
+	case "standard":
+		return ScryptStandard, nil
+	case "light":
+		return ScryptLight, nil
+	}
+
+	return ScryptParams{}, fmt.Errorf("unknown scrypt parameters %q", name)
+}
+
+// Validate checks the parameters are usable by scrypt and no cheaper than
+// ScryptLight, a lower cost leaves the passphrase too easy to brute force.
+func (s ScryptParams) Validate() error {
+	if s.N <= 1 || s.N&(s.N-1) != 0 {
+		return fmt.Errorf("scrypt N %d must be a power of two above 1", s.N)
+	}
+
+	if s.P < 1 {
+		return fmt.Errorf("scrypt P %d must be at least 1", s.P)
+	}
+
+	if s.N < keystore.LightScryptN {
+		return fmt.Errorf("scrypt N %d is below the light cost of %d", s.N, keystore.LightScryptN)
+	}
+
+	return nil
+}
+
+// ErrAccountExists is returned when a key is imported into a keystore that
+// already has the account.
+var ErrAccountExists = errors.New("account already exists")
+
+// KeyStoreConfig represents the settings of a keystore.
+type KeyStoreConfig struct {
+	Dir    string
+	Scrypt ScryptParams // zero value = ScryptStandard
+}
+
+// KeyStore manages the encrypted key files in a keystore directory. Every key
+// file it writes is encrypted with its scrypt parameters.
+type KeyStore struct {
+	ks *keystore.KeyStore
+}
+
+// NewKeyStore constructs a keystore for the directory, creating the directory
+// if it doesn't exist.
+func NewKeyStore(cfg KeyStoreConfig) (*KeyStore, error) {
+	scrypt := cfg.Scrypt
+	if scrypt == (ScryptParams{}) {
+		scrypt = ScryptStandard
+	}
+
+	if err := scrypt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating keystore: %w", err)
+	}
+
+	k := KeyStore{
+		ks: keystore.NewKeyStore(cfg.Dir, scrypt.N, scrypt.P),
+	}
+
+	return &k, nil
+}
+
+// Create generates a new key and stores it encrypted with the passphrase.
+func (k *KeyStore) Create(passphrase string) (Account, error) {
+	account, err := k.ks.NewAccount(passphrase)
+	if err != nil {
+		return Account{}, fmt.Errorf("creating account: %w", err)
+	}
+
+	return Account{Address: account.Address, File: account.URL.Path}, nil
+}
+
+// ImportKey stores the private key encrypted with the passphrase.
+func (k *KeyStore) ImportKey(key *ecdsa.PrivateKey, passphrase string) (Account, error) {
+	account, err := k.ks.ImportECDSA(key, passphrase)
+	if err != nil {
+		if errors.Is(err, keystore.ErrAccountAlreadyExists) {
+			return Account{}, fmt.Errorf("importing %s: %w", account.Address, ErrAccountExists)
+		}
+		return Account{}, fmt.Errorf("importing key: %w", err)
+	}
+
+	return Account{Address: account.Address, File: account.URL.Path}, nil
+}
+
+// ImportMnemonic derives the private key at the BIP-32 path from the BIP-39
+// mnemonic and its passphrase, and stores it encrypted with the keystore
+// passphrase.
+func (k *KeyStore) ImportMnemonic(mnemonic string, mnemonicPassphrase string, path accounts.DerivationPath, passphrase string) (Account, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	key, err := DeriveKey(seed, path)
+	if err != nil {
+		return Account{}, fmt.Errorf("deriving key at %s: %w", path, err)
+	}
+
+	return k.ImportKey(key, passphrase)
+}
+
+// Export decrypts the key file of the account with the passphrase and
+// returns its private key.
+func (k *KeyStore) Export(address common.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	account, err := k.find(address)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := PrivateKeyByKeyFile(account.URL.Path, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unlocking %s: %w", address, err)
+	}
+
+	return key, nil
+}
+
+// ChangePassphrase re-encrypts the key file of the account with the new
+// passphrase, using the keystore's scrypt parameters.
+func (k *KeyStore) ChangePassphrase(address common.Address, passphrase string, newPassphrase string) error {
+	account, err := k.find(address)
+	if err != nil {
+		return err
+	}
+
+	if err := k.ks.Update(account, passphrase, newPassphrase); err != nil {
+		return fmt.Errorf("updating %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// Unlock decrypts the key of the account with the passphrase and keeps it in
+// the keystore, returning a signer for the account. The key is never handed
+// out.
+func (k *KeyStore) Unlock(address common.Address, passphrase string) (*KeyStoreSigner, error) {
+	account, err := k.find(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k.ks.Unlock(account, passphrase); err != nil {
+		return nil, fmt.Errorf("unlocking %s: %w", address, err)
+	}
+
+	return NewKeyStoreSigner(k.ks, address)
+}
+
+// find returns the keystore account with the address.
+func (k *KeyStore) find(address common.Address) (accounts.Account, error) {
+	account, err := k.ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("account %s: %w", address, err)
+	}
+
+	return account, nil
+}
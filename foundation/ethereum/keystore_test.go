@@ -0,0 +1,200 @@
+package ethereum_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestKeyStore(t *testing.T) {
+	const passphrase = "123"
+
+	ks, err := ethereum.NewKeyStore(ethereum.KeyStoreConfig{
+		Dir:    t.TempDir(),
+		Scrypt: ethereum.ScryptLight,
+	})
+	if err != nil {
+		t.Fatalf("unable to create keystore: %s", err)
+	}
+
+	// export unlocks the account and checks the key matches its address.
+	export := func(t *testing.T, address common.Address, passphrase string) {
+		t.Helper()
+
+		key, err := ks.Export(address, passphrase)
+		if err != nil {
+			t.Fatalf("unable to export %s: %s", address, err)
+		}
+
+		if got := crypto.PubkeyToAddress(key.PublicKey); got != address {
+			t.Fatalf("wrong key, got %s  exp %s", got, address)
+		}
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("create", func(t *testing.T) {
+		account, err := ks.Create(passphrase)
+		if err != nil {
+			t.Fatalf("unable to create account: %s", err)
+		}
+
+		export(t, account.Address, passphrase)
+
+		if _, err := ks.Export(account.Address, "wrong"); err == nil {
+			t.Fatal("should fail to export with the wrong passphrase")
+		}
+
+		data, err := os.ReadFile(account.File)
+		if err != nil {
+			t.Fatalf("unable to read key file: %s", err)
+		}
+
+		var keyFile struct {
+			Crypto struct {
+				KDFParams struct {
+					N int `json:"n"`
+					P int `json:"p"`
+				} `json:"kdfparams"`
+			} `json:"crypto"`
+		}
+		if err := json.Unmarshal(data, &keyFile); err != nil {
+			t.Fatalf("unable to decode key file: %s", err)
+		}
+
+		params := ethereum.ScryptParams{N: keyFile.Crypto.KDFParams.N, P: keyFile.Crypto.KDFParams.P}
+		if params != ethereum.ScryptLight {
+			t.Fatalf("wrong scrypt parameters, got %+v  exp %+v", params, ethereum.ScryptLight)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("import key", func(t *testing.T) {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("unable to generate key: %s", err)
+		}
+
+		account, err := ks.ImportKey(key, passphrase)
+		if err != nil {
+			t.Fatalf("unable to import key: %s", err)
+		}
+
+		if account.Address != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Fatalf("wrong address, got %s  exp %s", account.Address, crypto.PubkeyToAddress(key.PublicKey))
+		}
+
+		export(t, account.Address, passphrase)
+
+		if _, err := ks.ImportKey(key, passphrase); !errors.Is(err, ethereum.ErrAccountExists) {
+			t.Fatalf("should fail to import the key twice, got %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("import mnemonic", func(t *testing.T) {
+		const mnemonic = "test test test test test test test test test test test junk"
+
+		path, err := accounts.ParseDerivationPath("m/44'/60'/0'/0/1")
+		if err != nil {
+			t.Fatalf("unable to parse path: %s", err)
+		}
+
+		account, err := ks.ImportMnemonic(mnemonic, "", path, passphrase)
+		if err != nil {
+			t.Fatalf("unable to import mnemonic: %s", err)
+		}
+
+		exp := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+		if account.Address != exp {
+			t.Fatalf("wrong address, got %s  exp %s", account.Address, exp)
+		}
+
+		if _, err := ks.ImportMnemonic("test test junk", "", path, passphrase); err == nil {
+			t.Fatal("should fail to import an invalid mnemonic")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("change passphrase", func(t *testing.T) {
+		account, err := ks.Create(passphrase)
+		if err != nil {
+			t.Fatalf("unable to create account: %s", err)
+		}
+
+		if err := ks.ChangePassphrase(account.Address, "wrong", "456"); err == nil {
+			t.Fatal("should fail to change the passphrase with the wrong passphrase")
+		}
+
+		if err := ks.ChangePassphrase(account.Address, passphrase, "456"); err != nil {
+			t.Fatalf("unable to change passphrase: %s", err)
+		}
+
+		export(t, account.Address, "456")
+
+		if _, err := ks.Export(account.Address, passphrase); err == nil {
+			t.Fatal("should fail to export with the old passphrase")
+		}
+
+		if err := ks.ChangePassphrase(common.Address{}, passphrase, "456"); err == nil {
+			t.Fatal("should fail for an unknown account")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("scrypt", func(t *testing.T) {
+		params, err := ethereum.ParseScrypt("standard")
+		if err != nil {
+			t.Fatalf("unable to parse scrypt: %s", err)
+		}
+
+		if params != ethereum.ScryptStandard {
+			t.Fatalf("wrong scrypt parameters, got %+v  exp %+v", params, ethereum.ScryptStandard)
+		}
+
+		if _, err := ethereum.ParseScrypt("fast"); err == nil {
+			t.Fatal("should fail for unknown scrypt parameters")
+		}
+
+		for _, params := range []ethereum.ScryptParams{ethereum.ScryptStandard, ethereum.ScryptLight, {N: 1 << 20, P: 2}} {
+			if err := params.Validate(); err != nil {
+				t.Fatalf("%+v should be valid, got %s", params, err)
+			}
+		}
+
+		invalid := []ethereum.ScryptParams{
+			{N: 0, P: 1},
+			{N: 1, P: 1},
+			{N: -4096, P: 1},
+			{N: 300_000, P: 1},
+			{N: 1 << 18, P: 0},
+			{N: 1 << 18, P: -1},
+			{N: 1 << 10, P: 1},
+		}
+		for _, params := range invalid {
+			if err := params.Validate(); err == nil {
+				t.Fatalf("%+v should be invalid", params)
+			}
+
+			dir := t.TempDir() + "/keystore"
+			if _, err := ethereum.NewKeyStore(ethereum.KeyStoreConfig{Dir: dir, Scrypt: params}); err == nil {
+				t.Fatalf("keystore with %+v should fail", params)
+			}
+
+			if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("keystore with %+v should not create the directory, got %v", params, err)
+			}
+		}
+	})
+}
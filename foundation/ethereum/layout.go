@@ -0,0 +1,191 @@
+package ethereum
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrLayoutIncompatible is returned when an implementation can't be used by
+// a proxy because their storage layouts differ.
+var ErrLayoutIncompatible = errors.New("storage layouts are incompatible")
+
+// StorageLayout represents the storage layout report solc produces for a
+// contract with --storage-layout.
+type StorageLayout struct {
+	Storage []StorageVariable      `json:"storage"`
+	Types   map[string]StorageType `json:"types"`
+}
+
+// StorageVariable represents a state variable and where it's stored.
+type StorageVariable struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// StorageType represents how a type is stored. Mappings have a key and
+// value, arrays a base and structs members.
+type StorageType struct {
+	Encoding      string            `json:"encoding"`
+	Label         string            `json:"label"`
+	NumberOfBytes string            `json:"numberOfBytes"`
+	Key           string            `json:"key,omitempty"`
+	Value         string            `json:"value,omitempty"`
+	Base          string            `json:"base,omitempty"`
+	Members       []StorageVariable `json:"members,omitempty"`
+}
+
+// ParseStorageLayouts parses the output of solc --storage-layout into the
+// layout of each contract by name. Both the text output of solc, where a
+// header names the contract before its layout, and one JSON object per line
+// holding the contract and its storageLayout are accepted.
+func ParseStorageLayouts(data []byte) (map[string]StorageLayout, error) {
+	layouts := make(map[string]StorageLayout)
+
+	var contract string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "======="):
+			contract = strings.TrimSpace(strings.Trim(line, "="))
+
+		case strings.HasPrefix(line, "{"):
+			var report struct {
+				Contract      string         `json:"contract"`
+				StorageLayout *StorageLayout `json:"storageLayout"`
+			}
+			if err := json.Unmarshal([]byte(line), &report); err != nil {
+				return nil, fmt.Errorf("decoding storage layout: %w", err)
+			}
+
+			if report.StorageLayout != nil {
+				layouts[contractName(report.Contract)] = *report.StorageLayout
+				continue
+			}
+
+			if contract == "" {
+				return nil, errors.New("storage layout without a contract")
+			}
+
+			var layout StorageLayout
+			if err := json.Unmarshal([]byte(line), &layout); err != nil {
+				return nil, fmt.Errorf("decoding storage layout of %s: %w", contract, err)
+			}
+			layouts[contractName(contract)] = layout
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading storage layouts: %w", err)
+	}
+
+	return layouts, nil
+}
+
+// CheckStorageLayout verifies an implementation can be delegate called by a
+// proxy. Every variable of the proxy must be at the same slot and offset in
+// the implementation, with the same name and an identically stored type.
+// The implementation may add variables after them. Every problem found is
+// reported in an error wrapping ErrLayoutIncompatible.
+func CheckStorageLayout(proxy StorageLayout, impl StorageLayout) error {
+	var errs []error
+
+	for i, pv := range proxy.Storage {
+		if i >= len(impl.Storage) {
+			errs = append(errs, fmt.Errorf("%s (slot %s): missing from the implementation", pv.Label, pv.Slot))
+			continue
+		}
+
+		iv := impl.Storage[i]
+
+		if pv.Label != iv.Label {
+			errs = append(errs, fmt.Errorf("%s (slot %s): implementation has %s in its place", pv.Label, pv.Slot, iv.Label))
+			continue
+		}
+
+		if pv.Slot != iv.Slot || pv.Offset != iv.Offset {
+			errs = append(errs, fmt.Errorf("%s: stored at slot %s offset %d, implementation uses slot %s offset %d", pv.Label, pv.Slot, pv.Offset, iv.Slot, iv.Offset))
+			continue
+		}
+
+		if err := compareTypes(proxy, pv.Type, impl, iv.Type); err != nil {
+			errs = append(errs, fmt.Errorf("%s (slot %s): %w", pv.Label, pv.Slot, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrLayoutIncompatible, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// compareTypes verifies the types are stored identically. Type ids hold AST
+// ids for user defined types, which differ between compilations, so the
+// types are compared by their description.
+func compareTypes(a StorageLayout, aID string, b StorageLayout, bID string) error {
+	at, aExists := a.Types[aID]
+	bt, bExists := b.Types[bID]
+
+	switch {
+	case !aExists || !bExists:
+		if aID != bID {
+			return fmt.Errorf("type %s, implementation has %s", aID, bID)
+		}
+		return nil
+
+	case at.Encoding != bt.Encoding || at.NumberOfBytes != bt.NumberOfBytes:
+		return fmt.Errorf("type %s is %s encoded in %s bytes, implementation has %s %s encoded in %s bytes", at.Label, at.Encoding, at.NumberOfBytes, bt.Label, bt.Encoding, bt.NumberOfBytes)
+
+	case len(at.Members) == 0 && at.Label != bt.Label:
+		return fmt.Errorf("type %s, implementation has %s", at.Label, bt.Label)
+	}
+
+	for _, pair := range [][2]string{{at.Key, bt.Key}, {at.Value, bt.Value}, {at.Base, bt.Base}} {
+		if pair[0] == "" && pair[1] == "" {
+			continue
+		}
+
+		if err := compareTypes(a, pair[0], b, pair[1]); err != nil {
+			return fmt.Errorf("%s: %w", at.Label, err)
+		}
+	}
+
+	if len(at.Members) != len(bt.Members) {
+		return fmt.Errorf("%s has %d members, implementation has %d", at.Label, len(at.Members), len(bt.Members))
+	}
+
+	for i, am := range at.Members {
+		bm := bt.Members[i]
+
+		if am.Label != bm.Label || am.Slot != bm.Slot || am.Offset != bm.Offset {
+			return fmt.Errorf("%s member %s at slot %s offset %d, implementation has %s at slot %s offset %d", at.Label, am.Label, am.Slot, am.Offset, bm.Label, bm.Slot, bm.Offset)
+		}
+
+		if err := compareTypes(a, am.Type, b, bm.Type); err != nil {
+			return fmt.Errorf("%s member %s: %w", at.Label, am.Label, err)
+		}
+	}
+
+	return nil
+}
+
+// contractName returns the name of the contract from its fully qualified
+// name, such as src/bank/bank.sol:bank.
+func contractName(qualified string) string {
+	if i := strings.LastIndex(qualified, ":"); i >= 0 {
+		return qualified[i+1:]
+	}
+
+	return qualified
+}
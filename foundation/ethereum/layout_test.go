@@ -0,0 +1,161 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestStorageLayout(t *testing.T) {
+	// layout reads the contract's layout from the solc report.
+	layout := func(t *testing.T, file string, contract string) ethereum.StorageLayout {
+		t.Helper()
+
+		data, err := os.ReadFile("testdata/layout/" + file)
+		if err != nil {
+			t.Fatalf("unable to read report: %s", err)
+		}
+
+		layouts, err := ethereum.ParseStorageLayouts(data)
+		if err != nil {
+			t.Fatalf("unable to parse report: %s", err)
+		}
+
+		l, exists := layouts[contract]
+		if !exists {
+			t.Fatalf("report should have contract %s", contract)
+		}
+
+		return l
+	}
+
+	// clone copies the layout so a test case can change it.
+	clone := func(l ethereum.StorageLayout) ethereum.StorageLayout {
+		c := ethereum.StorageLayout{
+			Storage: append([]ethereum.StorageVariable(nil), l.Storage...),
+			Types:   make(map[string]ethereum.StorageType),
+		}
+		for id, typ := range l.Types {
+			c.Types[id] = typ
+		}
+
+		return c
+	}
+
+	proxy := layout(t, "bank.layout", "bank")
+	impl := layout(t, "bankapi.layout", "BankAPI")
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("parse", func(t *testing.T) {
+		if len(proxy.Storage) != 4 || proxy.Storage[3].Label != "accountBalances" || proxy.Storage[3].Slot != "3" {
+			t.Fatalf("wrong layout: %+v", proxy.Storage)
+		}
+
+		// The text output of solc names the contract in a header.
+		text := "\n======= src/bank/bank.sol:bank =======\nContract Storage Layout:\n" +
+			`{"storage":[{"astId":4,"contract":"src/bank/bank.sol:bank","label":"API","offset":0,"slot":"0","type":"t_address"}],` +
+			`"types":{"t_address":{"encoding":"inplace","label":"address","numberOfBytes":"20"}}}` + "\n"
+
+		layouts, err := ethereum.ParseStorageLayouts([]byte(text))
+		if err != nil {
+			t.Fatalf("unable to parse report: %s", err)
+		}
+
+		if got := layouts["bank"]; len(got.Storage) != 1 || got.Types["t_address"].Label != "address" {
+			t.Fatalf("wrong layout: %+v", got)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("compatible", func(t *testing.T) {
+		if err := ethereum.CheckStorageLayout(proxy, impl); err != nil {
+			t.Fatalf("should accept an identical layout: %s", err)
+		}
+
+		appended := clone(impl)
+		appended.Storage = append(appended.Storage, ethereum.StorageVariable{Label: "DepositCap", Slot: "4", Type: "t_uint256"})
+
+		if err := ethereum.CheckStorageLayout(proxy, appended); err != nil {
+			t.Fatalf("should accept variables added at the end: %s", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("incompatible", func(t *testing.T) {
+		swapped := clone(impl)
+		swapped.Storage[0], swapped.Storage[2] = swapped.Storage[2], swapped.Storage[0]
+
+		inserted := clone(impl)
+		inserted.Storage = append([]ethereum.StorageVariable{{Label: "Paused", Slot: "0", Type: "t_bool"}}, inserted.Storage...)
+
+		retyped := clone(impl)
+		retyped.Storage[3].Type = "t_mapping(t_address,t_uint128)"
+		retyped.Types["t_mapping(t_address,t_uint128)"] = ethereum.StorageType{Encoding: "mapping", Label: "mapping(address => uint128)", NumberOfBytes: "32", Key: "t_address", Value: "t_uint128"}
+		retyped.Types["t_uint128"] = ethereum.StorageType{Encoding: "inplace", Label: "uint128", NumberOfBytes: "16"}
+
+		moved := clone(impl)
+		moved.Storage[2].Offset = 20
+
+		truncated := clone(impl)
+		truncated.Storage = truncated.Storage[:3]
+
+		tests := []struct {
+			name   string
+			layout ethereum.StorageLayout
+			exp    string
+		}{
+			{"swapped", swapped, "API (slot 0): implementation has Owner"},
+			{"inserted", inserted, "implementation has Paused"},
+			{"retyped", retyped, "uint128"},
+			{"moved", moved, "offset 20"},
+			{"truncated", truncated, "accountBalances (slot 3): missing"},
+		}
+
+		for _, tt := range tests {
+			err := ethereum.CheckStorageLayout(proxy, tt.layout)
+			if !errors.Is(err, ethereum.ErrLayoutIncompatible) {
+				t.Fatalf("%s: should be incompatible, got %v", tt.name, err)
+			}
+
+			if !strings.Contains(err.Error(), tt.exp) {
+				t.Fatalf("%s: error should contain %q, got %s", tt.name, tt.exp, err)
+			}
+		}
+	})
+}
+
+func TestCompile(t *testing.T) {
+	solc := strings.Fields(os.Getenv("SOLC"))
+	if len(solc) == 0 {
+		solc = []string{"solc"}
+	}
+
+	if _, err := exec.LookPath(solc[0]); err != nil {
+		t.Skipf("solc is not available, set SOLC to the command: %s", err)
+	}
+
+	compiled, err := ethereum.Compile(context.Background(), solc, "testdata/events/events.sol", "Events")
+	if err != nil {
+		t.Fatalf("unable to compile: %s", err)
+	}
+
+	if !strings.Contains(compiled.ABI, "ValueSet") || !strings.HasPrefix(compiled.Bin, "0x6080") {
+		t.Fatalf("wrong compile output, abi %.40s bin %.40s", compiled.ABI, compiled.Bin)
+	}
+
+	if len(compiled.Layout.Storage) != 1 || compiled.Layout.Storage[0].Label != "Values" {
+		t.Fatalf("wrong storage layout: %+v", compiled.Layout)
+	}
+
+	if _, err := ethereum.Compile(context.Background(), solc, "testdata/events/events.sol", "Missing"); err == nil {
+		t.Fatal("should fail for a contract that isn't in the source")
+	}
+}
@@ -0,0 +1,265 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Set of error messages returned by a node when the nonce of a transaction
+// has already been used, or is ahead of the account's pending nonce. Errors
+// lose their identity over RPC, so the messages are matched instead.
+const (
+	errMsgNonceTooLow  = "nonce too low"
+	errMsgAlreadyKnown = "already known"
+	errMsgNonceTooHigh = "nonce too high"
+)
+
+// NonceManager hands out nonces for a single account so multiple goroutines
+// can safely send transactions through the same Client. Nonces that were
+// handed out but never made it to the node are released and handed out
+// again before any new nonce, so no gaps are left behind.
+type NonceManager struct {
+	backend Backend
+	address common.Address
+
+	mu          sync.Mutex
+	synced      bool
+	reverts     uint64
+	next        uint64
+	outstanding map[uint64]struct{}
+	released    []uint64
+}
+
+// reverter is implemented by backends whose chain can be reverted, which
+// takes the pending nonce of an account backwards.
+type reverter interface {
+	revertCount() uint64
+}
+
+// NewNonceManager constructs a nonce manager for the specified account. The
+// starting nonce is retrieved from the node on first use.
+func NewNonceManager(backend Backend, address common.Address) *NonceManager {
+	nm := NonceManager{
+		backend:     backend,
+		address:     address,
+		outstanding: make(map[uint64]struct{}),
+	}
+
+	if r, ok := backend.(reverter); ok {
+		nm.reverts = r.revertCount()
+	}
+
+	return &nm
+}
+
+// Next returns the next nonce to use for a transaction. The nonce must either
+// be sent or released.
+func (nm *NonceManager) Next(ctx context.Context) (uint64, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	// Every nonce handed out before the chain was reverted is meaningless
+	// after it, so the manager starts over from the node's pending nonce.
+	if r, ok := nm.backend.(reverter); ok {
+		if reverts := r.revertCount(); reverts != nm.reverts {
+			nm.reverts = reverts
+			nm.synced = false
+			nm.outstanding = make(map[uint64]struct{})
+			nm.released = nil
+		}
+	}
+
+	if !nm.synced {
+		if err := nm.resync(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	var nonce uint64
+	switch {
+	case len(nm.released) > 0:
+		nonce = nm.released[0]
+		nm.released = nm.released[1:]
+	default:
+		nonce = nm.next
+		nm.next++
+	}
+
+	nm.outstanding[nonce] = struct{}{}
+
+	return nonce, nil
+}
+
+// Sent marks the nonce as used by a transaction the node accepted.
+func (nm *NonceManager) Sent(nonce uint64) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	delete(nm.outstanding, nonce)
+}
+
+// Release returns a nonce that was handed out but not used so it can be
+// handed out again. Releasing a nonce that isn't outstanding is a no-op.
+func (nm *NonceManager) Release(nonce uint64) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if _, exists := nm.outstanding[nonce]; !exists {
+		return
+	}
+	delete(nm.outstanding, nonce)
+
+	// If this was the last nonce handed out, roll the counter back along
+	// with any released nonces directly below it.
+	if nonce+1 == nm.next {
+		nm.next--
+		for len(nm.released) > 0 && nm.released[len(nm.released)-1]+1 == nm.next {
+			nm.next--
+			nm.released = nm.released[:len(nm.released)-1]
+		}
+		return
+	}
+
+	i := sort.Search(len(nm.released), func(i int) bool { return nm.released[i] >= nonce })
+	nm.released = append(nm.released, 0)
+	copy(nm.released[i+1:], nm.released[i:])
+	nm.released[i] = nonce
+}
+
+// Resync retrieves the pending nonce from the node. Released nonces the node
+// has already seen are discarded, and the counter is moved forward if
+// transactions were sent for this account outside of the manager.
+func (nm *NonceManager) Resync(ctx context.Context) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	return nm.resync(ctx)
+}
+
+// resync performs the work of Resync and expects the lock to be held.
+func (nm *NonceManager) resync(ctx context.Context) error {
+	pending, err := nm.backend.PendingNonceAt(ctx, nm.address)
+	if err != nil {
+		return fmt.Errorf("retrieving pending nonce: %w", err)
+	}
+
+	released := nm.released[:0]
+	for _, nonce := range nm.released {
+		if nonce >= pending {
+			released = append(released, nonce)
+		}
+	}
+	nm.released = released
+
+	switch {
+	case len(nm.outstanding) == 0 && len(nm.released) == 0:
+		nm.next = pending
+	case pending > nm.next:
+		nm.next = pending
+	}
+
+	nm.synced = true
+
+	return nil
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// managedBackend intercepts transactions sent for the nonce manager's account
+// so nonces are marked as sent, released, or resynced based on the result.
+type managedBackend struct {
+	Backend
+	nonces *NonceManager
+	signer types.Signer
+}
+
+// SendTransaction sends the transaction to the node and reports the outcome
+// to the nonce manager.
+func (mb *managedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	sender, err := types.Sender(mb.signer, tx)
+	if err != nil || sender != mb.nonces.address {
+		return mb.Backend.SendTransaction(ctx, tx)
+	}
+
+	err = mb.Backend.SendTransaction(ctx, tx)
+	switch {
+	case err == nil:
+		mb.nonces.Sent(tx.Nonce())
+
+	case isNonceUsed(err):
+		mb.nonces.Sent(tx.Nonce())
+		if rErr := mb.nonces.Resync(ctx); rErr != nil {
+			return fmt.Errorf("%w: %s", err, rErr)
+		}
+
+	case isNonceGap(err):
+		mb.nonces.Release(tx.Nonce())
+		if rErr := mb.nonces.Resync(ctx); rErr != nil {
+			return fmt.Errorf("%w: %s", err, rErr)
+		}
+
+	default:
+		mb.nonces.Release(tx.Nonce())
+	}
+
+	return err
+}
+
+// isNonceUsed reports whether the error from sending a transaction indicates
+// the nonce has already been used.
+func isNonceUsed(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, errMsgNonceTooLow) || strings.Contains(msg, errMsgAlreadyKnown)
+}
+
+// isNonceGap reports whether the error from sending a transaction indicates
+// the nonce is ahead of the account's pending nonce.
+func isNonceGap(err error) bool {
+	return strings.Contains(err.Error(), errMsgNonceTooHigh)
+}
+
+// withNonce returns a copy of the unsigned transaction using the nonce.
+func withNonce(tx *types.Transaction, nonce uint64) *types.Transaction {
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasPrice:   tx.GasPrice(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+
+	default:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: tx.GasPrice(),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		})
+	}
+}
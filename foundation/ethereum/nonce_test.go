@@ -0,0 +1,344 @@
+package ethereum_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// transfer signs a value transfer using the transaction opts and sends it
+// through the client's backend. The signer assigns the nonce unless the
+// transaction opts set one.
+func transfer(ctx context.Context, client *ethereum.Client, txOpts *bind.TransactOpts, to common.Address) (*types.Transaction, error) {
+	var nonce uint64
+	if txOpts.Nonce != nil {
+		nonce = txOpts.Nonce.Uint64()
+	}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: txOpts.GasPrice,
+		Gas:      21_000,
+		To:       &to,
+		Value:    txOpts.Value,
+	})
+
+	tx, err := txOpts.Signer(client.Address(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Backend.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+
+	// Auto commit is disabled so every transaction in a subtest is mined
+	// into the same block once the subtest commits.
+	backend, err := ethereum.CreateSimulatedBackend(2, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	receiver, err := ethereum.NewClient(backend, backend.PrivateKeys[1])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	to := receiver.Address()
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("concurrent senders", func(t *testing.T) {
+		const goroutines = 50
+
+		start, err := client.PendingNonceAt(ctx, client.Address())
+		if err != nil {
+			t.Fatalf("unable to retrieve starting nonce: %s", err)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+
+		txs := make([]*types.Transaction, goroutines)
+		errs := make([]error, goroutines)
+
+		for i := 0; i < goroutines; i++ {
+			go func(i int) {
+				defer wg.Done()
+
+				txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+				if err != nil {
+					errs[i] = err
+					return
+				}
+
+				txs[i], errs[i] = transfer(ctx, client, txOpts, to)
+			}(i)
+		}
+
+		wg.Wait()
+		backend.Commit()
+
+		seen := make(map[uint64]bool)
+		for i := range txs {
+			if errs[i] != nil {
+				t.Fatalf("goroutine %d failed to send: %s", i, errs[i])
+			}
+
+			if seen[txs[i].Nonce()] {
+				t.Fatalf("nonce %d was handed out twice", txs[i].Nonce())
+			}
+			seen[txs[i].Nonce()] = true
+
+			receipt, err := client.WaitMined(ctx, txs[i])
+			if err != nil {
+				t.Fatalf("waiting for transaction %d: %s", i, err)
+			}
+
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				t.Fatalf("transaction %d failed", i)
+			}
+		}
+
+		end, err := client.PendingNonceAt(ctx, client.Address())
+		if err != nil {
+			t.Fatalf("unable to retrieve ending nonce: %s", err)
+		}
+
+		if end-start != goroutines {
+			t.Fatalf("wrong number of nonces used, got %d  exp %d", end-start, goroutines)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("unsent opts hold no nonce", func(t *testing.T) {
+		pending, err := client.PendingNonceAt(ctx, client.Address())
+		if err != nil {
+			t.Fatalf("unable to retrieve pending nonce: %s", err)
+		}
+
+		// Opts that are never signed, like a call whose gas estimate
+		// reverts, must not leave a gap.
+		if _, err := client.NewTransactOpts(ctx, 0, big.NewInt(0), big.NewFloat(1)); err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+		backend.Commit()
+
+		if tx.Nonce() != pending {
+			t.Fatalf("wrong nonce, got %d  exp %d", tx.Nonce(), pending)
+		}
+
+		if _, err := client.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("release gap", func(t *testing.T) {
+		unused, err := client.Nonces().Next(ctx)
+		if err != nil {
+			t.Fatalf("unable to take nonce: %s", err)
+		}
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		// This transaction is queued behind the unused nonce.
+		queued, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		client.Nonces().Release(unused)
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		if tx.Nonce() != unused {
+			t.Fatalf("released nonce should be reused, got %d  exp %d", tx.Nonce(), unused)
+		}
+
+		backend.Commit()
+
+		for _, tx := range []*types.Transaction{tx, queued} {
+			if _, err := client.WaitMined(ctx, tx); err != nil {
+				t.Fatalf("waiting for transaction: %s", err)
+			}
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("failed send releases nonce", func(t *testing.T) {
+		pending, err := client.PendingNonceAt(ctx, client.Address())
+		if err != nil {
+			t.Fatalf("unable to retrieve pending nonce: %s", err)
+		}
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1e12))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		if _, err := transfer(ctx, client, txOpts, to); err == nil {
+			t.Fatal("transfer larger than the balance should fail")
+		}
+
+		next, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, next, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+		backend.Commit()
+
+		if tx.Nonce() != pending {
+			t.Fatalf("failed nonce should be reused, got %d  exp %d", tx.Nonce(), pending)
+		}
+
+		if _, err := client.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("resync on nonce too low", func(t *testing.T) {
+		stale, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		// A second client for the same account doesn't share the nonce
+		// manager, so it uses the same nonce behind the first one's back.
+		other, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+		if err != nil {
+			t.Fatalf("unable to create client: %s", err)
+		}
+
+		otherOpts, err := other.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		if _, err := transfer(ctx, other, otherOpts, to); err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		// Mine the other transaction so the stale nonce isn't treated as
+		// a replacement of an uncommitted transaction.
+		backend.Commit()
+
+		_, err = transfer(ctx, client, stale, to)
+		if err == nil || !strings.Contains(err.Error(), "nonce too low") {
+			t.Fatalf("stale nonce should be rejected, got %v", err)
+		}
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		pending, err := client.PendingNonceAt(ctx, client.Address())
+		if err != nil {
+			t.Fatalf("unable to retrieve pending nonce: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+		backend.Commit()
+
+		if tx.Nonce() != pending {
+			t.Fatalf("nonce should be resynced, got %d  exp %d", tx.Nonce(), pending)
+		}
+
+		if _, err := client.WaitMined(ctx, tx); err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+	})
+}
+
+func TestNonceEstimateRevert(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	backend, err := ethereum.CreateSimulatedBackend(1, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	_, fixture := deployFixture(t, client, "Revert")
+
+	// A zero gas limit has the call estimate its gas, which reverts before
+	// anything is signed or sent.
+	txOpts, err := client.NewTransactOpts(ctx, 0, big.NewInt(0), big.NewFloat(0))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts: %s", err)
+	}
+
+	if _, err := fixture.Transact(txOpts, "Withdraw", big.NewInt(1)); err == nil {
+		t.Fatal("gas estimate should revert")
+	}
+
+	// The next transaction must not be queued behind a gap.
+	txOpts, err = client.NewTransactOpts(ctx, 100_000, big.NewInt(0), big.NewFloat(0))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts: %s", err)
+	}
+
+	tx, err := fixture.Transact(txOpts, "SetOpen", true)
+	if err != nil {
+		t.Fatalf("unable to send transaction: %s", err)
+	}
+
+	if _, err := client.WaitMined(ctx, tx); err != nil {
+		t.Fatalf("waiting for transaction: %s", err)
+	}
+}
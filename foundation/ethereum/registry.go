@@ -0,0 +1,258 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Set of errors a lookup returns when a deployment can't be used.
+var (
+	ErrNotDeployed  = errors.New("contract is not deployed")
+	ErrNoCode       = errors.New("no code at the contract address")
+	ErrCodeMismatch = errors.New("code does not match")
+)
+
+// Deployment represents a contract deployed to a chain.
+type Deployment struct {
+	Name       string         `json:"name"`
+	ChainID    uint64         `json:"chainID"`
+	Address    common.Address `json:"address"`
+	TxHash     common.Hash    `json:"txHash"`
+	Block      uint64         `json:"block"`
+	BlockHash  common.Hash    `json:"blockHash"`
+	Deployer   common.Address `json:"deployer"`
+	CodeHash   common.Hash    `json:"codeHash"` // Keccak256 of the code at the address
+	BinHash    common.Hash    `json:"binHash"`  // Keccak256 of the compiled .bin
+	ABIHash    common.Hash    `json:"abiHash"`  // Keccak256 of the ABI, identifies its version
+	DeployedAt time.Time      `json:"deployedAt"`
+}
+
+// Registry records the contracts deployed to each chain in a JSON file,
+// keyed by chain id and contract name. Looking a contract up verifies it's
+// still deployed as recorded, so addresses left behind by a chain reset or a
+// redeploy from a different build aren't used.
+type Registry struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewRegistry constructs a registry stored in the file. The file is created
+// when the first deployment is saved.
+func NewRegistry(path string) *Registry {
+	return &Registry{path: path}
+}
+
+// Path returns the path of the registry file.
+func (r *Registry) Path() string {
+	return r.path
+}
+
+// Record saves the deployment of the named contract from its mined deploy
+// transaction. The code at the contract address must be the runtime code
+// of the compiled bytecode in the metadata.
+func (r *Registry) Record(ctx context.Context, backend Backend, name string, meta *bind.MetaData, tx *types.Transaction, receipt *types.Receipt) (Deployment, error) {
+	if receipt.ContractAddress == (common.Address{}) {
+		return Deployment{}, fmt.Errorf("transaction %s did not deploy a contract", tx.Hash())
+	}
+
+	deployer, err := types.Sender(types.LatestSignerForChainID(backend.ChainID()), tx)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("recovering deployer: %w", err)
+	}
+
+	code, err := verifyCode(ctx, backend, receipt.ContractAddress, meta.Bin)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	d := Deployment{
+		Name:       name,
+		ChainID:    backend.ChainID().Uint64(),
+		Address:    receipt.ContractAddress,
+		TxHash:     receipt.TxHash,
+		Block:      receipt.BlockNumber.Uint64(),
+		BlockHash:  receipt.BlockHash,
+		Deployer:   deployer,
+		CodeHash:   crypto.Keccak256Hash(code),
+		BinHash:    crypto.Keccak256Hash(common.FromHex(meta.Bin)),
+		ABIHash:    crypto.Keccak256Hash([]byte(meta.ABI)),
+		DeployedAt: time.Now().UTC(),
+	}
+
+	if err := r.Save(d); err != nil {
+		return Deployment{}, err
+	}
+
+	return d, nil
+}
+
+// Save writes the deployment to the registry, replacing any deployment of
+// the contract on the same chain.
+func (r *Registry) Save(d Deployment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chains, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	chainID := strconv.FormatUint(d.ChainID, 10)
+	if chains[chainID] == nil {
+		chains[chainID] = make(map[string]Deployment)
+	}
+	chains[chainID][d.Name] = d
+
+	data, err := json.MarshalIndent(chains, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding registry: %w", err)
+	}
+
+	// The file is replaced in one step so readers never see a partial write.
+	f, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing registry: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing registry: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing registry: %w", err)
+	}
+
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return fmt.Errorf("writing registry: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), r.path); err != nil {
+		return fmt.Errorf("writing registry: %w", err)
+	}
+
+	return nil
+}
+
+// Deployment returns the recorded deployment of the named contract on the
+// chain without verifying it. The error wraps ErrNotDeployed when there is
+// none.
+func (r *Registry) Deployment(chainID uint64, name string) (Deployment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chains, err := r.load()
+	if err != nil {
+		return Deployment{}, err
+	}
+
+	d, exists := chains[strconv.FormatUint(chainID, 10)][name]
+	if !exists {
+		return Deployment{}, fmt.Errorf("%s on chain %d: %w", name, chainID, ErrNotDeployed)
+	}
+
+	return d, nil
+}
+
+// Deployments returns the recorded deployments on the chain sorted by name.
+func (r *Registry) Deployments(chainID uint64) ([]Deployment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chains, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []Deployment
+	for _, d := range chains[strconv.FormatUint(chainID, 10)] {
+		deployments = append(deployments, d)
+	}
+
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Name < deployments[j].Name
+	})
+
+	return deployments, nil
+}
+
+// Lookup returns the deployment of the named contract on the chain of the
+// backend, verifying the contract address has code, the code is what was
+// deployed and, when metadata is given, the code is the runtime code of its
+// compiled bytecode.
+func (r *Registry) Lookup(ctx context.Context, backend Backend, name string, meta *bind.MetaData) (Deployment, error) {
+	d, err := r.Deployment(backend.ChainID().Uint64(), name)
+	if err != nil {
+		return Deployment{}, err
+	}
+
+	var bin string
+	if meta != nil {
+		bin = meta.Bin
+	}
+
+	code, err := verifyCode(ctx, backend, d.Address, bin)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if crypto.Keccak256Hash(code) != d.CodeHash {
+		return Deployment{}, fmt.Errorf("%s at %s: %w the recorded deployment", name, d.Address, ErrCodeMismatch)
+	}
+
+	return d, nil
+}
+
+// load reads the registry file, an empty registry when it doesn't exist.
+func (r *Registry) load() (map[string]map[string]Deployment, error) {
+	chains := make(map[string]map[string]Deployment)
+
+	data, err := os.ReadFile(r.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return chains, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading registry: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("decoding registry %s: %w", r.path, err)
+	}
+
+	return chains, nil
+}
+
+// verifyCode returns the code at the address. The code must exist and, when
+// the compiled bytecode is given, be the runtime code it deploys, which the
+// bytecode holds to copy into place.
+func verifyCode(ctx context.Context, backend Backend, address common.Address, bin string) ([]byte, error) {
+	code, err := backend.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving code at %s: %w", address, err)
+	}
+
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w %s, the chain may have been reset", ErrNoCode, address)
+	}
+
+	if bin != "" && !bytes.Contains(common.FromHex(bin), code) {
+		return nil, fmt.Errorf("%s: %w the compiled bytecode", address, ErrCodeMismatch)
+	}
+
+	return code, nil
+}
@@ -0,0 +1,175 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(1, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	registry := ethereum.NewRegistry(filepath.Join(t.TempDir(), "deployments.json"))
+
+	// metaData reads the compiled ABI and bytecode of the fixture.
+	metaData := func(t *testing.T, name string) *bind.MetaData {
+		t.Helper()
+
+		dir := "testdata/" + strings.ToLower(name) + "/"
+
+		abiData, err := os.ReadFile(dir + name + ".abi")
+		if err != nil {
+			t.Fatalf("unable to read abi: %s", err)
+		}
+
+		binData, err := os.ReadFile(dir + name + ".bin")
+		if err != nil {
+			t.Fatalf("unable to read bin: %s", err)
+		}
+
+		return &bind.MetaData{ABI: string(abiData), Bin: "0x" + strings.TrimSpace(string(binData))}
+	}
+
+	// deploy deploys the fixture and records it in the registry.
+	deploy := func(t *testing.T, name string, meta *bind.MetaData) ethereum.Deployment {
+		t.Helper()
+
+		parsed, err := meta.GetAbi()
+		if err != nil {
+			t.Fatalf("unable to parse abi: %s", err)
+		}
+
+		txOpts, err := client.NewTransactOpts(ctx, 3_000_000, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		_, tx, _, err := bind.DeployContract(txOpts, *parsed, common.FromHex(meta.Bin), client.Backend)
+		if err != nil {
+			t.Fatalf("unable to deploy %s: %s", name, err)
+		}
+
+		receipt, err := client.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("waiting for deploy: %s", err)
+		}
+
+		d, err := registry.Record(ctx, backend, name, meta, tx, receipt)
+		if err != nil {
+			t.Fatalf("unable to record %s: %s", name, err)
+		}
+
+		return d
+	}
+
+	events := metaData(t, "Events")
+	revert := metaData(t, "Revert")
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("record", func(t *testing.T) {
+		d := deploy(t, "events", events)
+
+		if d.ChainID != 1337 || d.Deployer != client.Address() || d.Block == 0 {
+			t.Fatalf("wrong deployment: %+v", d)
+		}
+
+		got, err := registry.Lookup(ctx, backend, "events", events)
+		if err != nil {
+			t.Fatalf("unable to look up deployment: %s", err)
+		}
+
+		if got.Address != d.Address || got.CodeHash != d.CodeHash || !got.DeployedAt.Equal(d.DeployedAt) {
+			t.Fatalf("wrong deployment, got %+v  exp %+v", got, d)
+		}
+
+		data, err := os.ReadFile(registry.Path())
+		if err != nil {
+			t.Fatalf("unable to read registry: %s", err)
+		}
+
+		var chains map[string]map[string]ethereum.Deployment
+		if err := json.Unmarshal(data, &chains); err != nil {
+			t.Fatalf("unable to decode registry: %s", err)
+		}
+
+		if chains["1337"]["events"].TxHash != d.TxHash {
+			t.Fatalf("registry should be keyed by chain id and name:\n%s", data)
+		}
+
+		deploy(t, "revert", revert)
+
+		deployments, err := registry.Deployments(1337)
+		if err != nil {
+			t.Fatalf("unable to list deployments: %s", err)
+		}
+
+		if len(deployments) != 2 || deployments[0].Name != "events" || deployments[1].Name != "revert" {
+			t.Fatalf("wrong deployments: %+v", deployments)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("verify", func(t *testing.T) {
+		d := deploy(t, "events", events)
+
+		if _, err := registry.Lookup(ctx, backend, "missing", nil); !errors.Is(err, ethereum.ErrNotDeployed) {
+			t.Fatalf("should fail for a contract that isn't deployed, got %v", err)
+		}
+
+		if _, err := registry.Lookup(ctx, backend, "events", revert); !errors.Is(err, ethereum.ErrCodeMismatch) {
+			t.Fatalf("should fail for a different build, got %v", err)
+		}
+
+		if _, err := registry.Lookup(ctx, backend, "events", nil); err != nil {
+			t.Fatalf("should skip the bytecode check without metadata: %s", err)
+		}
+
+		stale := d
+		stale.CodeHash = common.Hash{1}
+		if err := registry.Save(stale); err != nil {
+			t.Fatalf("unable to save deployment: %s", err)
+		}
+
+		if _, err := registry.Lookup(ctx, backend, "events", events); !errors.Is(err, ethereum.ErrCodeMismatch) {
+			t.Fatalf("should fail when the code isn't what was deployed, got %v", err)
+		}
+
+		if err := registry.Save(d); err != nil {
+			t.Fatalf("unable to save deployment: %s", err)
+		}
+
+		// A new chain with the same id has nothing at the address.
+		reset, err := ethereum.CreateSimulatedBackend(1, true, big.NewInt(100))
+		if err != nil {
+			t.Fatalf("unable to create simulated backend: %s", err)
+		}
+		defer reset.Close()
+
+		if _, err := registry.Lookup(ctx, reset, "events", events); !errors.Is(err, ethereum.ErrNoCode) {
+			t.Fatalf("should fail after a chain reset, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,299 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MinPriceBump is the minimum percentage a node requires the fees of a
+// replacement transaction to be increased by.
+const MinPriceBump = 10
+
+// cancelGasLimit is the gas needed for the plain transfer used to cancel
+// a transaction.
+const cancelGasLimit = 21_000
+
+// ErrReplaceUnderpriced is returned when the fees of a replacement transaction
+// aren't bumped enough for a node to accept it.
+var ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
+
+// SpeedUp resubmits a pending transaction with the same nonce and its fees
+// increased by the specified percentage. A percentage less than MinPriceBump
+// is raised to MinPriceBump, and the fees are never set below what the
+// connected node currently suggests. WaitMined for the original transaction
+// returns the receipt of whichever transaction is mined.
+func (c *Client) SpeedUp(ctx context.Context, tx *types.Transaction, bumpPercent uint64) (*types.Transaction, error) {
+	return c.replace(ctx, tx, bumpPercent, tx.To(), tx.Value(), tx.Data(), tx.Gas(), tx.AccessList())
+}
+
+// Cancel replaces a pending transaction with a zero value transfer to the
+// client's own account using the same nonce and fees increased by
+// MinPriceBump. WaitMined for the original transaction returns the receipt
+// of whichever transaction is mined.
+func (c *Client) Cancel(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	to := c.address
+
+	// The transfer has no access list, since any gas it adds would push
+	// the transfer over cancelGasLimit.
+	return c.replace(ctx, tx, MinPriceBump, &to, big.NewInt(0), nil, cancelGasLimit, nil)
+}
+
+// replace signs and sends a transaction at the nonce of the specified
+// transaction using bumped fees.
+func (c *Client) replace(ctx context.Context, tx *types.Transaction, bumpPercent uint64, to *common.Address, value *big.Int, data []byte, gas uint64, accessList types.AccessList) (*types.Transaction, error) {
+	if bumpPercent < MinPriceBump {
+		bumpPercent = MinPriceBump
+	}
+
+	var txData types.TxData
+
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		gasTipCap, gasFeeCap, err := c.bumpDynamicFee(ctx, tx, bumpPercent)
+		if err != nil {
+			return nil, err
+		}
+
+		txData = &types.DynamicFeeTx{
+			ChainID:    c.Backend.ChainID(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: accessList,
+		}
+
+	case types.AccessListTxType:
+		gasPrice, err := c.bumpGasPrice(ctx, tx, bumpPercent)
+		if err != nil {
+			return nil, err
+		}
+
+		txData = &types.AccessListTx{
+			ChainID:    c.Backend.ChainID(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   gasPrice,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: accessList,
+		}
+
+	default:
+		gasPrice, err := c.bumpGasPrice(ctx, tx, bumpPercent)
+		if err != nil {
+			return nil, err
+		}
+
+		txData = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	}
+
+	replacement, err := c.signer.SignTx(ctx, types.NewTx(txData), c.Backend.ChainID())
+	if err != nil {
+		return nil, fmt.Errorf("signing replacement: %w", err)
+	}
+
+	if err := c.SendTransaction(ctx, replacement); err != nil {
+		return nil, fmt.Errorf("sending replacement: %w", err)
+	}
+
+	c.replacements.add(tx, replacement)
+
+	return replacement, nil
+}
+
+// bumpGasPrice calculates the gas price of a replacement legacy transaction.
+func (c *Client) bumpGasPrice(ctx context.Context, tx *types.Transaction, bumpPercent uint64) (*big.Int, error) {
+	suggested, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving suggested gas price: %w", err)
+	}
+
+	return maxBig(bumpFee(tx.GasPrice(), bumpPercent), suggested), nil
+}
+
+// bumpDynamicFee calculates the tip and fee caps of a replacement dynamic-fee
+// transaction.
+func (c *Client) bumpDynamicFee(ctx context.Context, tx *types.Transaction, bumpPercent uint64) (gasTipCap *big.Int, gasFeeCap *big.Int, err error) {
+	suggestedTip, err := c.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving suggested gas tip cap: %w", err)
+	}
+
+	baseFee, err := c.BaseFee(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasTipCap = maxBig(bumpFee(tx.GasTipCap(), bumpPercent), suggestedTip)
+
+	suggestedFeeCap := big.NewInt(0)
+	if baseFee != nil {
+		suggestedFeeCap = mulFloat(baseFee, defaultBaseFeeMultiplier)
+	}
+	suggestedFeeCap.Add(suggestedFeeCap, gasTipCap)
+
+	gasFeeCap = maxBig(bumpFee(tx.GasFeeCap(), bumpPercent), suggestedFeeCap)
+
+	return gasTipCap, gasFeeCap, nil
+}
+
+// bumpFee increases the fee by the percentage, guaranteeing the result is
+// strictly greater than the original fee.
+func bumpFee(fee *big.Int, bumpPercent uint64) *big.Int {
+	bumped := big.NewInt(0).Mul(fee, new(big.Int).SetUint64(100+bumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+
+	return bumped
+}
+
+// checkPriceBump validates the replacement transaction bumps the fees of the
+// original transaction by at least MinPriceBump, mirroring a node's
+// transaction pool rules.
+func checkPriceBump(original *types.Transaction, replacement *types.Transaction) error {
+	if original.GasFeeCapCmp(replacement) >= 0 || original.GasTipCapCmp(replacement) >= 0 {
+		return ErrReplaceUnderpriced
+	}
+
+	thresholdFeeCap := big.NewInt(0).Mul(original.GasFeeCap(), big.NewInt(100+MinPriceBump))
+	thresholdFeeCap.Div(thresholdFeeCap, big.NewInt(100))
+
+	thresholdTip := big.NewInt(0).Mul(original.GasTipCap(), big.NewInt(100+MinPriceBump))
+	thresholdTip.Div(thresholdTip, big.NewInt(100))
+
+	if replacement.GasFeeCapIntCmp(thresholdFeeCap) < 0 || replacement.GasTipCapIntCmp(thresholdTip) < 0 {
+		return ErrReplaceUnderpriced
+	}
+
+	return nil
+}
+
+// maxBig returns the larger of the two values.
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return new(big.Int).Set(b)
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// replacements tracks every transaction sent by the client at a nonce, so
+// waiting on any of them can track whichever one gets mined.
+type replacements struct {
+	mu   sync.Mutex
+	sets map[uint64]*replacementSet
+}
+
+// replacementSet represents the transactions sent at a nonce and the number
+// of waits tracking them. The set is kept until it's done and the last wait
+// returns, so every wait on any transaction in the set sees the mined one.
+type replacementSet struct {
+	txs     []*types.Transaction
+	waiters int
+	done    bool
+}
+
+// add records the replacement as part of the original's replacement set.
+func (r *replacements) add(original *types.Transaction, replacement *types.Transaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set := r.set(original.Nonce())
+	if len(set.txs) == 0 {
+		set.txs = append(set.txs, original)
+	}
+
+	set.txs = append(set.txs, replacement)
+}
+
+// candidates returns the transactions in the replacement set of the
+// specified transaction, which includes the transaction itself.
+func (r *replacements) candidates(tx *types.Transaction) []*types.Transaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if set, exists := r.sets[tx.Nonce()]; exists {
+		for _, candidate := range set.txs {
+			if candidate.Hash() == tx.Hash() {
+				return append([]*types.Transaction(nil), set.txs...)
+			}
+		}
+	}
+
+	return []*types.Transaction{tx}
+}
+
+// wait registers a wait on the replacement set for the nonce. Every wait must
+// be followed by a call to release once the wait returns.
+func (r *replacements) wait(nonce uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.set(nonce).waiters++
+}
+
+// finish marks the replacement set for the nonce as done once a transaction
+// in it was mined or they were all dropped.
+func (r *replacements) finish(nonce uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if set, exists := r.sets[nonce]; exists {
+		set.done = true
+	}
+}
+
+// release ends a wait on the replacement set for the nonce, forgetting the
+// set when no other wait is using it and it's done or holds no replacements.
+func (r *replacements) release(nonce uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, exists := r.sets[nonce]
+	if !exists {
+		return
+	}
+
+	set.waiters--
+	if set.waiters <= 0 && (set.done || len(set.txs) == 0) {
+		delete(r.sets, nonce)
+	}
+}
+
+// set returns the replacement set for the nonce, creating it if needed. The
+// lock must be held.
+func (r *replacements) set(nonce uint64) *replacementSet {
+	if r.sets == nil {
+		r.sets = make(map[uint64]*replacementSet)
+	}
+
+	set, exists := r.sets[nonce]
+	if !exists {
+		set = &replacementSet{}
+		r.sets[nonce] = set
+	}
+
+	return set
+}
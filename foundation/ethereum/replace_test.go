@@ -0,0 +1,337 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// receiptPolled signals the first time a receipt is looked up, so a test
+// knows a wait is under way.
+type receiptPolled struct {
+	ethereum.Backend
+	once   *sync.Once
+	polled chan struct{}
+}
+
+// TransactionReceipt signals the lookup and retrieves the receipt.
+func (rp receiptPolled) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	rp.once.Do(func() { close(rp.polled) })
+	return rp.Backend.TransactionReceipt(ctx, txHash)
+}
+
+func TestReplacement(t *testing.T) {
+	ctx := context.Background()
+
+	// Auto commit is disabled so transactions stay pending until the
+	// test decides which transaction in the replacement set is mined.
+	backend, err := ethereum.CreateSimulatedBackend(2, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	receiver, err := ethereum.NewClient(backend, backend.PrivateKeys[1])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	to := receiver.Address()
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("speed up legacy", func(t *testing.T) {
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		fast, err := client.SpeedUp(ctx, tx, 25)
+		if err != nil {
+			t.Fatalf("unable to speed up transaction: %s", err)
+		}
+
+		if fast.Nonce() != tx.Nonce() {
+			t.Fatalf("replacement should use the same nonce, got %d  exp %d", fast.Nonce(), tx.Nonce())
+		}
+
+		exp := big.NewInt(0).Div(big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(125)), big.NewInt(100))
+		if fast.GasPrice().Cmp(exp) < 0 {
+			t.Fatalf("gas price should be bumped, got %v  exp >= %v", fast.GasPrice(), exp)
+		}
+
+		backend.Commit()
+
+		receipt, err := client.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if receipt.TxHash != fast.Hash() {
+			t.Fatalf("replacement should be mined, got %s  exp %s", receipt.TxHash, fast.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("speed up dynamic fee", func(t *testing.T) {
+		txOpts, err := client.NewDynamicFeeTransactOpts(ctx, 21_000, ethereum.FeeMultipliers{}, big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   backend.ChainID(),
+			GasTipCap: txOpts.GasTipCap,
+			GasFeeCap: txOpts.GasFeeCap,
+			Gas:       txOpts.GasLimit,
+			To:        &to,
+			Value:     txOpts.Value,
+		})
+
+		tx, err = txOpts.Signer(client.Address(), tx)
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %s", err)
+		}
+
+		if err := client.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		fast, err := client.SpeedUp(ctx, tx, 0)
+		if err != nil {
+			t.Fatalf("unable to speed up transaction: %s", err)
+		}
+
+		if fast.Type() != types.DynamicFeeTxType {
+			t.Fatalf("replacement should keep the transaction type, got %d", fast.Type())
+		}
+
+		if fast.GasTipCapCmp(tx) <= 0 || fast.GasFeeCapCmp(tx) <= 0 {
+			t.Fatalf("fees should be bumped, tip %v -> %v, fee cap %v -> %v", tx.GasTipCap(), fast.GasTipCap(), tx.GasFeeCap(), fast.GasFeeCap())
+		}
+
+		backend.Commit()
+
+		receipt, err := client.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if receipt.TxHash != fast.Hash() {
+			t.Fatalf("replacement should be mined, got %s  exp %s", receipt.TxHash, fast.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("cancel", func(t *testing.T) {
+		startingBalance, err := receiver.Balance(ctx)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		cancel, err := client.Cancel(ctx, tx)
+		if err != nil {
+			t.Fatalf("unable to cancel transaction: %s", err)
+		}
+
+		if *cancel.To() != client.Address() || cancel.Value().Sign() != 0 {
+			t.Fatalf("cancel should be a zero value self transfer, got to %s value %v", cancel.To(), cancel.Value())
+		}
+
+		backend.Commit()
+
+		receipt, err := client.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if receipt.TxHash != cancel.Hash() {
+			t.Fatalf("cancel should be mined, got %s  exp %s", receipt.TxHash, cancel.Hash())
+		}
+
+		endingBalance, err := receiver.Balance(ctx)
+		if err != nil {
+			t.Fatalf("unable to retrieve balance: %s", err)
+		}
+
+		if startingBalance.Cmp(endingBalance) != 0 {
+			t.Fatalf("cancelled transfer should not be paid, got %v  exp %v", endingBalance, startingBalance)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("cancel with access list", func(t *testing.T) {
+		txOpts, err := client.NewDynamicFeeTransactOpts(ctx, 30_000, ethereum.FeeMultipliers{}, big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		// The access list raises the transfer's intrinsic gas above what
+		// the cancel transfer is given.
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   backend.ChainID(),
+			GasTipCap: txOpts.GasTipCap,
+			GasFeeCap: txOpts.GasFeeCap,
+			Gas:       txOpts.GasLimit,
+			To:        &to,
+			Value:     txOpts.Value,
+			AccessList: types.AccessList{
+				{Address: to, StorageKeys: []common.Hash{{}}},
+			},
+		})
+
+		tx, err = txOpts.Signer(client.Address(), tx)
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %s", err)
+		}
+
+		if err := client.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		cancel, err := client.Cancel(ctx, tx)
+		if err != nil {
+			t.Fatalf("unable to cancel transaction: %s", err)
+		}
+
+		if len(cancel.AccessList()) != 0 {
+			t.Fatalf("cancel should not have an access list, got %v", cancel.AccessList())
+		}
+
+		backend.Commit()
+
+		receipt, err := client.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if receipt.TxHash != cancel.Hash() {
+			t.Fatalf("cancel should be mined, got %s  exp %s", receipt.TxHash, cancel.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("underpriced replacement", func(t *testing.T) {
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		// Setting the nonce signs the replacement at the original's nonce.
+		txOpts.Nonce = big.NewInt(0).SetUint64(tx.Nonce())
+		txOpts.GasPrice = big.NewInt(0).Add(tx.GasPrice(), big.NewInt(1))
+		if _, err := transfer(ctx, client, txOpts, to); !errors.Is(err, ethereum.ErrReplaceUnderpriced) {
+			t.Fatalf("replacement without a 10%% bump should be rejected, got %v", err)
+		}
+
+		backend.Commit()
+
+		receipt, err := client.WaitMined(ctx, tx)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if receipt.TxHash != tx.Hash() {
+			t.Fatalf("original should be mined, got %s  exp %s", receipt.TxHash, tx.Hash())
+		}
+	})
+}
+
+func TestReplacementWaiters(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	backend, err := ethereum.CreateSimulatedBackend(2, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	polled := receiptPolled{Backend: backend, once: new(sync.Once), polled: make(chan struct{})}
+
+	client, err := ethereum.NewClient(polled, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	to := crypto.PubkeyToAddress(backend.PrivateKeys[1].PublicKey)
+
+	txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+	if err != nil {
+		t.Fatalf("unable to create transaction opts: %s", err)
+	}
+
+	tx, err := transfer(ctx, client, txOpts, to)
+	if err != nil {
+		t.Fatalf("unable to send transaction: %s", err)
+	}
+
+	fast, err := client.SpeedUp(ctx, tx, 25)
+	if err != nil {
+		t.Fatalf("unable to speed up transaction: %s", err)
+	}
+
+	// The first wait on the original is still waiting for its second
+	// confirmation when the second wait finds the replacement mined.
+	opts := ethereum.WaitOpts{Confirmations: 2, PollInterval: 10 * time.Millisecond}
+	first := waitAsync(ctx, client, tx, opts)
+	<-polled.polled
+
+	backend.Commit()
+
+	receipt, err := client.WaitMined(ctx, tx)
+	if err != nil {
+		t.Fatalf("waiting for transaction: %s", err)
+	}
+
+	if receipt.TxHash != fast.Hash() {
+		t.Fatalf("replacement should be mined, got %s  exp %s", receipt.TxHash, fast.Hash())
+	}
+
+	backend.Commit()
+
+	resp := <-first
+	if resp.err != nil {
+		t.Fatalf("waiting for transaction: %s", resp.err)
+	}
+
+	if resp.result.Status != ethereum.StatusReplaced || resp.result.Receipt == nil || resp.result.Receipt.TxHash != fast.Hash() {
+		t.Fatalf("first wait should see the replacement mined, got %s", resp.result.Status)
+	}
+}
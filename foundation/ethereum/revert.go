@@ -0,0 +1,236 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Set of selectors for the errors the Solidity compiler encodes on its own.
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// panicReasons maps the codes of Solidity's Panic(uint256) error to what
+// caused them.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum conversion out of bounds",
+	0x22: "incorrectly encoded storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized function",
+}
+
+// RevertError represents the decoded data of a reverted transaction or call.
+// Only one of Reason, PanicCode or CustomError is set, depending on how the
+// contract reverted. None of them are set if the contract reverted without
+// data or with data that couldn't be decoded.
+type RevertError struct {
+	Data        []byte   // Raw revert data
+	Reason      string   // Message of a require or revert with a string
+	PanicCode   *big.Int // Code of a Solidity panic
+	CustomError string   // Name of a Solidity custom error
+	Args        []any    // Arguments of the custom error
+}
+
+// Error implements the error interface.
+func (re *RevertError) Error() string {
+	switch {
+	case re.PanicCode != nil:
+		return fmt.Sprintf("execution reverted: panic 0x%x (%s)", re.PanicCode, re.PanicReason())
+
+	case re.CustomError != "":
+		args := make([]string, len(re.Args))
+		for i, arg := range re.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", re.CustomError, strings.Join(args, ", "))
+
+	case re.Reason != "":
+		return "execution reverted: " + re.Reason
+	}
+
+	return "execution reverted"
+}
+
+// PanicReason returns the description of the panic code, or an empty string
+// if the contract didn't panic.
+func (re *RevertError) PanicReason() string {
+	if re.PanicCode == nil {
+		return ""
+	}
+
+	if re.PanicCode.IsUint64() {
+		if reason, exists := panicReasons[re.PanicCode.Uint64()]; exists {
+			return reason
+		}
+	}
+
+	return "unknown panic code"
+}
+
+// DecodeRevert decodes the revert data returned by a contract. Custom errors
+// are decoded using the errors defined in the specified ABIs.
+func DecodeRevert(data []byte, abis ...*abi.ABI) *RevertError {
+	revertErr := RevertError{
+		Data: data,
+	}
+
+	if len(data) < 4 {
+		return &revertErr
+	}
+
+	selector := data[:4]
+
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			revertErr.Reason = reason
+		}
+		return &revertErr
+
+	case bytes.Equal(selector, panicSelector):
+		if len(data) == 4+32 {
+			revertErr.PanicCode = new(big.Int).SetBytes(data[4:])
+		}
+		return &revertErr
+	}
+
+	for _, contractABI := range abis {
+		if contractABI == nil {
+			continue
+		}
+
+		for _, abiErr := range contractABI.Errors {
+			if !bytes.Equal(selector, abiErr.ID[:4]) {
+				continue
+			}
+
+			args, err := abiErr.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+
+			revertErr.CustomError = abiErr.Name
+			revertErr.Args = args
+			return &revertErr
+		}
+	}
+
+	return &revertErr
+}
+
+// RegisterErrorABI adds contract ABIs whose custom errors are decoded when a
+// transaction waited on by the client reverts.
+func (c *Client) RegisterErrorABI(abis ...*abi.ABI) {
+	c.errorABIs.add(abis...)
+}
+
+// extractError replays the failed transaction against the block it was mined
+// in and decodes why it failed. A *RevertError is returned if the contract
+// reverted.
+func (c *Client) extractError(ctx context.Context, tx *types.Transaction, blockNumber *big.Int) error {
+	from, err := types.Sender(types.LatestSignerForChainID(c.Backend.ChainID()), tx)
+	if err != nil {
+		return fmt.Errorf("retrieving sender: %w", err)
+	}
+
+	msg := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+
+	_, err = c.CallContract(ctx, msg, blockNumber)
+	if err == nil {
+		return fmt.Errorf("tx failed but succeeded when replayed at block %v", blockNumber)
+	}
+
+	if data, ok := revertData(err); ok {
+		return DecodeRevert(data, c.errorABIs.list()...)
+	}
+
+	return err
+}
+
+// CallRevert decodes the revert data carried by the error of a contract call,
+// such as a view called through a binding. False is returned when the error
+// isn't a revert with data.
+func CallRevert(err error, abis ...*abi.ABI) (*RevertError, bool) {
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return revertErr, true
+	}
+
+	data, ok := revertData(err)
+	if !ok {
+		return nil, false
+	}
+
+	return DecodeRevert(data, abis...), true
+}
+
+// revertData returns the revert data carried by an error returned from a
+// call, if there is any.
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+
+	data, err := hexutil.Decode(hexData)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// errorABIs holds the ABIs used to decode custom errors.
+type errorABIs struct {
+	mu   sync.RWMutex
+	abis []*abi.ABI
+}
+
+// add appends the ABIs to the set.
+func (ea *errorABIs) add(abis ...*abi.ABI) {
+	ea.mu.Lock()
+	defer ea.mu.Unlock()
+
+	ea.abis = append(ea.abis, abis...)
+}
+
+// list returns a copy of the ABIs in the set.
+func (ea *errorABIs) list() []*abi.ABI {
+	ea.mu.RLock()
+	defer ea.mu.RUnlock()
+
+	return append([]*abi.ABI(nil), ea.abis...)
+}
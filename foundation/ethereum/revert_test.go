@@ -0,0 +1,230 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestRevertError(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(1, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	_, contract := deployFixture(t, client, "Revert")
+
+	abiData, err := os.ReadFile("testdata/revert/Revert.abi")
+	if err != nil {
+		t.Fatalf("unable to read abi: %s", err)
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(string(abiData)))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %s", err)
+	}
+	client.RegisterErrorABI(&contractABI)
+
+	// send calls the method with a fixed gas limit, so the failing
+	// transaction is mined instead of failing gas estimation.
+	send := func(t *testing.T, method string, params ...any) *types.Transaction {
+		txOpts, err := client.NewTransactOpts(ctx, 100_000, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := contract.Transact(txOpts, method, params...)
+		if err != nil {
+			t.Fatalf("unable to send %s transaction: %s", method, err)
+		}
+
+		return tx
+	}
+
+	transact := func(t *testing.T, method string, params ...any) error {
+		_, err := client.WaitMined(ctx, send(t, method, params...))
+		return err
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reason replayed at failing block", func(t *testing.T) {
+		tx := send(t, "Enter")
+
+		// Opening the contract makes Enter succeed against the latest block,
+		// so the reason can only come from replaying the failing block.
+		if err := transact(t, "SetOpen", true); err != nil {
+			t.Fatalf("unable to open contract: %s", err)
+		}
+
+		_, err := client.WaitMined(ctx, tx)
+
+		var revertErr *ethereum.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("should get a revert error, got %v", err)
+		}
+
+		if revertErr.Reason != "contract is closed" {
+			t.Fatalf("wrong reason, got %q  exp %q", revertErr.Reason, "contract is closed")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("panic", func(t *testing.T) {
+		err := transact(t, "Divide", big.NewInt(0))
+
+		var revertErr *ethereum.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("should get a revert error, got %v", err)
+		}
+
+		if revertErr.PanicCode == nil || revertErr.PanicCode.Cmp(big.NewInt(0x12)) != 0 {
+			t.Fatalf("wrong panic code, got %v  exp %v", revertErr.PanicCode, 0x12)
+		}
+
+		if revertErr.PanicReason() != "division or modulo by zero" {
+			t.Fatalf("wrong panic reason, got %q", revertErr.PanicReason())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("custom error", func(t *testing.T) {
+		err := transact(t, "Withdraw", big.NewInt(5))
+
+		var revertErr *ethereum.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("should get a revert error, got %v", err)
+		}
+
+		if revertErr.CustomError != "InsufficientBalance" {
+			t.Fatalf("wrong custom error, got %q  exp %q", revertErr.CustomError, "InsufficientBalance")
+		}
+
+		if len(revertErr.Args) != 3 {
+			t.Fatalf("wrong number of args, got %d  exp %d", len(revertErr.Args), 3)
+		}
+
+		if account, ok := revertErr.Args[0].(common.Address); !ok || account != client.Address() {
+			t.Fatalf("wrong account arg, got %v  exp %s", revertErr.Args[0], client.Address())
+		}
+
+		if required, ok := revertErr.Args[2].(*big.Int); !ok || required.Cmp(big.NewInt(5)) != 0 {
+			t.Fatalf("wrong required arg, got %v  exp %d", revertErr.Args[2], 5)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("no data", func(t *testing.T) {
+		err := transact(t, "Fail")
+
+		var revertErr *ethereum.RevertError
+		if errors.As(err, &revertErr) {
+			t.Fatalf("revert without data should not be decoded, got %v", revertErr)
+		}
+
+		if err == nil || !strings.Contains(err.Error(), "execution reverted") {
+			t.Fatalf("should get an execution reverted error, got %v", err)
+		}
+	})
+}
+
+func TestCallRevert(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(1, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	_, contract := deployFixture(t, client, "Revert")
+
+	callOpts, err := client.NewCallOpts(ctx)
+	if err != nil {
+		t.Fatalf("unable to create call opts: %s", err)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reason", func(t *testing.T) {
+		err := contract.Call(callOpts, nil, "Enter")
+
+		revertErr, ok := ethereum.CallRevert(err)
+		if !ok {
+			t.Fatalf("should get a revert error, got %v", err)
+		}
+
+		if revertErr.Reason != "contract is closed" {
+			t.Fatalf("wrong reason, got %q  exp %q", revertErr.Reason, "contract is closed")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("not a revert", func(t *testing.T) {
+		if revertErr, ok := ethereum.CallRevert(errors.New("connection refused")); ok {
+			t.Fatalf("should not get a revert error, got %v", revertErr)
+		}
+	})
+}
+
+func TestDecodeRevert(t *testing.T) {
+	tt := []struct {
+		name string
+		data string
+		exp  string
+	}{
+		{
+			name: "error string",
+			data: "0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"6f6f707300000000000000000000000000000000000000000000000000000000",
+			exp: "execution reverted: oops",
+		},
+		{
+			name: "panic",
+			data: "0x4e487b71" + "0000000000000000000000000000000000000000000000000000000000000011",
+			exp:  "execution reverted: panic 0x11 (arithmetic underflow or overflow)",
+		},
+		{
+			name: "unknown selector",
+			data: "0xdeadbeef",
+			exp:  "execution reverted",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			revertErr := ethereum.DecodeRevert(common.FromHex(tc.data))
+			if revertErr.Error() != tc.exp {
+				t.Fatalf("wrong error, got %q  exp %q", revertErr.Error(), tc.exp)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package ethereum
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // ZeroHash represents a 0 value hashcode.
@@ -15,6 +21,212 @@ const ZeroHash = "0x000000000000000000000000000000000000000000000000000000000000
 // that the signature comes from the ethereum blockchain.
 const ethID = 27
 
+// signatureLength is the length of a signature in the [R || S || V] format.
+const signatureLength = 65
+
+// ethPrefix is the salt applied to a 32 byte hash before signing. It matches
+// the prefix used by the smart contracts when recovering an address.
+const ethPrefix = "\x19Ethereum Signed Message:\n32"
+
+// ErrInvalidSignature is returned when a signature is malformed or doesn't
+// match the expected address.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Sign ABI-encodes and hashes the values, applies the Ethereum salt, and signs
+// the result with the private key. This matches the contract side scheme of
+// keccak256(abi.encode(values...)) being passed to ecrecover after salting.
+// The returned signature is 65 bytes in the [R || S || V] format with V
+// normalized to 27 or 28.
+func Sign(privateKey *ecdsa.PrivateKey, values ...any) ([]byte, error) {
+	hash, err := Hash(values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return SignHash(privateKey, hash)
+}
+
+// SignHash applies the Ethereum salt to the hash and signs the result with the
+// private key. The returned signature has V normalized to 27 or 28.
+func SignHash(privateKey *ecdsa.PrivateKey, hash common.Hash) ([]byte, error) {
+	return signDigest(privateKey, SaltHash(hash))
+}
+
+// FromSignature recovers the address that signed the specified values.
+func FromSignature(sig []byte, values ...any) (common.Address, error) {
+	hash, err := Hash(values...)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return FromHashSignature(sig, hash)
+}
+
+// FromHashSignature recovers the address that signed the hash after the
+// Ethereum salt was applied. V may be either 0/1 or 27/28.
+func FromHashSignature(sig []byte, hash common.Hash) (common.Address, error) {
+	return recoverDigest(sig, SaltHash(hash))
+}
+
+// VerifySignature checks the specified address signed the values.
+func VerifySignature(address common.Address, sig []byte, values ...any) error {
+	signer, err := FromSignature(sig, values...)
+	if err != nil {
+		return err
+	}
+
+	if signer != address {
+		return fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidSignature, signer, address)
+	}
+
+	return nil
+}
+
+// SignatureString returns the hex encoded form of the signature.
+func SignatureString(sig []byte) string {
+	return hexutil.Encode(sig)
+}
+
+// ToSignatureBytes decodes the hex encoded form of a signature.
+func ToSignatureBytes(sig string) ([]byte, error) {
+	b, err := hexutil.Decode(sig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	if len(b) != signatureLength {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidSignature, len(b))
+	}
+
+	return b, nil
+}
+
+// signDigest signs the digest as is and normalizes V to 27 or 28.
+func signDigest(privateKey *ecdsa.PrivateKey, digest common.Hash) ([]byte, error) {
+	sig, err := crypto.Sign(digest.Bytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing digest: %w", err)
+	}
+
+	sig[crypto.RecoveryIDOffset] += ethID
+
+	return sig, nil
+}
+
+// recoverDigest recovers the address that signed the digest as is. V may be
+// either 0/1 or 27/28.
+func recoverDigest(sig []byte, digest common.Hash) (common.Address, error) {
+	if len(sig) != signatureLength {
+		return common.Address{}, fmt.Errorf("%w: length %d", ErrInvalidSignature, len(sig))
+	}
+
+	rsv := make([]byte, signatureLength)
+	copy(rsv, sig)
+
+	if rsv[crypto.RecoveryIDOffset] >= ethID {
+		rsv[crypto.RecoveryIDOffset] -= ethID
+	}
+
+	publicKey, err := crypto.SigToPub(digest.Bytes(), rsv)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// Hash ABI-encodes the values and returns the keccak256 hash of the result.
+// This matches keccak256(abi.encode(values...)) in Solidity.
+func Hash(values ...any) (common.Hash, error) {
+	data, err := ABIEncode(values...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(data), nil
+}
+
+// SaltHash applies the Ethereum salt to the hash, matching
+// keccak256(abi.encodePacked("\x19Ethereum Signed Message:\n32", hash)).
+func SaltHash(hash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte(ethPrefix), hash.Bytes())
+}
+
+// ABIEncode encodes the values using the standard ABI encoding, matching
+// abi.encode(values...) in Solidity. Strings, booleans, byte slices,
+// addresses, 32 byte hashes, big integers and the fixed size integer kinds
+// are supported. Integers are encoded as uint256 or int256.
+func ABIEncode(values ...any) ([]byte, error) {
+	args := make(abi.Arguments, len(values))
+	packed := make([]any, len(values))
+
+	for i, value := range values {
+		typ, v, err := abiType(value)
+		if err != nil {
+			return nil, fmt.Errorf("value[%d]: %w", i, err)
+		}
+
+		args[i] = abi.Argument{Type: typ}
+		packed[i] = v
+	}
+
+	data, err := args.Pack(packed...)
+	if err != nil {
+		return nil, fmt.Errorf("abi encoding: %w", err)
+	}
+
+	return data, nil
+}
+
+// abiType returns the ABI type for the value and the value converted to the
+// Go type the abi package expects for that type.
+func abiType(value any) (abi.Type, any, error) {
+	var solType string
+
+	switch v := value.(type) {
+	case string:
+		solType = "string"
+	case bool:
+		solType = "bool"
+	case []byte:
+		solType = "bytes"
+	case common.Address:
+		solType = "address"
+	case common.Hash:
+		solType, value = "bytes32", [32]byte(v)
+	case [32]byte:
+		solType = "bytes32"
+	case *big.Int:
+		solType = "uint256"
+		if v.Sign() < 0 {
+			solType = "int256"
+		}
+	case int:
+		solType, value = "int256", big.NewInt(int64(v))
+	case int64:
+		solType, value = "int256", big.NewInt(v)
+	case uint:
+		solType, value = "uint256", new(big.Int).SetUint64(uint64(v))
+	case uint64:
+		solType, value = "uint256", new(big.Int).SetUint64(v)
+	case uint32:
+		solType, value = "uint256", new(big.Int).SetUint64(uint64(v))
+	case uint8:
+		solType, value = "uint256", new(big.Int).SetUint64(uint64(v))
+	default:
+		return abi.Type{}, nil, fmt.Errorf("unsupported type %T", value)
+	}
+
+	typ, err := abi.NewType(solType, "", nil)
+	if err != nil {
+		return abi.Type{}, nil, err
+	}
+
+	return typ, value, nil
+}
+
 // /////////////////////////////////////////////////////////////////
 
 func PrivateKeyByKeyFile(keyFile, passphrase string) (*ecdsa.PrivateKey, error) {
@@ -0,0 +1,138 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	smart "github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+func TestABIEncode(t *testing.T) {
+	data, err := smart.ABIEncode("a", common.HexToAddress("0x01"), big.NewInt(1))
+	if err != nil {
+		t.Fatalf("unable to abi encode: %s", err)
+	}
+
+	exp := strings.Join([]string{
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"6100000000000000000000000000000000000000000000000000000000000000",
+	}, "")
+
+	if got := common.Bytes2Hex(data); got != exp {
+		t.Fatalf("wrong encoding, got %s  exp %s", got, exp)
+	}
+
+	if _, err := smart.ABIEncode(1.5); err == nil {
+		t.Fatal("should not be able to encode a float")
+	}
+}
+
+func TestSignature(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := smart.CreateSimulatedBackend(2, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	privateKey := backend.PrivateKeys[0]
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	const betID = "bet-1"
+	nonce := big.NewInt(3)
+
+	sig, err := smart.Sign(privateKey, betID, address, nonce)
+	if err != nil {
+		t.Fatalf("unable to sign: %s", err)
+	}
+
+	if len(sig) != 65 {
+		t.Fatalf("wrong signature length, got %d  exp %d", len(sig), 65)
+	}
+
+	if v := sig[64]; v != 27 && v != 28 {
+		t.Fatalf("v should be normalized to 27 or 28, got %d", v)
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("recover", func(t *testing.T) {
+		signer, err := smart.FromSignature(sig, betID, address, nonce)
+		if err != nil {
+			t.Fatalf("unable to recover address: %s", err)
+		}
+
+		if signer != address {
+			t.Fatalf("wrong signer, got %s  exp %s", signer, address)
+		}
+
+		if err := smart.VerifySignature(address, sig, betID, address, nonce); err != nil {
+			t.Fatalf("signature should verify: %s", err)
+		}
+
+		other := crypto.PubkeyToAddress(backend.PrivateKeys[1].PublicKey)
+		if err := smart.VerifySignature(other, sig, betID, address, nonce); !errors.Is(err, smart.ErrInvalidSignature) {
+			t.Fatalf("signature should not verify for another address: %v", err)
+		}
+
+		if err := smart.VerifySignature(address, sig, betID, address, big.NewInt(4)); !errors.Is(err, smart.ErrInvalidSignature) {
+			t.Fatalf("signature should not verify for another nonce: %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("hex round trip", func(t *testing.T) {
+		b, err := smart.ToSignatureBytes(smart.SignatureString(sig))
+		if err != nil {
+			t.Fatalf("unable to decode signature: %s", err)
+		}
+
+		if !strings.EqualFold(hexutil.Encode(b), hexutil.Encode(sig)) {
+			t.Fatalf("wrong signature, got %x  exp %x", b, sig)
+		}
+
+		if _, err := smart.ToSignatureBytes("0x1234"); !errors.Is(err, smart.ErrInvalidSignature) {
+			t.Fatalf("short signature should be rejected: %v", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	// The ecrecover precompile is what the contracts call after salting the
+	// hash, so recovering through it proves the signature layout matches.
+	t.Run("ecrecover precompile", func(t *testing.T) {
+		hash, err := smart.Hash(betID, address, nonce)
+		if err != nil {
+			t.Fatalf("unable to hash: %s", err)
+		}
+
+		input := make([]byte, 128)
+		copy(input[0:32], smart.SaltHash(hash).Bytes())
+		input[63] = sig[64]
+		copy(input[64:96], sig[:32])
+		copy(input[96:128], sig[32:64])
+
+		ecrecover := common.BytesToAddress([]byte{1})
+		out, err := backend.CallContract(ctx, ethereum.CallMsg{To: &ecrecover, Data: input}, nil)
+		if err != nil {
+			t.Fatalf("unable to call ecrecover: %s", err)
+		}
+
+		if signer := common.BytesToAddress(out); signer != address {
+			t.Fatalf("wrong signer from ecrecover, got %s  exp %s", signer, address)
+		}
+	})
+}
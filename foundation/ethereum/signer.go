@@ -0,0 +1,270 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer signs transactions and hashes for an account. The key material stays
+// with the implementation, so a client never needs to hold a private key.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() common.Address
+
+	// SignTx signs the transaction for the chain.
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// SignHash applies the Ethereum salt to the hash and signs the result,
+	// the same scheme as SignHash. The signature has V normalized to 27 or
+	// 28.
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// SignWith ABI-encodes and hashes the values like Sign, and signs the hash
+// with the signer.
+func SignWith(ctx context.Context, signer Signer, values ...any) ([]byte, error) {
+	hash, err := Hash(values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.SignHash(ctx, hash)
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// KeySigner signs with a private key held in memory.
+type KeySigner struct {
+	address    common.Address
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewKeySigner constructs a signer for the private key.
+func NewKeySigner(privateKey *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		privateKey: privateKey,
+	}
+}
+
+// Address returns the address of the private key.
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs the transaction with the private key.
+func (s *KeySigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+}
+
+// SignHash signs the salted hash with the private key.
+func (s *KeySigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return SignHash(s.privateKey, hash)
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// KeyStoreSigner signs with an account in a geth keystore. The account must
+// be unlocked in the keystore, signing fails with keystore.ErrLocked
+// otherwise.
+type KeyStoreSigner struct {
+	ks      *keystore.KeyStore
+	account accounts.Account
+}
+
+// NewKeyStoreSigner constructs a signer for the account in the keystore.
+func NewKeyStoreSigner(ks *keystore.KeyStore, address common.Address) (*KeyStoreSigner, error) {
+	account, err := ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("account %s: %w", address, err)
+	}
+
+	s := KeyStoreSigner{
+		ks:      ks,
+		account: account,
+	}
+
+	return &s, nil
+}
+
+// Address returns the address of the account.
+func (s *KeyStoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+// SignTx signs the transaction with the unlocked account.
+func (s *KeyStoreSigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.ks.SignTx(s.account, tx, chainID)
+}
+
+// SignHash signs the salted hash with the unlocked account.
+func (s *KeyStoreSigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	sig, err := s.ks.SignHash(s.account, SaltHash(hash).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("signing hash: %w", err)
+	}
+
+	sig[crypto.RecoveryIDOffset] += ethID
+
+	return sig, nil
+}
+
+// /////////////////////////////////////////////////////////////////
+
+// ClefSigner signs with an account managed by an external signer speaking the
+// Clef JSON-RPC API. Every request may need to be approved in the signer, so
+// calls block until it responds or the context is done. The signer's
+// responses are checked, it can't substitute a different transaction or sign
+// with a different account.
+type ClefSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewClefSigner connects to the external signer at the endpoint and checks it
+// manages the account.
+func NewClefSigner(ctx context.Context, endpoint string, address common.Address) (*ClefSigner, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("dialing signer: %w", err)
+	}
+
+	var addresses []common.Address
+	if err := client.CallContext(ctx, &addresses, "account_list"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("listing signer accounts: %w", err)
+	}
+
+	for _, managed := range addresses {
+		if managed == address {
+			s := ClefSigner{
+				client:  client,
+				address: address,
+			}
+
+			return &s, nil
+		}
+	}
+
+	client.Close()
+	return nil, fmt.Errorf("account %s is not managed by the signer", address)
+}
+
+// Close closes the connection to the external signer.
+func (s *ClefSigner) Close() {
+	s.client.Close()
+}
+
+// Address returns the address of the account.
+func (s *ClefSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx asks the external signer to sign the transaction.
+func (s *ClefSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+
+	case types.AccessListTxType:
+		accessList := tx.AccessList()
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = &accessList
+
+	case types.DynamicFeeTxType:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = &accessList
+
+	default:
+		return nil, fmt.Errorf("unsupported tx type %d", tx.Type())
+	}
+
+	var result struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := s.client.CallContext(ctx, &result, "account_signTransaction", &args); err != nil {
+		return nil, fmt.Errorf("signing transaction: %w", err)
+	}
+
+	var signed types.Transaction
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("decoding signed transaction: %w", err)
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+
+	if signer.Hash(&signed) != signer.Hash(tx) {
+		return nil, errors.New("signer returned a different transaction")
+	}
+
+	sender, err := types.Sender(signer, &signed)
+	if err != nil {
+		return nil, fmt.Errorf("recovering sender: %w", err)
+	}
+
+	if sender != s.address {
+		return nil, fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidSignature, sender, s.address)
+	}
+
+	return &signed, nil
+}
+
+// SignHash asks the external signer to sign the hash as text/plain data,
+// which the signer salts with the Ethereum prefix.
+func (s *ClefSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	address := common.NewMixedcaseAddress(s.address)
+
+	var sig hexutil.Bytes
+	if err := s.client.CallContext(ctx, &sig, "account_signData", accounts.MimetypeTextPlain, &address, hexutil.Encode(hash.Bytes())); err != nil {
+		return nil, fmt.Errorf("signing hash: %w", err)
+	}
+
+	if len(sig) != signatureLength {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidSignature, len(sig))
+	}
+
+	if sig[crypto.RecoveryIDOffset] < ethID {
+		sig[crypto.RecoveryIDOffset] += ethID
+	}
+
+	signer, err := FromHashSignature(sig, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer != s.address {
+		return nil, fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidSignature, signer, s.address)
+	}
+
+	return sig, nil
+}
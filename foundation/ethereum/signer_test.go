@@ -0,0 +1,263 @@
+package ethereum_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// clefService stands in for the account API of Clef, approving every
+// request. When tamper is set, the transactions it signs send one more wei
+// than requested.
+type clefService struct {
+	key    *ecdsa.PrivateKey
+	tamper bool
+}
+
+// Version implements account_version.
+func (s *clefService) Version() string {
+	return "6.1.0"
+}
+
+// List implements account_list.
+func (s *clefService) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+// SignTransaction implements account_signTransaction.
+func (s *clefService) SignTransaction(args apitypes.SendTxArgs, methodSelector *string) (map[string]any, error) {
+	if args.From.Address() != crypto.PubkeyToAddress(s.key.PublicKey) {
+		return nil, fmt.Errorf("unknown account %s", args.From.Address())
+	}
+
+	if s.tamper {
+		args.Value = hexutil.Big(*new(big.Int).Add(args.Value.ToInt(), big.NewInt(1)))
+	}
+
+	tx, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"raw": hexutil.Bytes(raw), "tx": tx}, nil
+}
+
+// SignData implements account_signData for text/plain data.
+func (s *clefService) SignData(contentType string, address common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if contentType != accounts.MimetypeTextPlain {
+		return nil, fmt.Errorf("unsupported content type %s", contentType)
+	}
+
+	sig, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+
+	return sig, nil
+}
+
+// startClef serves the stand-in signer over HTTP and returns its endpoint.
+func startClef(t *testing.T, service *clefService) string {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", service); err != nil {
+		t.Fatalf("unable to register signer: %s", err)
+	}
+
+	srv := httptest.NewServer(server)
+	t.Cleanup(func() {
+		srv.Close()
+		server.Stop()
+	})
+
+	return srv.URL
+}
+
+func TestSigners(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := ethereum.CreateSimulatedBackend(4, true, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	to := crypto.PubkeyToAddress(backend.PrivateKeys[3].PublicKey)
+
+	// /////////////////////////////////////////////////////////////
+
+	ks, err := ethereum.NewKeyStore(ethereum.KeyStoreConfig{
+		Dir:    t.TempDir(),
+		Scrypt: ethereum.ScryptLight,
+	})
+	if err != nil {
+		t.Fatalf("unable to create keystore: %s", err)
+	}
+
+	account, err := ks.ImportKey(backend.PrivateKeys[1], "123")
+	if err != nil {
+		t.Fatalf("unable to import key: %s", err)
+	}
+
+	if _, err := ks.Unlock(account.Address, "wrong"); err == nil {
+		t.Fatal("should fail to unlock with the wrong passphrase")
+	}
+
+	keyStoreSigner, err := ks.Unlock(account.Address, "123")
+	if err != nil {
+		t.Fatalf("unable to unlock account: %s", err)
+	}
+
+	clefSigner, err := ethereum.NewClefSigner(ctx, startClef(t, &clefService{key: backend.PrivateKeys[2]}), crypto.PubkeyToAddress(backend.PrivateKeys[2].PublicKey))
+	if err != nil {
+		t.Fatalf("unable to connect to signer: %s", err)
+	}
+	defer clefSigner.Close()
+
+	signers := []struct {
+		name   string
+		signer ethereum.Signer
+		key    *ecdsa.PrivateKey
+	}{
+		{"key", ethereum.NewKeySigner(backend.PrivateKeys[0]), backend.PrivateKeys[0]},
+		{"keystore", keyStoreSigner, backend.PrivateKeys[1]},
+		{"clef", clefSigner, backend.PrivateKeys[2]},
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	for _, tt := range signers {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := ethereum.NewSignerClient(backend, tt.signer)
+			if err != nil {
+				t.Fatalf("unable to create client: %s", err)
+			}
+
+			if exp := crypto.PubkeyToAddress(tt.key.PublicKey); client.Address() != exp {
+				t.Fatalf("wrong address, got %s  exp %s", client.Address(), exp)
+			}
+
+			txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+			if err != nil {
+				t.Fatalf("unable to create transaction opts: %s", err)
+			}
+
+			tx, err := transfer(ctx, client, txOpts, to)
+			if err != nil {
+				t.Fatalf("unable to send transaction: %s", err)
+			}
+
+			if _, err := client.WaitMined(ctx, tx); err != nil {
+				t.Fatalf("waiting for transaction: %s", err)
+			}
+
+			dynamicTx, err := tt.signer.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{
+				ChainID:   backend.ChainID(),
+				Nonce:     tx.Nonce() + 1,
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(1_000_000_000),
+				Gas:       21_000,
+				To:        &to,
+			}), backend.ChainID())
+			if err != nil {
+				t.Fatalf("unable to sign dynamic fee transaction: %s", err)
+			}
+
+			if sender, err := types.Sender(types.LatestSignerForChainID(backend.ChainID()), dynamicTx); err != nil || sender != client.Address() {
+				t.Fatalf("wrong sender, got %s %v  exp %s", sender, err, client.Address())
+			}
+
+			// The signature must match the one made with the key directly.
+			sig, err := client.Sign(ctx, "bet", client.Address(), big.NewInt(0))
+			if err != nil {
+				t.Fatalf("unable to sign: %s", err)
+			}
+
+			if err := ethereum.VerifySignature(client.Address(), sig, "bet", client.Address(), big.NewInt(0)); err != nil {
+				t.Fatalf("signature should verify: %s", err)
+			}
+
+			exp, err := ethereum.Sign(tt.key, "bet", client.Address(), big.NewInt(0))
+			if err != nil {
+				t.Fatalf("unable to sign with key: %s", err)
+			}
+
+			if hexutil.Encode(sig) != hexutil.Encode(exp) {
+				t.Fatalf("wrong signature, got %s  exp %s", hexutil.Encode(sig), hexutil.Encode(exp))
+			}
+		})
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("keystore locked", func(t *testing.T) {
+		ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+
+		account, err := ks.ImportECDSA(backend.PrivateKeys[0], "123")
+		if err != nil {
+			t.Fatalf("unable to import key: %s", err)
+		}
+
+		signer, err := ethereum.NewKeyStoreSigner(ks, account.Address)
+		if err != nil {
+			t.Fatalf("unable to create signer: %s", err)
+		}
+
+		if _, err := signer.SignHash(ctx, common.Hash{}); !errors.Is(err, keystore.ErrLocked) {
+			t.Fatalf("should fail for a locked account, got %v", err)
+		}
+
+		if _, err := ethereum.NewKeyStoreSigner(ks, to); err == nil {
+			t.Fatal("should fail for an account outside the keystore")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("clef checks", func(t *testing.T) {
+		address := crypto.PubkeyToAddress(backend.PrivateKeys[2].PublicKey)
+
+		if _, err := ethereum.NewClefSigner(ctx, startClef(t, &clefService{key: backend.PrivateKeys[2]}), to); err == nil {
+			t.Fatal("should fail for an account the signer doesn't manage")
+		}
+
+		signer, err := ethereum.NewClefSigner(ctx, startClef(t, &clefService{key: backend.PrivateKeys[2], tamper: true}), address)
+		if err != nil {
+			t.Fatalf("unable to connect to signer: %s", err)
+		}
+		defer signer.Close()
+
+		tx := types.NewTx(&types.LegacyTx{
+			GasPrice: big.NewInt(1),
+			Gas:      21_000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		})
+
+		if _, err := signer.SignTx(ctx, tx, backend.ChainID()); err == nil {
+			t.Fatal("should reject a transaction the signer changed")
+		}
+	})
+}
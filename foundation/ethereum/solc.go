@@ -0,0 +1,95 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Compiled represents a contract compiled by solc.
+type Compiled struct {
+	Name   string
+	ABI    string
+	Bin    string // Hex encoded with the 0x prefix abigen uses
+	Layout StorageLayout
+}
+
+// MetaData returns the ABI and bytecode of the contract in the form the
+// generated bindings use.
+func (c Compiled) MetaData() *bind.MetaData {
+	return &bind.MetaData{ABI: c.ABI, Bin: c.Bin}
+}
+
+// Compile compiles the named contract in the source file, producing its ABI,
+// bytecode and storage layout. Solc is the command to run, such as
+// []string{"solc"}, and is given the same flags as the makefile builds use.
+func Compile(ctx context.Context, solc []string, source string, contract string) (Compiled, error) {
+	if len(solc) == 0 {
+		return Compiled{}, fmt.Errorf("compiling %s: no solc command", source)
+	}
+
+	dir, err := os.MkdirTemp("", "solc")
+	if err != nil {
+		return Compiled{}, fmt.Errorf("compiling %s: %w", source, err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := runSolc(ctx, solc, "--abi", "--bin", source, "-o", dir, "--overwrite"); err != nil {
+		return Compiled{}, err
+	}
+
+	abiData, err := os.ReadFile(filepath.Join(dir, contract+".abi"))
+	if err != nil {
+		return Compiled{}, fmt.Errorf("contract %s not found in %s: %w", contract, source, err)
+	}
+
+	binData, err := os.ReadFile(filepath.Join(dir, contract+".bin"))
+	if err != nil {
+		return Compiled{}, fmt.Errorf("contract %s not found in %s: %w", contract, source, err)
+	}
+
+	out, err := runSolc(ctx, solc, "--storage-layout", source)
+	if err != nil {
+		return Compiled{}, err
+	}
+
+	layouts, err := ParseStorageLayouts(out)
+	if err != nil {
+		return Compiled{}, fmt.Errorf("compiling %s: %w", source, err)
+	}
+
+	layout, exists := layouts[contract]
+	if !exists {
+		return Compiled{}, fmt.Errorf("no storage layout for contract %s in %s", contract, source)
+	}
+
+	c := Compiled{
+		Name:   contract,
+		ABI:    strings.TrimSpace(string(abiData)),
+		Bin:    "0x" + strings.TrimPrefix(strings.TrimSpace(string(binData)), "0x"),
+		Layout: layout,
+	}
+
+	return c, nil
+}
+
+// runSolc runs solc with the arguments, returning its output.
+func runSolc(ctx context.Context, solc []string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, solc[0], append(solc[1:], args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running %s: %w: %s", strings.Join(solc, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
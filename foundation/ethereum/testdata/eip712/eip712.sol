@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.0;
+
+// EIP712 is a test fixture implementing the contract side of EIP-712 in the
+// same way as OpenZeppelin's _hashTypedDataV4, using a Bet authorization as
+// the typed struct.
+contract EIP712 {
+    // TYPE_HASH is the type hash of the EIP712Domain struct.
+    bytes32 private constant TYPE_HASH = keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)");
+
+    // BET_TYPEHASH is the type hash of the Bet struct.
+    bytes32 private constant BET_TYPEHASH = keccak256("Bet(string betID,address participant,uint256 nonce)");
+
+    // DomainSeparator is calculated when the contract is deployed.
+    bytes32 public DomainSeparator;
+
+    // constructor is called when the contract is deployed.
+    constructor(string memory name, string memory version) {
+        DomainSeparator = keccak256(abi.encode(TYPE_HASH, keccak256(bytes(name)), keccak256(bytes(version)), block.chainid, address(this)));
+    }
+
+    // HashBet returns hashStruct for a Bet.
+    function HashBet(string memory betID, address participant, uint256 nonce) public pure returns (bytes32) {
+        return keccak256(abi.encode(BET_TYPEHASH, keccak256(bytes(betID)), participant, nonce));
+    }
+
+    // HashTypedDataV4 returns the digest that is signed for a Bet.
+    function HashTypedDataV4(string memory betID, address participant, uint256 nonce) public view returns (bytes32) {
+        return keccak256(abi.encodePacked("\x19\x01", DomainSeparator, HashBet(betID, participant, nonce)));
+    }
+
+    // Recover returns the address that signed the Bet.
+    function Recover(string memory betID, address participant, uint256 nonce, bytes calldata sig) public view returns (address) {
+        if (sig.length != 65) {
+            revert("invalid signature length");
+        }
+
+        bytes32 r = bytes32(sig[:32]);
+        bytes32 s = bytes32(sig[32:64]);
+        uint8   v = uint8(sig[64]);
+
+        return ecrecover(HashTypedDataV4(betID, participant, nonce), v, r, s);
+    }
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.0;
+
+// Events is a test fixture that emits an event with both indexed and
+// non-indexed arguments.
+contract Events {
+
+    // ValueSet is emitted every time a value is set.
+    event ValueSet(address indexed sender, string key, uint256 value);
+
+    // Values holds the values that have been set.
+    mapping (string => uint256) public Values;
+
+    // Set stores the value and emits ValueSet.
+    function Set(string memory key, uint256 value) public {
+        Values[key] = value;
+        emit ValueSet(msg.sender, key, value);
+    }
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.0;
+
+// Revert is a test fixture that fails in each of the ways the Solidity
+// compiler can encode a revert.
+contract Revert {
+
+    // InsufficientBalance is a custom error with arguments.
+    error InsufficientBalance(address account, uint256 available, uint256 required);
+
+    // Open controls if Enter succeeds, so a failed Enter can be made to pass
+    // when replayed against a later block.
+    bool public Open;
+
+    // Counter is changed by the successful calls.
+    uint256 public Counter;
+
+    // SetOpen opens or closes the contract.
+    function SetOpen(bool open) public {
+        Open = open;
+    }
+
+    // Enter reverts with an Error(string) reason while the contract is closed.
+    function Enter() public {
+        require(Open, "contract is closed");
+        Counter++;
+    }
+
+    // Divide panics with a division by zero when the divisor is zero.
+    function Divide(uint256 divisor) public {
+        Counter = Counter / divisor;
+    }
+
+    // Withdraw reverts with the InsufficientBalance custom error.
+    function Withdraw(uint256 amount) public view {
+        revert InsufficientBalance(msg.sender, Counter, amount);
+    }
+
+    // Fail reverts without a reason.
+    function Fail() public pure {
+        revert();
+    }
+}
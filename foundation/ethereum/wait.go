@@ -0,0 +1,244 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// defaultPollInterval is how often the node is polled when no interval is
+// specified in the WaitOpts.
+const defaultPollInterval = time.Second
+
+// WaitStatus represents the final state of a transaction that was waited on.
+type WaitStatus int
+
+// Set of final states a transaction that was waited on can end up in.
+const (
+	// StatusConfirmed means the transaction was mined successfully and
+	// reached the required number of confirmations.
+	StatusConfirmed WaitStatus = iota + 1
+
+	// StatusReverted means the transaction, or the transaction that
+	// replaced it, was mined but its execution failed.
+	StatusReverted
+
+	// StatusReplaced means a different transaction using the same nonce
+	// was mined successfully, either one sent with SpeedUp or Cancel or one
+	// this client doesn't know about.
+	StatusReplaced
+
+	// StatusDropped means the node no longer knows about the transaction
+	// and its nonce hasn't been used.
+	StatusDropped
+)
+
+// String implements the fmt.Stringer interface.
+func (s WaitStatus) String() string {
+	switch s {
+	case StatusConfirmed:
+		return "confirmed"
+	case StatusReverted:
+		return "reverted"
+	case StatusReplaced:
+		return "replaced"
+	case StatusDropped:
+		return "dropped"
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
+// WaitOpts is the collection of options to fine tune waiting for a
+// transaction. The zero value waits for a single confirmation, polling
+// every second until the context is done.
+type WaitOpts struct {
+	Confirmations uint64        // Number of blocks, including the one the tx is mined in (0 = 1)
+	PollInterval  time.Duration // How often the node is polled (0 = 1s)
+	Timeout       time.Duration // Overall deadline for the wait (0 = only the context)
+	ReorgCheck    bool          // Re-verify the receipt's block is still canonical on every poll
+}
+
+// WaitResult represents the outcome of waiting for a transaction.
+type WaitResult struct {
+	Status WaitStatus
+
+	// Transaction and Receipt are the transaction in the replacement set that
+	// was mined and its receipt. They are nil when the transaction was dropped
+	// or replaced by a transaction this client doesn't know about.
+	Transaction *types.Transaction
+	Receipt     *types.Receipt
+
+	// Replaced is true when the mined transaction isn't the one waited on,
+	// so a reverted replacement can be told apart from a reverted original.
+	Replaced bool
+
+	// Confirmations is the number of blocks, including the one the mined
+	// transaction is in, on the canonical chain when the wait finished.
+	Confirmations uint64
+
+	// Err is the error extracted from a reverted transaction, which is a
+	// *RevertError if the contract reverted.
+	Err error
+}
+
+// WaitConfirmed waits for the transaction, or any transaction that replaced it
+// using SpeedUp or Cancel, to reach a final state. A mined transaction is only
+// reported once it has the required number of confirmations. An error is only
+// returned if the node can't be queried or the wait timed out.
+func (c *Client) WaitConfirmed(ctx context.Context, tx *types.Transaction, opts WaitOpts) (*WaitResult, error) {
+	if opts.Confirmations == 0 {
+		opts.Confirmations = 1
+	}
+
+	if opts.PollInterval == 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	c.replacements.wait(tx.Nonce())
+	defer c.replacements.release(tx.Nonce())
+
+	queryTicker := time.NewTicker(opts.PollInterval)
+	defer queryTicker.Stop()
+
+	var receipt *types.Receipt
+	var mined *types.Transaction
+
+	for {
+		var err error
+		receipt, mined, err = c.pollReceipt(ctx, tx, receipt, mined, opts.ReorgCheck)
+		if err != nil {
+			return nil, err
+		}
+
+		switch receipt {
+		case nil:
+			status, err := c.unminedStatus(ctx, tx)
+			if err != nil {
+				return nil, err
+			}
+
+			if status != 0 {
+				c.replacements.finish(tx.Nonce())
+				return &WaitResult{Status: status, Replaced: status == StatusReplaced}, nil
+			}
+
+		default:
+			head, err := c.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving latest header: %w", err)
+			}
+
+			depth := big.NewInt(0).Sub(head.Number, receipt.BlockNumber).Uint64() + 1
+			if depth >= opts.Confirmations {
+				c.replacements.finish(tx.Nonce())
+				return c.waitResult(ctx, tx, receipt, mined, depth), nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for tx %s: %w", tx.Hash(), ctx.Err())
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// pollReceipt looks for a receipt for the replacement set of the transaction
+// if one hasn't been found yet. With the reorg check enabled, the receipt is
+// retrieved again and discarded if its block is no longer canonical.
+func (c *Client) pollReceipt(ctx context.Context, tx *types.Transaction, receipt *types.Receipt, mined *types.Transaction, reorgCheck bool) (*types.Receipt, *types.Transaction, error) {
+	if receipt != nil && !reorgCheck {
+		return receipt, mined, nil
+	}
+
+	receipt, mined = c.findReceipt(ctx, tx)
+	if receipt == nil || !reorgCheck {
+		return receipt, mined, nil
+	}
+
+	header, err := c.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving header %v: %w", receipt.BlockNumber, err)
+	}
+
+	if header.Hash() != receipt.BlockHash {
+		log.Debug("Receipt block is no longer canonical", "hash", mined.Hash(), "block", receipt.BlockHash)
+		return nil, nil, nil
+	}
+
+	return receipt, mined, nil
+}
+
+// unminedStatus determines if a transaction without a receipt is still
+// pending. A zero status is returned if it is, otherwise the transaction was
+// either replaced by one the client doesn't know about or dropped.
+func (c *Client) unminedStatus(ctx context.Context, tx *types.Transaction) (WaitStatus, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(c.Backend.ChainID()), tx)
+	if err != nil {
+		return 0, fmt.Errorf("retrieving sender: %w", err)
+	}
+
+	nonce, err := c.NonceAt(ctx, from, nil)
+	if err != nil {
+		return 0, fmt.Errorf("retrieving nonce: %w", err)
+	}
+
+	if nonce > tx.Nonce() {
+		// The nonce could have been used by one of the transactions in the
+		// replacement set since the receipts were checked.
+		if receipt, _ := c.findReceipt(ctx, tx); receipt != nil {
+			return 0, nil
+		}
+
+		return StatusReplaced, nil
+	}
+
+	// The transaction is only dropped when the node doesn't know any of the
+	// candidates, any other error leaves it unknown whether they're pending.
+	for _, candidate := range c.replacements.candidates(tx) {
+		_, _, err := c.TransactionByHash(ctx, candidate.Hash())
+		switch {
+		case err == nil:
+			return 0, nil
+
+		case !errors.Is(err, ethereum.NotFound):
+			return 0, fmt.Errorf("retrieving transaction %s: %w", candidate.Hash(), err)
+		}
+	}
+
+	return StatusDropped, nil
+}
+
+// waitResult constructs the result for a mined transaction.
+func (c *Client) waitResult(ctx context.Context, tx *types.Transaction, receipt *types.Receipt, mined *types.Transaction, depth uint64) *WaitResult {
+	result := WaitResult{
+		Status:        StatusConfirmed,
+		Transaction:   mined,
+		Receipt:       receipt,
+		Confirmations: depth,
+		Replaced:      mined.Hash() != tx.Hash(),
+	}
+
+	switch {
+	case receipt.Status == types.ReceiptStatusFailed:
+		result.Status = StatusReverted
+		result.Err = c.extractError(ctx, mined, receipt.BlockNumber)
+
+	case result.Replaced:
+		result.Status = StatusReplaced
+	}
+
+	return &result
+}
@@ -0,0 +1,403 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/adamwoolhether/smartcontract/foundation/ethereum"
+)
+
+// revertCode is the init code of a contract whose runtime code reverts every
+// call without a reason: PUSH1 0 PUSH1 0 REVERT.
+var revertCode = common.FromHex("0x6460006000fd6000526005601bf3")
+
+type waitResponse struct {
+	result *ethereum.WaitResult
+	err    error
+}
+
+// lookupFailure fails every transaction lookup with the error, like a node
+// that can't be reached.
+type lookupFailure struct {
+	ethereum.Backend
+	err error
+}
+
+// TransactionByHash fails with the lookup error.
+func (lf lookupFailure) TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error) {
+	return nil, false, lf.err
+}
+
+// waitAsync waits for the transaction in a separate goroutine so the test
+// can keep mining blocks.
+func waitAsync(ctx context.Context, client *ethereum.Client, tx *types.Transaction, opts ethereum.WaitOpts) <-chan waitResponse {
+	ch := make(chan waitResponse, 1)
+
+	go func() {
+		result, err := client.WaitConfirmed(ctx, tx, opts)
+		ch <- waitResponse{result: result, err: err}
+	}()
+
+	return ch
+}
+
+func TestWaitConfirmed(t *testing.T) {
+	ctx := context.Background()
+
+	// Auto commit is disabled so the test controls when blocks are mined.
+	backend, err := ethereum.CreateSimulatedBackend(2, false, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("unable to create simulated backend: %s", err)
+	}
+	defer backend.Close()
+
+	client, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	receiver, err := ethereum.NewClient(backend, backend.PrivateKeys[1])
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	to := receiver.Address()
+
+	send := func(t *testing.T) *types.Transaction {
+		txOpts, err := client.NewTransactOpts(ctx, 21_000, big.NewInt(0), big.NewFloat(1))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, to)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		return tx
+	}
+
+	opts := ethereum.WaitOpts{
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      10 * time.Second,
+	}
+
+	// deployReverter deploys a contract that reverts every call and returns
+	// its address.
+	deployReverter := func(t *testing.T) common.Address {
+		t.Helper()
+
+		txOpts, err := client.NewTransactOpts(ctx, 100_000, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		deploy, err := txOpts.Signer(client.Address(), types.NewTx(&types.LegacyTx{
+			GasPrice: txOpts.GasPrice,
+			Gas:      txOpts.GasLimit,
+			Value:    big.NewInt(0),
+			Data:     revertCode,
+		}))
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %s", err)
+		}
+
+		if err := client.SendTransaction(ctx, deploy); err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+		backend.Commit()
+
+		result, err := client.WaitConfirmed(ctx, deploy, opts)
+		if err != nil {
+			t.Fatalf("waiting for deployment: %s", err)
+		}
+
+		return result.Receipt.ContractAddress
+	}
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("confirmations", func(t *testing.T) {
+		tx := send(t)
+		backend.Commit()
+
+		opts := opts
+		opts.Confirmations = 3
+		ch := waitAsync(ctx, client, tx, opts)
+
+		for i := 0; i < 2; i++ {
+			select {
+			case resp := <-ch:
+				t.Fatalf("wait should not finish with %d confirmations, got %+v", i+1, resp)
+			case <-time.After(50 * time.Millisecond):
+			}
+			backend.Commit()
+		}
+
+		resp := <-ch
+		if resp.err != nil {
+			t.Fatalf("waiting for transaction: %s", resp.err)
+		}
+
+		if resp.result.Status != ethereum.StatusConfirmed {
+			t.Fatalf("wrong status, got %s  exp %s", resp.result.Status, ethereum.StatusConfirmed)
+		}
+
+		if resp.result.Confirmations != 3 {
+			t.Fatalf("wrong number of confirmations, got %d  exp %d", resp.result.Confirmations, 3)
+		}
+
+		if resp.result.Receipt.TxHash != tx.Hash() {
+			t.Fatalf("wrong receipt, got %s  exp %s", resp.result.Receipt.TxHash, tx.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reverted", func(t *testing.T) {
+		contract := deployReverter(t)
+
+		txOpts, err := client.NewTransactOpts(ctx, 50_000, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, contract)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+		backend.Commit()
+
+		result, err := client.WaitConfirmed(ctx, tx, opts)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if result.Status != ethereum.StatusReverted {
+			t.Fatalf("wrong status, got %s  exp %s", result.Status, ethereum.StatusReverted)
+		}
+
+		if result.Err == nil {
+			t.Fatal("reverted transaction should have an error")
+		}
+
+		if result.Replaced {
+			t.Fatal("original transaction should not be reported as replaced")
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reverted replacement", func(t *testing.T) {
+		contract := deployReverter(t)
+
+		txOpts, err := client.NewTransactOpts(ctx, 50_000, big.NewInt(0), big.NewFloat(0))
+		if err != nil {
+			t.Fatalf("unable to create transaction opts: %s", err)
+		}
+
+		tx, err := transfer(ctx, client, txOpts, contract)
+		if err != nil {
+			t.Fatalf("unable to send transaction: %s", err)
+		}
+
+		fast, err := client.SpeedUp(ctx, tx, 0)
+		if err != nil {
+			t.Fatalf("unable to speed up transaction: %s", err)
+		}
+		backend.Commit()
+
+		result, err := client.WaitConfirmed(ctx, tx, opts)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if result.Status != ethereum.StatusReverted {
+			t.Fatalf("wrong status, got %s  exp %s", result.Status, ethereum.StatusReverted)
+		}
+
+		if result.Err == nil {
+			t.Fatal("reverted replacement should have an error")
+		}
+
+		if !result.Replaced || result.Transaction.Hash() != fast.Hash() {
+			t.Fatalf("replacement should be reported, got %s  exp %s", result.Transaction.Hash(), fast.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("replaced", func(t *testing.T) {
+		tx := send(t)
+
+		fast, err := client.SpeedUp(ctx, tx, 0)
+		if err != nil {
+			t.Fatalf("unable to speed up transaction: %s", err)
+		}
+		backend.Commit()
+
+		result, err := client.WaitConfirmed(ctx, tx, opts)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if result.Status != ethereum.StatusReplaced {
+			t.Fatalf("wrong status, got %s  exp %s", result.Status, ethereum.StatusReplaced)
+		}
+
+		if !result.Replaced || result.Transaction.Hash() != fast.Hash() || result.Receipt.TxHash != fast.Hash() {
+			t.Fatalf("replacement should be reported, got %s  exp %s", result.Transaction.Hash(), fast.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("replaced by unknown", func(t *testing.T) {
+		tx := send(t)
+
+		// A second client for the same account doesn't share the
+		// replacement set, so the first client can't find a receipt.
+		other, err := ethereum.NewClient(backend, backend.PrivateKeys[0])
+		if err != nil {
+			t.Fatalf("unable to create client: %s", err)
+		}
+
+		if _, err := other.Cancel(ctx, tx); err != nil {
+			t.Fatalf("unable to cancel transaction: %s", err)
+		}
+		backend.Commit()
+
+		result, err := client.WaitConfirmed(ctx, tx, opts)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if result.Status != ethereum.StatusReplaced {
+			t.Fatalf("wrong status, got %s  exp %s", result.Status, ethereum.StatusReplaced)
+		}
+
+		if result.Receipt != nil {
+			t.Fatalf("unknown replacement should not have a receipt, got %s", result.Receipt.TxHash)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("reorg", func(t *testing.T) {
+		tx := send(t)
+		backend.Commit()
+
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			t.Fatalf("unable to retrieve receipt: %s", err)
+		}
+
+		header, err := backend.HeaderByHash(ctx, receipt.BlockHash)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+
+		opts := opts
+		opts.Confirmations = 2
+		opts.ReorgCheck = true
+		ch := waitAsync(ctx, client, tx, opts)
+
+		// Replace the block the transaction was mined in with a longer
+		// chain that mines it in a different block.
+		if err := backend.Fork(ctx, header.ParentHash); err != nil {
+			t.Fatalf("unable to fork: %s", err)
+		}
+		backend.Commit()
+
+		if err := backend.SendTransaction(ctx, tx); err != nil {
+			t.Fatalf("unable to resend transaction: %s", err)
+		}
+		backend.Commit()
+		backend.Commit()
+
+		resp := <-ch
+		if resp.err != nil {
+			t.Fatalf("waiting for transaction: %s", resp.err)
+		}
+
+		if resp.result.Status != ethereum.StatusConfirmed {
+			t.Fatalf("wrong status, got %s  exp %s", resp.result.Status, ethereum.StatusConfirmed)
+		}
+
+		if resp.result.Receipt.BlockHash == receipt.BlockHash {
+			t.Fatalf("receipt from the reorged block should be discarded, got %s", resp.result.Receipt.BlockHash)
+		}
+
+		canonical, err := client.HeaderByNumber(ctx, resp.result.Receipt.BlockNumber)
+		if err != nil {
+			t.Fatalf("unable to retrieve header: %s", err)
+		}
+
+		if canonical.Hash() != resp.result.Receipt.BlockHash {
+			t.Fatalf("receipt should be in the canonical chain, got %s  exp %s", resp.result.Receipt.BlockHash, canonical.Hash())
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("timeout", func(t *testing.T) {
+		tx := send(t)
+
+		opts := opts
+		opts.Timeout = 50 * time.Millisecond
+
+		if _, err := client.WaitConfirmed(ctx, tx, opts); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("wait should time out, got %v", err)
+		}
+
+		backend.Commit()
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("dropped", func(t *testing.T) {
+		tx := send(t)
+		backend.Rollback()
+
+		result, err := client.WaitConfirmed(ctx, tx, opts)
+		if err != nil {
+			t.Fatalf("waiting for transaction: %s", err)
+		}
+
+		if result.Status != ethereum.StatusDropped {
+			t.Fatalf("wrong status, got %s  exp %s", result.Status, ethereum.StatusDropped)
+		}
+
+		if err := client.Nonces().Resync(ctx); err != nil {
+			t.Fatalf("unable to resync nonces: %s", err)
+		}
+	})
+
+	// /////////////////////////////////////////////////////////////
+
+	t.Run("lookup error", func(t *testing.T) {
+		tx := send(t)
+		backend.Rollback()
+
+		lookupErr := errors.New("connection refused")
+
+		failing, err := ethereum.NewClient(lookupFailure{Backend: backend, err: lookupErr}, backend.PrivateKeys[0])
+		if err != nil {
+			t.Fatalf("unable to create client: %s", err)
+		}
+
+		// A failed lookup doesn't mean the transaction is gone.
+		if _, err := failing.WaitConfirmed(ctx, tx, opts); !errors.Is(err, lookupErr) {
+			t.Fatalf("wait should fail with the lookup error, got %v", err)
+		}
+
+		if err := client.Nonces().Resync(ctx); err != nil {
+			t.Fatalf("unable to resync nonces: %s", err)
+		}
+	})
+}
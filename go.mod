@@ -5,6 +5,8 @@ go 1.20
 require (
 	github.com/DeOne4eg/eth-unit-converter v0.2.0
 	github.com/ethereum/go-ethereum v1.11.2
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/term v0.5.0
 )
 
 require (
@@ -57,7 +59,6 @@ require (
 	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7 // indirect
 	github.com/tklauser/go-sysconf v0.3.11 // indirect
 	github.com/tklauser/numcpus v0.6.0 // indirect
-	github.com/tyler-smith/go-bip39 v1.1.0 // indirect
 	github.com/yusufpapurcu/wmi v1.2.2 // indirect
 	golang.org/x/crypto v0.4.0 // indirect
 	golang.org/x/exp v0.0.0-20230206171751-46f607a40771 // indirect